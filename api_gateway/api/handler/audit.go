@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/user_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuditEvents godoc
+// @Router /audit/list [get]
+// @Summary Get the audit log
+// @Description Get the recorded create, update and delete calls, newest first. Sensitive fields are redacted.
+// @Security BearerAuth
+// @Tags audit
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param actor_id query string false "ID of the user who made the change"
+// @Param resource query string false "user, session, user_settings, post, post_attachment ..."
+// @Param target_id query string false "ID of the changed resource"
+// @Param method query string false "RPC method, e.g. Update"
+// @Param from query string false "from (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "to (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} user_service.GetListAuditEventResponse
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetAuditEvents(ctx *gin.Context) {
+	var (
+		req user_service.GetListAuditEventRequest
+	)
+
+	if ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only admins can view the audit log", http.StatusForbidden)
+		return
+	}
+
+	page, err := strconv.ParseUint(ctx.DefaultQuery("page", "1"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid page", 400)
+		return
+	}
+
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	req.Page = page
+	req.Limit = limit
+	req.ActorId = ctx.Query("actor_id")
+	req.Resource = ctx.Query("resource")
+	req.TargetId = ctx.Query("target_id")
+	req.Method = ctx.Query("method")
+	req.From = ctx.Query("from")
+	req.To = ctx.Query("to")
+
+	resp, err := h.grpcClient.AuditService().GetList(ctx, &req)
+	if h.HandleDbError(ctx, err, "Error getting audit events") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
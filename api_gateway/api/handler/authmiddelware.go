@@ -13,6 +13,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// authUserKey is the context key of the id of the user whose token was verified.
+const authUserKey = "auth_user_id"
+
 func (h *handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
@@ -58,6 +61,16 @@ func (h *handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 				for key, value := range claims {
 					c.Request.Header.Set(key, fmt.Sprintf("%v", value))
 				}
+
+				// unlike the headers this can not be sent by the client
+				c.Set(authUserKey, fmt.Sprintf("%v", claims["sub"]))
+
+				// Let the services know who is calling, the audit log relies on it
+				appendOutgoing(c,
+					"user_id", fmt.Sprintf("%v", claims["sub"]),
+					"user_role", userRole,
+					"session_id", sessionID,
+				)
 			}
 		}
 
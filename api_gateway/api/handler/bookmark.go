@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+	"user_api_gateway/genproto/user_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookmarkPost godoc
+// @Router /post/{id}/bookmark [post]
+// @Summary Bookmark a post
+// @Description Save a post for later, and add it to a collection of the current user when collection_id is set.
+// @Description Bookmarking a post again changes nothing.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param bookmark body post_service.BookmarkRequest false "Bookmark, only collection_id is used"
+// @Success 200 {object} user_service.SuccessResponse
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) BookmarkPost(ctx *gin.Context) {
+	var (
+		body = &post_service.BookmarkRequest{}
+	)
+
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(body); err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+			return
+		}
+	}
+
+	_, err := h.grpcClient.BookmarkService().Create(ctx, &post_service.BookmarkRequest{
+		UserId:       ctx.GetHeader("sub"),
+		PostId:       ctx.Param("id"),
+		CollectionId: body.CollectionId,
+	})
+	if h.HandleDbError(ctx, err, "Error bookmarking post") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user_service.SuccessResponse{
+		Message: "Post bookmarked successfully",
+	})
+}
+
+// UnbookmarkPost godoc
+// @Router /post/{id}/bookmark [delete]
+// @Summary Remove a bookmark
+// @Description Remove a post from a collection, or without collection_id remove the bookmark and the post from every collection.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param collection_id query string false "collection_id"
+// @Success 200 {object} user_service.SuccessResponse
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) UnbookmarkPost(ctx *gin.Context) {
+	_, err := h.grpcClient.BookmarkService().Delete(ctx, &post_service.BookmarkRequest{
+		UserId:       ctx.GetHeader("sub"),
+		PostId:       ctx.Param("id"),
+		CollectionId: ctx.Query("collection_id"),
+	})
+	if h.HandleDbError(ctx, err, "Error removing bookmark") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user_service.SuccessResponse{
+		Message: "Bookmark removed successfully",
+	})
+}
+
+// GetBookmarks godoc
+// @Router /bookmarks/list [get]
+// @Summary Get my bookmarks
+// @Description Get the posts the current user bookmarked, most recently saved first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.PostList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetBookmarks(ctx *gin.Context) {
+	h.getBookmarks(ctx, "")
+}
+
+// GetBookmarkCollectionPosts godoc
+// @Router /bookmarks/collections/{id}/posts [get]
+// @Summary Get the posts of a collection
+// @Description Get the posts of a collection of the current user or of a shared collection, most recently saved first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.PostList
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) GetBookmarkCollectionPosts(ctx *gin.Context) {
+	h.getBookmarks(ctx, ctx.Param("id"))
+}
+
+func (h *handler) getBookmarks(ctx *gin.Context, collectionID string) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	posts, err := h.grpcClient.BookmarkService().GetList(ctx, &post_service.GetListBookmarkRequest{
+		UserId:       ctx.GetHeader("sub"),
+		CollectionId: collectionID,
+		Cursor:       ctx.Query("cursor"),
+		Limit:        limit,
+		ViewerId:     ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting bookmarks") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, posts)
+}
+
+// CreateBookmarkCollection godoc
+// @Router /bookmarks/collections [post]
+// @Summary Create a bookmark collection
+// @Description Create a named collection, shared collections can be read by every user.
+// @Description The existing collection is returned if the current user already has one with the name.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param collection body post_service.BookmarkCollection true "Collection, name and shared are used"
+// @Success 201 {object} post_service.BookmarkCollection
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) CreateBookmarkCollection(ctx *gin.Context) {
+	var (
+		body = &post_service.BookmarkCollection{}
+	)
+
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	collection, err := h.grpcClient.BookmarkCollectionService().Create(ctx, &post_service.BookmarkCollection{
+		OwnerId: ctx.GetHeader("sub"),
+		Name:    body.Name,
+		Shared:  body.Shared,
+	})
+	if h.HandleDbError(ctx, err, "Error creating bookmark collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, collection)
+}
+
+// GetBookmarkCollections godoc
+// @Router /bookmarks/collections [get]
+// @Summary Get bookmark collections
+// @Description Get the collections of a user in their order, only the shared ones of other users.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param owner_id query string false "owner_id, the current user when empty"
+// @Success 200 {object} post_service.BookmarkCollectionList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetBookmarkCollections(ctx *gin.Context) {
+	collections, err := h.grpcClient.BookmarkCollectionService().GetList(ctx, &post_service.GetListBookmarkCollectionRequest{
+		OwnerId:  ctx.DefaultQuery("owner_id", ctx.GetHeader("sub")),
+		ViewerId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting bookmark collections") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collections)
+}
+
+// GetBookmarkCollection godoc
+// @Router /bookmarks/collections/{id} [get]
+// @Summary Get a bookmark collection
+// @Description Get a collection of the current user or a shared collection.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} post_service.BookmarkCollection
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) GetBookmarkCollection(ctx *gin.Context) {
+	collection, err := h.grpcClient.BookmarkCollectionService().GetSingle(ctx, &post_service.BookmarkCollectionSingleRequest{
+		Id:       ctx.Param("id"),
+		ViewerId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting bookmark collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+// UpdateBookmarkCollection godoc
+// @Router /bookmarks/collections/{id} [put]
+// @Summary Update a bookmark collection
+// @Description Rename a collection of the current user, or share or unshare it.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param collection body post_service.BookmarkCollection true "Collection, name and shared are used"
+// @Success 200 {object} post_service.BookmarkCollection
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+// @Failure 409 {object} user_service.ErrorResponse
+func (h *handler) UpdateBookmarkCollection(ctx *gin.Context) {
+	var (
+		body = &post_service.BookmarkCollection{}
+	)
+
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	collection, err := h.grpcClient.BookmarkCollectionService().Update(ctx, &post_service.BookmarkCollection{
+		Id:      ctx.Param("id"),
+		OwnerId: ctx.GetHeader("sub"),
+		Name:    body.Name,
+		Shared:  body.Shared,
+	})
+	if h.HandleDbError(ctx, err, "Error updating bookmark collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+// DeleteBookmarkCollection godoc
+// @Router /bookmarks/collections/{id} [delete]
+// @Summary Delete a bookmark collection
+// @Description Delete a collection of the current user, the posts in it stay bookmarked.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} user_service.SuccessResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) DeleteBookmarkCollection(ctx *gin.Context) {
+	_, err := h.grpcClient.BookmarkCollectionService().Delete(ctx, &post_service.BookmarkCollectionSingleRequest{
+		Id:       ctx.Param("id"),
+		ViewerId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error deleting bookmark collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user_service.SuccessResponse{
+		Message: "Collection deleted successfully",
+	})
+}
+
+// ReorderBookmarkCollections godoc
+// @Router /bookmarks/collections/order [put]
+// @Summary Reorder bookmark collections
+// @Description Put the collections of the current user in the order of ids, the ones left out follow in their current order.
+// @Security BearerAuth
+// @Tags bookmark
+// @Accept  json
+// @Produce  json
+// @Param order body post_service.ReorderBookmarkCollectionsRequest true "Order, only ids is used"
+// @Success 200 {object} post_service.BookmarkCollectionList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) ReorderBookmarkCollections(ctx *gin.Context) {
+	var (
+		body = &post_service.ReorderBookmarkCollectionsRequest{}
+	)
+
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	collections, err := h.grpcClient.BookmarkCollectionService().Reorder(ctx, &post_service.ReorderBookmarkCollectionsRequest{
+		OwnerId: ctx.GetHeader("sub"),
+		Ids:     body.Ids,
+	})
+	if h.HandleDbError(ctx, err, "Error reordering bookmark collections") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collections)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/user_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateBulkUserJob godoc
+// @Router /user/bulk [post]
+// @Summary Run a bulk action on users
+// @Description Block, unblock, change role, verify or delete every user matched by the filter.
+// @Description The job runs in the background, poll GET /user/bulk/{id} for progress.
+// @Description With dry_run set nothing is changed and the matched users are returned as a preview.
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param job body user_service.BulkUserActionRequest true "Bulk action"
+// @Success 202 {object} user_service.BulkUserJob
+// @Success 200 {object} user_service.BulkUserJob
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) CreateBulkUserJob(ctx *gin.Context) {
+	var (
+		body *user_service.BulkUserActionRequest
+	)
+
+	if ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only admins can run bulk actions", http.StatusForbidden)
+		return
+	}
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.RequestedBy = ctx.GetHeader("sub")
+
+	job, err := h.grpcClient.BulkUserJobService().Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating bulk user job") {
+		return
+	}
+
+	if job.DryRun {
+		ctx.JSON(http.StatusOK, job)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, job)
+}
+
+// GetBulkUserJob godoc
+// @Router /user/bulk/{id} [get]
+// @Summary Get a bulk user job
+// @Description Get status and progress of a bulk user job
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Job ID"
+// @Success 200 {object} user_service.BulkUserJob
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetBulkUserJob(ctx *gin.Context) {
+	if ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only admins can view bulk jobs", http.StatusForbidden)
+		return
+	}
+
+	job, err := h.grpcClient.BulkUserJobService().GetSingle(ctx, &user_service.BulkUserJobSingleRequest{
+		Id: ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting bulk user job") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+// GetBulkUserJobs godoc
+// @Router /user/bulk/list [get]
+// @Summary Get a list of bulk user jobs
+// @Description Get a list of bulk user jobs, newest first
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param status query string false "pending, running, completed or failed"
+// @Success 200 {object} user_service.GetListBulkUserJobResponse
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetBulkUserJobs(ctx *gin.Context) {
+	var (
+		req user_service.GetListBulkUserJobRequest
+	)
+
+	if ctx.GetHeader("user_type") != "admin" {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only admins can view bulk jobs", http.StatusForbidden)
+		return
+	}
+
+	page, err := strconv.ParseUint(ctx.DefaultQuery("page", "1"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid page", 400)
+		return
+	}
+
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	req.Page = page
+	req.Limit = limit
+	req.Status = ctx.Query("status")
+
+	resp, err := h.grpcClient.BulkUserJobService().GetList(ctx, &req)
+	if h.HandleDbError(ctx, err, "Error getting bulk user jobs") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
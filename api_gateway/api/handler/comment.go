@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+	"user_api_gateway/genproto/user_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateComment godoc
+// @Router /post/{id}/comments [post]
+// @Summary Comment on a post
+// @Description Add a comment to a post, set parent_id to reply to another comment.
+// @Description Replies can be nested at most 4 levels deep.
+// @Security BearerAuth
+// @Tags comment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param comment body post_service.Comment true "Comment object, only content and parent_id are used"
+// @Success 201 {object} post_service.Comment
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) CreateComment(ctx *gin.Context) {
+	var (
+		body *post_service.Comment
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	comment, err := h.grpcClient.CommentService().Create(ctx, &post_service.Comment{
+		PostId:   ctx.Param("id"),
+		ParentId: body.ParentId,
+		AuthorId: ctx.GetHeader("sub"),
+		Content:  body.Content,
+	})
+	if h.HandleDbError(ctx, err, "Error creating comment") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+// GetComments godoc
+// @Router /post/{id}/comments [get]
+// @Summary Get comments of a post
+// @Description Get top level comments of a post oldest first, or the replies to parent_id.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags comment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param parent_id query string false "parent comment ID"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.CommentList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetComments(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	comments, err := h.grpcClient.CommentService().GetList(ctx, &post_service.GetListCommentRequest{
+		PostId:   ctx.Param("id"),
+		ParentId: ctx.Query("parent_id"),
+		Cursor:   ctx.Query("cursor"),
+		Limit:    limit,
+		ViewerId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting comments") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comments)
+}
+
+// UpdateComment godoc
+// @Router /post/{id}/comments/{comment_id} [put]
+// @Summary Edit a comment
+// @Description Edit the content of a comment, only the author can edit it
+// @Security BearerAuth
+// @Tags comment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Param comment body post_service.Comment true "Comment object, only content is used"
+// @Success 200 {object} post_service.Comment
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) UpdateComment(ctx *gin.Context) {
+	var (
+		body *post_service.Comment
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	comment, err := h.grpcClient.CommentService().Update(ctx, &post_service.Comment{
+		Id:       ctx.Param("comment_id"),
+		AuthorId: ctx.GetHeader("sub"),
+		Content:  body.Content,
+	})
+	if h.HandleDbError(ctx, err, "Error updating comment") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment godoc
+// @Router /post/{id}/comments/{comment_id} [delete]
+// @Summary Delete a comment
+// @Description Delete a comment, moderators and admins can remove comments of other users.
+// @Description Replies to a deleted comment are kept.
+// @Security BearerAuth
+// @Tags comment
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Success 200 {object} user_service.SuccessResponse
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) DeleteComment(ctx *gin.Context) {
+	_, err := h.grpcClient.CommentService().Delete(ctx, &post_service.DeleteCommentRequest{
+		Id:          ctx.Param("comment_id"),
+		RequestedBy: ctx.GetHeader("sub"),
+		Moderator:   isModerator(ctx),
+	})
+	if h.HandleDbError(ctx, err, "Error deleting comment") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user_service.SuccessResponse{
+		Message: "Comment deleted successfully",
+	})
+}
+
+// isModerator reports whether the caller may moderate content of other users. The auth middleware
+// sets user_role from the verified token, admins inherit the moderator role.
+func isModerator(ctx *gin.Context) bool {
+	role := ctx.GetHeader("user_role")
+	return role == "moderator" || role == "admin"
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (h handler) HandleDbError(c *gin.Context, err error, message string) bool {
@@ -31,6 +33,36 @@ func (h handler) HandleDbError(c *gin.Context, err error, message string) bool {
 		return true
 	}
 
+	// Errors returned by the services with an explicit gRPC status code
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, &user_service.ErrorResponse{
+				Message: st.Message(),
+				Code:    config.ErrorBadRequest,
+			})
+			return true
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, &user_service.ErrorResponse{
+				Message: st.Message(),
+				Code:    config.ErrorNotFound,
+			})
+			return true
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, &user_service.ErrorResponse{
+				Message: st.Message(),
+				Code:    config.ErrorForbidden,
+			})
+			return true
+		case codes.AlreadyExists, codes.FailedPrecondition:
+			c.JSON(http.StatusConflict, &user_service.ErrorResponse{
+				Message: st.Message(),
+				Code:    config.ErrorConflict,
+			})
+			return true
+		}
+	}
+
 	switch e := err.(type) {
 	case *pgconn.PgError:
 		// Handle PostgreSQL-specific errors
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetHomeFeed godoc
+// @Router /post/feed [get]
+// @Summary Get the home feed
+// @Description Get published posts of the followed accounts and of the current user, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.PostList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetHomeFeed(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	posts, err := h.grpcClient.FeedService().GetHome(ctx, &post_service.GetHomeFeedRequest{
+		ViewerId: ctx.GetHeader("sub"),
+		Cursor:   ctx.Query("cursor"),
+		Limit:    limit,
+	})
+	if h.HandleDbError(ctx, err, "Error getting home feed") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, posts)
+}
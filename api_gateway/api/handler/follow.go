@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/user_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FollowUser godoc
+// @Router /user/{id}/follow [post]
+// @Summary Follow a user
+// @Description Follow a user, their posts show up in the home feed. Following a user twice changes nothing.
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} user_service.FollowStats
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) FollowUser(ctx *gin.Context) {
+	stats, err := h.grpcClient.FollowService().Create(ctx, &user_service.Follow{
+		FollowerId: ctx.GetHeader("sub"),
+		FolloweeId: ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error following user") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// UnfollowUser godoc
+// @Router /user/{id}/follow [delete]
+// @Summary Unfollow a user
+// @Description Unfollow a user
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} user_service.FollowStats
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) UnfollowUser(ctx *gin.Context) {
+	stats, err := h.grpcClient.FollowService().Delete(ctx, &user_service.Follow{
+		FollowerId: ctx.GetHeader("sub"),
+		FolloweeId: ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error unfollowing user") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// GetFollowers godoc
+// @Router /user/{id}/followers [get]
+// @Summary Get followers of a user
+// @Description Get followers of a user, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} user_service.FollowList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetFollowers(ctx *gin.Context) {
+	h.getFollows(ctx, "followers")
+}
+
+// GetFollowing godoc
+// @Router /user/{id}/following [get]
+// @Summary Get accounts followed by a user
+// @Description Get accounts followed by a user, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} user_service.FollowList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetFollowing(ctx *gin.Context) {
+	h.getFollows(ctx, "following")
+}
+
+func (h *handler) getFollows(ctx *gin.Context, direction string) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if err != nil || limit > 100 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	follows, err := h.grpcClient.FollowService().GetList(ctx, &user_service.GetListFollowRequest{
+		UserId:    ctx.Param("id"),
+		Direction: direction,
+		Cursor:    ctx.Query("cursor"),
+		Limit:     limit,
+	})
+	if h.HandleDbError(ctx, err, "Error getting "+direction) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, follows)
+}
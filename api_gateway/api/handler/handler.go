@@ -3,8 +3,10 @@ package handler
 import (
 	"strconv"
 	"user_api_gateway/config"
+	"user_api_gateway/pkg/blob"
 	"user_api_gateway/pkg/grpc_client"
 	"user_api_gateway/pkg/logger"
+	"user_api_gateway/pkg/signedurl"
 
 	rediscache "github.com/golanguzb70/redis-cache"
 
@@ -16,6 +18,9 @@ type handler struct {
 	grpcClient *grpc_client.GrpcClient
 	cfg        config.Config
 	redis      rediscache.RedisCache
+	blobs      blob.Store
+	images     blob.Store
+	signer     *signedurl.Signer
 }
 
 // HandlerV1Config ...
@@ -24,6 +29,9 @@ type HandlerConfig struct {
 	GrpcClient *grpc_client.GrpcClient
 	Cfg        config.Config
 	Redis      rediscache.RedisCache
+	Blobs      blob.Store
+	// Images holds the files of the attachments added before uploads
+	Images blob.Store
 }
 
 const (
@@ -59,11 +67,23 @@ var (
 
 // New ...
 func New(c *HandlerConfig) *handler {
+	secret := c.Cfg.MediaURLSecret
+	if secret == "" {
+		secret = c.Cfg.JWT
+	}
+	ttl := c.Cfg.MediaURLTTL
+	if ttl <= 0 {
+		ttl = defaultMediaURLTTL
+	}
+
 	return &handler{
 		log:        c.Logger,
 		grpcClient: c.GrpcClient,
 		cfg:        c.Cfg,
 		redis:      c.Redis,
+		blobs:      c.Blobs,
+		images:     c.Images,
+		signer:     signedurl.New(secret, ttl),
 	}
 }
 
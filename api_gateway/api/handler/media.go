@@ -0,0 +1,352 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+	"user_api_gateway/pkg/blob"
+	"user_api_gateway/pkg/logger"
+	"user_api_gateway/pkg/mediatype"
+	"user_api_gateway/pkg/signedurl"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// the size limits of the uploads by type when MEDIA_MAX_SIZE_* are not set
+	defaultPhotoMaxSize    = 10 << 20
+	defaultVideoMaxSize    = 100 << 20
+	defaultAudioMaxSize    = 25 << 20
+	defaultDocumentMaxSize = 25 << 20
+	// defaultMediaURLTTL is how long a signed url works when MEDIA_URL_TTL is not set.
+	defaultMediaURLTTL = time.Hour
+)
+
+// UploadMedia godoc
+// @Router /media [post]
+// @Summary Upload a file to attach to a post
+// @Description Upload a photo (JPEG, PNG, GIF, WebP), a video (MP4, WebM, QuickTime), audio (MP3, Ogg, WAV, FLAC, AAC, M4A)
+// @Description or a document (PDF, text, CSV, Word, Excel, PowerPoint, OpenDocument text) as the file field of a multipart form.
+// @Description The type is detected from the content, a Content-Type or file extension that does not match it is rejected.
+// @Description Each type has its own size limit. Identical files are stored once, uploading a file again returns the same key.
+// @Description Attach the file to a post with the returned key as object_key.
+// @Security BearerAuth
+// @Tags media
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "the file"
+// @Success 201 {object} post_service.Media
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 413 {object} user_service.ErrorResponse
+// @Failure 415 {object} user_service.ErrorResponse
+func (h *handler) UploadMedia(ctx *gin.Context) {
+	maxSizes := h.mediaMaxSizes()
+
+	var maxSize int64
+	for _, size := range maxSizes {
+		maxSize = max(maxSize, size)
+	}
+
+	// the form around the file takes a few more bytes
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+1<<20)
+
+	file, header, err := ctx.Request.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "The file is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	if header.Size == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "The file is empty", http.StatusBadRequest)
+		return
+	}
+
+	uploadType, err := mediatype.Detect(file, header.Header.Get("Content-Type"), header.Filename)
+	if errors.Is(err, mediatype.ErrUnsupported) || errors.Is(err, mediatype.ErrMismatch) {
+		h.ReturnError(ctx, config.ErrorBadRequest, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Error reading the file", http.StatusBadRequest)
+		return
+	}
+
+	if header.Size > maxSizes[uploadType.Kind] {
+		h.ReturnError(ctx, config.ErrorBadRequest, "The file is too large for "+uploadType.Kind, http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	digest, err := sha256Hex(file)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error reading the file", http.StatusInternalServerError)
+		return
+	}
+
+	// files are stored by their content, so identical uploads share one file
+	ownerID := ctx.GetHeader("sub")
+	key := "sha256/" + digest + uploadType.Ext()
+
+	media, err := h.grpcClient.MediaService().Create(ctx, &post_service.Media{
+		Key:      key,
+		OwnerId:  ownerID,
+		MimeType: uploadType.MimeType,
+		Type:     uploadType.Kind,
+		Size:     header.Size,
+		Sha256:   digest,
+	})
+	if h.HandleDbError(ctx, err, "Error saving the file") {
+		return
+	}
+
+	if err := h.storeOnce(ctx, key, file, header.Size, uploadType.MimeType); err != nil {
+		h.log.Error("Error storing the file", logger.Error(err))
+		// the record is of no use without its file, an earlier upload of the owner is kept
+		if media.Created {
+			h.dropUpload(ctx, key, ownerID)
+		}
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error storing the file", http.StatusInternalServerError)
+		return
+	}
+	media.Url = h.signer.Sign(mediaURL(key), ownerID)
+
+	ctx.JSON(http.StatusCreated, media)
+}
+
+// mediaMaxSizes returns the size limits of the uploads by type.
+func (h *handler) mediaMaxSizes() map[string]int64 {
+	sizes := map[string]int64{
+		mediatype.Photo:    defaultPhotoMaxSize,
+		mediatype.Video:    defaultVideoMaxSize,
+		mediatype.Audio:    defaultAudioMaxSize,
+		mediatype.Document: defaultDocumentMaxSize,
+	}
+
+	for kind, size := range map[string]int64{
+		mediatype.Photo:    h.cfg.PhotoMaxSize,
+		mediatype.Video:    h.cfg.VideoMaxSize,
+		mediatype.Audio:    h.cfg.AudioMaxSize,
+		mediatype.Document: h.cfg.DocumentMaxSize,
+	} {
+		if size > 0 {
+			sizes[kind] = size
+		}
+	}
+
+	return sizes
+}
+
+// sha256Hex hashes the content of file and rewinds it.
+func sha256Hex(file io.ReadSeeker) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	sum := sha256.New()
+	if _, err := io.Copy(sum, file); err != nil {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// storeOnce puts the file under key unless an identical upload stored it already.
+func (h *handler) storeOnce(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	existing, _, err := h.blobs.Get(ctx, key)
+	if err == nil {
+		return existing.Close()
+	}
+	if !errors.Is(err, blob.ErrNotFound) {
+		return err
+	}
+
+	return h.blobs.Put(ctx, key, r, size, contentType)
+}
+
+// dropUpload removes the upload of ownerID, and the file when no other upload refers to it.
+func (h *handler) dropUpload(ctx context.Context, key, ownerID string) {
+	media, err := h.grpcClient.MediaService().Delete(ctx, &post_service.MediaDeleteRequest{
+		Key:     key,
+		OwnerId: ownerID,
+	})
+	if err != nil {
+		h.log.Error("Error deleting the upload", logger.Error(err))
+		return
+	}
+
+	if media.RefCount == 0 {
+		if err := h.blobs.Delete(ctx, key); err != nil {
+			h.log.Error("Error deleting the file", logger.Error(err))
+		}
+	}
+}
+
+// GetMedia godoc
+// @Router /media/{key} [get]
+// @Summary Download an uploaded file
+// @Description Stream a file uploaded with POST /media. Only the signed url of the file works, it is
+// @Description handed out with the posts the viewer can read and expires. A url signed for a user only works
+// @Description with the token of that user, urls signed for anonymous viewers need no token. Range requests are supported.
+// @Security BearerAuth
+// @Tags media
+// @Produce  octet-stream
+// @Param key path string true "object key"
+// @Param expires query string true "set in the signed url"
+// @Param viewer query string false "set in the signed url"
+// @Param signature query string true "set in the signed url"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 403 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) GetMedia(ctx *gin.Context) {
+	h.serveSigned(ctx, h.blobs, strings.TrimPrefix(ctx.Param("key"), "/"))
+}
+
+// GetImage godoc
+// @Router /images/{path} [get]
+// @Summary Download the file of an old attachment
+// @Description Stream the file of an attachment added before uploads, like GET /media/{key} only signed urls work.
+// @Security BearerAuth
+// @Tags media
+// @Produce  octet-stream
+// @Param path path string true "filepath of the attachment"
+// @Param expires query string true "set in the signed url"
+// @Param viewer query string false "set in the signed url"
+// @Param signature query string true "set in the signed url"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 403 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) GetImage(ctx *gin.Context) {
+	h.serveSigned(ctx, h.images, strings.TrimPrefix(ctx.Param("path"), "/"))
+}
+
+// serveSigned streams the blob stored under key when the url of the request is signed for the
+// user who makes it. The files are user content served from the API origin, so browsers are kept
+// from sniffing their type and only media is shown inline.
+func (h *handler) serveSigned(ctx *gin.Context, store blob.Store, key string) {
+	viewer, expires, err := h.signer.Verify(ctx.Request.URL.Path, ctx.Request.URL.Query())
+	if errors.Is(err, signedurl.ErrExpired) {
+		h.ReturnError(ctx, config.ErrorForbidden, "The link expired", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorForbidden, "Invalid signature", http.StatusForbidden)
+		return
+	}
+	if viewer != "" && viewer != ctx.GetString(authUserKey) {
+		h.ReturnError(ctx, config.ErrorForbidden, "The link was made for another user", http.StatusForbidden)
+		return
+	}
+
+	file, obj, err := store.Get(ctx, key)
+	if errors.Is(err, blob.ErrNotFound) || errors.Is(err, blob.ErrInvalidKey) {
+		h.ReturnError(ctx, config.ErrorNotFound, "File not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.log.Error("Error reading the file", logger.Error(err))
+		h.ReturnError(ctx, config.ErrorInternalServer, "Error reading the file", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	if obj.ContentType != "" {
+		ctx.Header("Content-Type", obj.ContentType)
+	}
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Content-Disposition", contentDisposition(key, obj.ContentType))
+	// the file must not outlive the link in shared caches
+	ctx.Header("Cache-Control", "private, max-age="+strconv.Itoa(int(time.Until(expires).Seconds())))
+
+	http.ServeContent(ctx.Writer, ctx.Request, "", obj.ModTime, file)
+}
+
+// mediaURL is where the gateway serves the file stored under key.
+func mediaURL(key string) string {
+	return "/media/" + key
+}
+
+// contentDisposition shows photos, videos and audio inline and has other files downloaded under
+// the name of their key.
+func contentDisposition(key, contentType string) string {
+	disposition := "attachment"
+	switch strings.SplitN(contentType, "/", 2)[0] {
+	case "image", "video", "audio":
+		disposition = "inline"
+	}
+	return mime.FormatMediaType(disposition, map[string]string{"filename": path.Base(key)})
+}
+
+// sendPosts writes a response holding posts, with the urls of their attachments signed for the
+// user who requested them.
+func (h *handler) sendPosts(ctx *gin.Context, code int, resp interface{}) {
+	h.signPosts(resp, ctx.GetHeader("sub"))
+	ctx.JSON(code, resp)
+}
+
+// signPosts signs the attachment urls of the posts in resp and of the posts they repost for the
+// viewer. resp is a *post_service.Post, *post_service.PostList, *post_service.PostSearchResult or
+// *post_service.ModerationQueue, other values are left as they are.
+func (h *handler) signPosts(resp interface{}, viewerID string) {
+	switch resp := resp.(type) {
+	case *post_service.Post:
+		for post := resp; post != nil; post = post.Original {
+			h.signAttachments(post.Attachments, viewerID)
+		}
+	case *post_service.PostList:
+		for _, post := range resp.GetItems() {
+			h.signPosts(post, viewerID)
+		}
+	case *post_service.PostSearchResult:
+		for _, hit := range resp.GetItems() {
+			h.signPosts(hit.GetPost(), viewerID)
+		}
+	case *post_service.ModerationQueue:
+		for _, item := range resp.GetItems() {
+			h.signPosts(item.GetPost(), viewerID)
+		}
+	}
+}
+
+// signAttachments fills the urls of the attachments and their variants with links signed for the
+// viewer. The caller checks that the viewer can read the post first.
+func (h *handler) signAttachments(attachments []*post_service.Attachment, viewerID string) {
+	for _, attachment := range attachments {
+		switch {
+		case attachment.StrippedKey != "":
+			attachment.Url = h.signer.Sign(mediaURL(attachment.StrippedKey), viewerID)
+		case attachment.ObjectKey != "":
+			attachment.Url = h.signer.Sign(mediaURL(attachment.ObjectKey), viewerID)
+		case attachment.Filepath != "":
+			// old attachments refer to their file in ./static/images, which was served as /images
+			path := strings.TrimPrefix(attachment.Filepath, "/")
+			path = strings.TrimPrefix(path, "static/")
+			path = strings.TrimPrefix(path, "images/")
+			attachment.Url = h.signer.Sign("/images/"+path, viewerID)
+		}
+
+		for _, variant := range attachment.Variants {
+			variant.Url = h.signer.Sign(mediaURL(variant.ObjectKey), viewerID)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+	"user_api_gateway/pkg/blob"
+	"user_api_gateway/pkg/grpc_client"
+	"user_api_gateway/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+type fakeFeed struct {
+	post_service.UnimplementedFeedServiceServer
+	resp *post_service.PostList
+}
+
+func (f *fakeFeed) GetHome(context.Context, *post_service.GetHomeFeedRequest) (*post_service.PostList, error) {
+	return f.resp, nil
+}
+
+// testHandler returns a handler whose post service is served by register.
+func testHandler(t *testing.T, register func(*grpc.Server)) *handler {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	register(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	host, port, _ := net.SplitHostPort(lis.Addr().String())
+	cfg := config.Config{PostServiceHost: host, PostServicePort: port, UserServiceHost: host, UserServicePort: port, JWT: "secret"}
+
+	client, err := grpc_client.New(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return New(&HandlerConfig{Logger: logger.New("error", "test"), GrpcClient: client, Cfg: cfg})
+}
+
+func TestGetHomeFeedSignsAttachments(t *testing.T) {
+	feed := &fakeFeed{resp: &post_service.PostList{Items: []*post_service.Post{{
+		Id:          "quote",
+		Attachments: []*post_service.Attachment{{ObjectKey: "sha256/a.jpg"}},
+		Original: &post_service.Post{
+			Id: "original",
+			Attachments: []*post_service.Attachment{{
+				ObjectKey:   "sha256/b",
+				StrippedKey: "variants/sha256/b/original.png",
+				Variants:    []*post_service.AttachmentVariant{{ObjectKey: "variants/sha256/b/320.webp"}},
+			}},
+		},
+	}}}}
+	h := testHandler(t, func(s *grpc.Server) { post_service.RegisterFeedServiceServer(s, feed) })
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/feed/home", nil)
+	ctx.Request.Header.Set("sub", "viewer-1")
+
+	h.GetHomeFeed(ctx)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+
+	var resp post_service.PostList
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	// a processed photo links to its copy without metadata
+	post := resp.Items[0]
+	urls := map[string]string{
+		"/media/sha256/a.jpg":                   post.Attachments[0].Url,
+		"/media/variants/sha256/b/original.png": post.Original.Attachments[0].Url,
+		"/media/variants/sha256/b/320.webp":     post.Original.Attachments[0].Variants[0].Url,
+	}
+	for path, raw := range urls {
+		signed, err := url.Parse(raw)
+		if err != nil || signed.Path != path {
+			t.Errorf("url of %s = %q", path, raw)
+			continue
+		}
+		viewer, _, err := h.signer.Verify(signed.Path, signed.Query())
+		if err != nil {
+			t.Errorf("url of %s: %v", path, err)
+		}
+		if viewer != "viewer-1" {
+			t.Errorf("url of %s is signed for %q, want viewer-1", path, viewer)
+		}
+	}
+}
+
+type fakeMedia struct {
+	post_service.UnimplementedMediaServiceServer
+	created bool
+	deleted []string
+}
+
+func (f *fakeMedia) Create(_ context.Context, req *post_service.Media) (*post_service.Media, error) {
+	req.Created = f.created
+	return req, nil
+}
+
+func (f *fakeMedia) Delete(_ context.Context, req *post_service.MediaDeleteRequest) (*post_service.Media, error) {
+	f.deleted = append(f.deleted, req.Key)
+	return &post_service.Media{Key: req.Key, RefCount: 1}, nil
+}
+
+// failingStore is a blob store that has no blobs and can not store any.
+type failingStore struct{}
+
+func (failingStore) Put(context.Context, string, io.Reader, int64, string) error {
+	return errors.New("disk full")
+}
+
+func (failingStore) Get(context.Context, string) (io.ReadSeekCloser, *blob.Object, error) {
+	return nil, nil, blob.ErrNotFound
+}
+
+func (failingStore) Delete(context.Context, string) error {
+	return nil
+}
+
+func TestUploadMediaKeepsEarlierUpload(t *testing.T) {
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewGray(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, created := range []bool{true, false} {
+		media := &fakeMedia{created: created}
+		h := testHandler(t, func(s *grpc.Server) { post_service.RegisterMediaServiceServer(s, media) })
+		h.blobs = failingStore{}
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "a.png")
+		part.Write(img.Bytes())
+		form.Close()
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/media", &body)
+		ctx.Request.Header.Set("Content-Type", form.FormDataContentType())
+		ctx.Request.Header.Set("sub", "owner-1")
+
+		h.UploadMedia(ctx)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("status %d: %s", w.Code, w.Body)
+		}
+		// only the upload this request added is dropped when its file can not be stored
+		if dropped := len(media.deleted) > 0; dropped != created {
+			t.Errorf("created %v: dropped the upload %v", created, dropped)
+		}
+	}
+}
+
+func TestGetMediaChecksViewer(t *testing.T) {
+	store, err := blob.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, content := range map[string]string{"sha256/a.png": "png", "sha256/b.pdf": "pdf"} {
+		contentType := "image/png"
+		if strings.HasSuffix(key, ".pdf") {
+			contentType = "application/pdf"
+		}
+		if err := store.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), contentType); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := testHandler(t, func(*grpc.Server) {})
+	h.blobs = store
+
+	tests := []struct {
+		name, key, signedFor, caller string
+		code                         int
+		disposition                  string
+	}{
+		{"viewer", "sha256/a.png", "viewer-1", "viewer-1", http.StatusOK, `inline; filename=a.png`},
+		{"document", "sha256/b.pdf", "viewer-1", "viewer-1", http.StatusOK, `attachment; filename=b.pdf`},
+		{"no token", "sha256/a.png", "viewer-1", "", http.StatusForbidden, ""},
+		{"other user", "sha256/a.png", "viewer-1", "viewer-2", http.StatusForbidden, ""},
+		{"anonymous url", "sha256/a.png", "", "", http.StatusOK, `inline; filename=a.png`},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, h.signer.Sign(mediaURL(tt.key), tt.signedFor), nil)
+			// a sub header is not proof of the caller, only the verified token is
+			ctx.Request.Header.Set("sub", tt.signedFor)
+			if tt.caller != "" {
+				ctx.Set(authUserKey, tt.caller)
+			}
+			ctx.Params = gin.Params{{Key: "key", Value: "/" + tt.key}}
+
+			h.GetMedia(ctx)
+
+			if w.Code != tt.code {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.code, w.Body)
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+				t.Errorf("X-Content-Type-Options = %q", got)
+			}
+			if got := w.Header().Get("Content-Disposition"); got != tt.disposition {
+				t.Errorf("Content-Disposition = %q, want %q", got, tt.disposition)
+			}
+		})
+	}
+}
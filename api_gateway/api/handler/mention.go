@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMentionPosts godoc
+// @Router /post/mentions [get]
+// @Summary Get posts that mention me
+// @Description Get published posts that mention the current user, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.PostList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetMentionPosts(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	posts, err := h.grpcClient.MentionService().GetPosts(ctx, &post_service.GetMentionPostsRequest{
+		UserId:   ctx.GetHeader("sub"),
+		Cursor:   ctx.Query("cursor"),
+		Limit:    limit,
+		ViewerId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting mentions") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, posts)
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestMetadata forwards the caller's address to the services as gRPC metadata,
+// handlers pass the gin context to the clients so it is sent with every call.
+func (h *handler) RequestMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		appendOutgoing(c,
+			"client_ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		)
+
+		c.Next()
+	}
+}
+
+func appendOutgoing(c *gin.Context, kv ...string) {
+	c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(c.Request.Context(), kv...))
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateReport godoc
+// @Router /reports/ [post]
+// @Summary Report a post or a user
+// @Description Report a post or a user to the moderators with a reason: spam, harassment, hate, violence,
+// @Description sexual, misinformation, impersonation or other. Reporting the same target again while the report is open updates it.
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param report body post_service.Report true "Report, target_type, target_id, reason and details are used"
+// @Success 201 {object} post_service.Report
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) CreateReport(ctx *gin.Context) {
+	var (
+		body = &post_service.Report{}
+	)
+
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	report, err := h.grpcClient.ReportService().Create(ctx, &post_service.Report{
+		TargetType: body.TargetType,
+		TargetId:   body.TargetId,
+		ReporterId: ctx.GetHeader("sub"),
+		Reason:     body.Reason,
+		Details:    body.Details,
+	})
+	if h.HandleDbError(ctx, err, "Error creating report") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// GetReports godoc
+// @Router /reports/list [get]
+// @Summary Get reports
+// @Description Get the reports, newest first. Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param target_type query string false "post or user"
+// @Param target_id query string false "target_id"
+// @Param status query string false "open, resolved or dismissed"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.ReportList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetReports(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	reports, err := h.grpcClient.ReportService().GetList(ctx, &post_service.GetListReportRequest{
+		TargetType: ctx.Query("target_type"),
+		TargetId:   ctx.Query("target_id"),
+		Status:     ctx.Query("status"),
+		Cursor:     ctx.Query("cursor"),
+		Limit:      limit,
+	})
+	if h.HandleDbError(ctx, err, "Error getting reports") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// GetModerationQueue godoc
+// @Router /reports/queue [get]
+// @Summary Get the moderation queue
+// @Description Get the posts and users with open reports, the longest waiting first, with the reported post embedded.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param target_type query string false "post or user"
+// @Param reason query string false "only targets reported for this reason"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.ModerationQueue
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetModerationQueue(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	queue, err := h.grpcClient.ReportService().GetQueue(ctx, &post_service.GetModerationQueueRequest{
+		TargetType: ctx.Query("target_type"),
+		Reason:     ctx.Query("reason"),
+		Cursor:     ctx.Query("cursor"),
+		Limit:      limit,
+	})
+	if h.HandleDbError(ctx, err, "Error getting moderation queue") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, queue)
+}
+
+// CreateModerationAction godoc
+// @Router /moderation/actions [post]
+// @Summary Act on a reported target
+// @Description Hide or delete a post, warn or block the user, or dismiss the reports. The open reports of the target are closed.
+// @Description hide_post and delete_post only apply to posts, the other actions apply to the owner of a post target.
+// @Description Only published posts can be hidden. Moderators can only block plain users, admins can block anyone but themselves.
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param action body post_service.ModerationAction true "Action, target_type, target_id, action and note are used"
+// @Success 201 {object} post_service.ModerationAction
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 403 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+// @Failure 409 {object} user_service.ErrorResponse
+func (h *handler) CreateModerationAction(ctx *gin.Context) {
+	var (
+		body = &post_service.ModerationAction{}
+	)
+
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	action, err := h.grpcClient.ModerationService().Create(ctx, &post_service.ModerationAction{
+		TargetType:  body.TargetType,
+		TargetId:    body.TargetId,
+		Action:      body.Action,
+		ModeratorId: ctx.GetHeader("sub"),
+		Note:        body.Note,
+	})
+	if h.HandleDbError(ctx, err, "Error applying moderation action") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, action)
+}
+
+// GetModerationActions godoc
+// @Router /moderation/actions/list [get]
+// @Summary Get moderation actions
+// @Description Get the decisions of the moderators, newest first. Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param target_type query string false "post or user"
+// @Param target_id query string false "target_id"
+// @Param user_id query string false "ID of the user the action applies to"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.ModerationActionList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetModerationActions(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	actions, err := h.grpcClient.ModerationService().GetList(ctx, &post_service.GetListModerationActionRequest{
+		TargetType: ctx.Query("target_type"),
+		TargetId:   ctx.Query("target_id"),
+		UserId:     ctx.Query("user_id"),
+		Cursor:     ctx.Query("cursor"),
+		Limit:      limit,
+	})
+	if h.HandleDbError(ctx, err, "Error getting moderation actions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, actions)
+}
@@ -15,7 +15,11 @@ import (
 // CreatePost godoc
 // @Router /post [post]
 // @Summary Create a new post
-// @Description Create a new post
+// @Description Create a new post. A post with status scheduled is published at publish_at,
+// @Description an RFC3339 time or a local time like 2025-03-14T09:30 in the timezone of the user.
+// @Description visibility is public (the default), followers or private, drafts are only visible to the owner.
+// @Description poll is optional: 2 to 10 options, multiple for multiple choice and closes_at as RFC3339.
+// @Description attachments refer to files uploaded with POST /media by their object_key.
 // @Security BearerAuth
 // @Tags post
 // @Accept  json
@@ -41,6 +45,14 @@ func (h *handler) CreatePost(ctx *gin.Context) {
 		return
 	}
 
+	if body.Status == "" {
+		settings, err := h.grpcClient.UserSettingsService().GetSingle(ctx, &user_service.UserSettingsSingleRequest{UserId: body.OwnerId})
+		if h.HandleDbError(ctx, err, "Error getting user settings") {
+			return
+		}
+		body.Status = settings.DefaultPostStatus
+	}
+
 	defaultTags, err := h.grpcClient.PostAttachmentService().GetDefaultTags(ctx, &post_service.GetDefaultTagsRequest{})
 	if h.HandleDbError(ctx, err, "Error getting default tags") {
 		return
@@ -56,11 +68,7 @@ func (h *handler) CreatePost(ctx *gin.Context) {
 		return
 	}
 
-	body.Tags = map[string]*post_service.StringList{
-		"gemini_tags":      {Values: tags},
-		"owner_id":         {Values: []string{body.OwnerId}},
-		"content_hashtags": {Values: contentHashtags},
-	}
+	body.Tags = postTags(body, tags, contentHashtags)
 
 	post, err := h.grpcClient.PostService().Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating post") {
@@ -76,11 +84,8 @@ func (h *handler) CreatePost(ctx *gin.Context) {
 		return
 	}
 	post.Attachments = attachmentList.Items
-	if h.HandleDbError(ctx, err, "Error creating post") {
-		return
-	}
 
-	ctx.JSON(201, post)
+	h.sendPosts(ctx, 201, post)
 }
 
 // GetPost godoc
@@ -100,6 +105,8 @@ func (h *handler) GetPost(ctx *gin.Context) {
 	)
 
 	req.Id = ctx.Param("id")
+	req.ViewerId = ctx.GetHeader("sub")
+	req.RecordView = true
 
 	post, err := h.grpcClient.PostService().GetSingle(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting post") {
@@ -115,6 +122,7 @@ func (h *handler) GetPost(ctx *gin.Context) {
 	}
 
 	post.Attachments = postAttachments.Items
+	h.signPosts(post, ctx.GetHeader("sub"))
 
 	user, err := h.grpcClient.UserService().GetSingle(ctx, &user_service.UserSingleRequest{Id: post.OwnerId})
 	if h.HandleDbError(ctx, err, "Error getting post owner") {
@@ -131,13 +139,17 @@ func (h *handler) GetPost(ctx *gin.Context) {
 // GetPosts godoc
 // @Router /post/list [get]
 // @Summary Get a list of posts
-// @Description Get a list of posts
+// @Description Get a list of posts, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page, page is deprecated and ignored when cursor is set.
+// @Description The total count is only returned when with_total is true.
 // @Security BearerAuth
 // @Tags post
 // @Accept  json
 // @Produce  json
-// @Param page query number true "page"
+// @Param page query number false "page"
 // @Param limit query number true "limit"
+// @Param cursor query string false "cursor"
+// @Param with_total query bool false "with_total"
 // @Param owner_id query string false "owner_id"
 // @Success 200 {object} post_service.PostList
 // @Failure 400 {object} user_service.ErrorResponse
@@ -150,6 +162,8 @@ func (h *handler) GetPosts(ctx *gin.Context) {
 	limitStr := ctx.DefaultQuery("limit", "10")
 
 	req.Search = ctx.Query("owner_id")
+	req.ViewerId = ctx.GetHeader("sub")
+	req.Cursor = ctx.Query("cursor")
 
 	page, err := strconv.Atoi(pageStr)
 	if err != nil {
@@ -163,21 +177,77 @@ func (h *handler) GetPosts(ctx *gin.Context) {
 		return
 	}
 
+	withTotal, err := strconv.ParseBool(ctx.DefaultQuery("with_total", "false"))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid with_total", 400)
+		return
+	}
+
 	req.Page = uint64(page)
 	req.Limit = uint64(limit)
+	req.WithTotal = withTotal
 
 	posts, err := h.grpcClient.PostService().GetList(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting posts") {
 		return
 	}
 
-	ctx.JSON(200, posts)
+	h.sendPosts(ctx, 200, posts)
+}
+
+// SearchPosts godoc
+// @Router /post/search [get]
+// @Summary Search posts
+// @Description Full-text search over the content and the tags of posts, the best matches first.
+// @Description q supports quoted phrases, OR and -word. The snippet is HTML escaped content with the matches wrapped in <mark></mark>.
+// @Description Drafts and posts hidden by their visibility are only found for the users who can read them.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param q query string false "search query"
+// @Param owner_id query string false "owner_id"
+// @Param status query string false "status" Enums(draft, scheduled, published, archived)
+// @Param tag query string false "tag"
+// @Param created_from query string false "YYYY-MM-DD or RFC3339"
+// @Param created_to query string false "YYYY-MM-DD or RFC3339, exclusive"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.PostSearchResult
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) SearchPosts(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	req := &post_service.SearchPostRequest{
+		Query:       ctx.Query("q"),
+		OwnerId:     ctx.Query("owner_id"),
+		Status:      ctx.Query("status"),
+		Tag:         ctx.Query("tag"),
+		CreatedFrom: ctx.Query("created_from"),
+		CreatedTo:   ctx.Query("created_to"),
+		Cursor:      ctx.Query("cursor"),
+		Limit:       limit,
+		ViewerId:    ctx.GetHeader("sub"),
+	}
+
+	posts, err := h.grpcClient.PostService().Search(ctx, req)
+	if h.HandleDbError(ctx, err, "Error searching posts") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, posts)
 }
 
 // UpdatePost godoc
 // @Router /post [put]
 // @Summary Update a post
-// @Description Update a post
+// @Description Update a post. An empty status keeps the current one. Drafts and scheduled posts can be published,
+// @Description published posts archived and archived posts published again, other changes of status are rejected with 400.
 // @Security BearerAuth
 // @Tags post
 // @Accept  json
@@ -185,6 +255,7 @@ func (h *handler) GetPosts(ctx *gin.Context) {
 // @Param post body post_service.Post true "Post object"
 // @Success 200 {object} post_service.Post
 // @Failure 400 {object} user_service.ErrorResponse
+// @Failure 403 {object} user_service.ErrorResponse
 func (h *handler) UpdatePost(ctx *gin.Context) {
 	var (
 		body *post_service.Post
@@ -200,6 +271,7 @@ func (h *handler) UpdatePost(ctx *gin.Context) {
 		h.ReturnError(ctx, config.ErrorForbidden, "You have no access to the post", http.StatusForbidden)
 		return
 	}
+	body.EditorId = ctx.GetHeader("sub")
 
 	defaultTags, err := h.grpcClient.PostAttachmentService().GetDefaultTags(ctx, &post_service.GetDefaultTagsRequest{})
 	if h.HandleDbError(ctx, err, "Error getting default tags") {
@@ -216,11 +288,7 @@ func (h *handler) UpdatePost(ctx *gin.Context) {
 		return
 	}
 
-	body.Tags = map[string]*post_service.StringList{
-		"ownerId":    {Values: []string{body.OwnerId}},
-		"geminiTags": {Values: tags},
-		"getTagBody": {Values: contentHashtags},
-	}
+	body.Tags = postTags(body, tags, contentHashtags)
 
 	post, err := h.grpcClient.PostService().Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating post") {
@@ -231,7 +299,70 @@ func (h *handler) UpdatePost(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(200, post)
+	h.sendPosts(ctx, 200, post)
+}
+
+// VotePoll godoc
+// @Router /post/{id}/poll/vote [post]
+// @Summary Vote in the poll of a post
+// @Description Choose one option, or several in a multiple choice poll. A user votes once and the results
+// @Description are hidden until they vote or the poll closes.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param vote body post_service.PollVoteRequest true "Vote, only option_ids is used"
+// @Success 200 {object} post_service.Poll
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+// @Failure 409 {object} user_service.ErrorResponse
+func (h *handler) VotePoll(ctx *gin.Context) {
+	var (
+		body = &post_service.PollVoteRequest{}
+	)
+
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	poll, err := h.grpcClient.PostService().Vote(ctx, &post_service.PollVoteRequest{
+		PostId:    ctx.Param("id"),
+		UserId:    ctx.GetHeader("sub"),
+		OptionIds: body.OptionIds,
+	})
+	if h.HandleDbError(ctx, err, "Error voting in poll") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, poll)
+}
+
+// GetPostStats godoc
+// @Router /post/{id}/stats [get]
+// @Summary Get the view counts of a post
+// @Description Get how often a post was opened and shown in feeds, and about how many users saw it.
+// @Description Only the owner and admins can see the stats, views show up after a short delay.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Success 200 {object} post_service.PostStats
+// @Failure 403 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) GetPostStats(ctx *gin.Context) {
+	stats, err := h.grpcClient.PostService().GetStats(ctx, &post_service.PostStatsRequest{
+		PostId:   ctx.Param("id"),
+		ViewerId: ctx.GetHeader("sub"),
+		Admin:    ctx.GetHeader("user_type") == "admin",
+	})
+	if h.HandleDbError(ctx, err, "Error getting post stats") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
 }
 
 // DeletePost godoc
@@ -276,3 +407,156 @@ func (h *handler) DeletePost(ctx *gin.Context) {
 		Message: "Post deleted successfully",
 	})
 }
+
+// postTags builds the tags of a post, the author can only set manual_tags.
+// post_service indexes the tags by these keys, so they must stay the same for create and update.
+func postTags(body *post_service.Post, aiTags, contentHashtags []string) map[string]*post_service.StringList {
+	return map[string]*post_service.StringList{
+		"gemini_tags":      {Values: aiTags},
+		"owner_id":         {Values: []string{body.OwnerId}},
+		"content_hashtags": {Values: contentHashtags},
+		"manual_tags":      {Values: body.Tags["manual_tags"].GetValues()},
+	}
+}
+
+// ReschedulePost godoc
+// @Router /post/{id}/schedule [put]
+// @Summary Schedule a post
+// @Description Set when a draft or scheduled post is published, the post becomes scheduled.
+// @Description publish_at is an RFC3339 time or a local time like 2025-03-14T09:30 in the timezone of the user.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param schedule body post_service.ReschedulePostRequest true "Schedule, only publish_at is used"
+// @Success 200 {object} post_service.Post
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 409 {object} user_service.ErrorResponse
+func (h *handler) ReschedulePost(ctx *gin.Context) {
+	var (
+		body *post_service.ReschedulePostRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	if !h.isPostOwner(ctx, ctx.Param("id")) {
+		return
+	}
+
+	post, err := h.grpcClient.PostService().Reschedule(ctx, &post_service.ReschedulePostRequest{
+		Id:        ctx.Param("id"),
+		PublishAt: body.PublishAt,
+	})
+	if h.HandleDbError(ctx, err, "Error scheduling post") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, post)
+}
+
+// CancelPostSchedule godoc
+// @Router /post/{id}/schedule [delete]
+// @Summary Cancel a scheduled post
+// @Description Turn a scheduled post back into a draft
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Success 200 {object} post_service.Post
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 409 {object} user_service.ErrorResponse
+func (h *handler) CancelPostSchedule(ctx *gin.Context) {
+	if !h.isPostOwner(ctx, ctx.Param("id")) {
+		return
+	}
+
+	post, err := h.grpcClient.PostService().CancelSchedule(ctx, &post_service.PostSingleRequest{Id: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error cancelling post schedule") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, post)
+}
+
+// isPostOwner writes an error response and returns false unless the current user owns the post.
+func (h *handler) isPostOwner(ctx *gin.Context, postID string) bool {
+	post, err := h.grpcClient.PostService().GetSingle(ctx, &post_service.PostSingleRequest{Id: postID})
+	if h.HandleDbError(ctx, err, "Error getting post") {
+		return false
+	}
+
+	if post.OwnerId != ctx.GetHeader("sub") {
+		h.ReturnError(ctx, config.ErrorForbidden, "You have no access to the post", http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+// Repost godoc
+// @Router /post/{id}/repost [post]
+// @Summary Repost a post
+// @Description Repost a published post, or quote it with a comment when content is set.
+// @Description Reposting a post again returns the existing repost, reposting a repost reposts its original.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param repost body post_service.RepostRequest false "Repost, only content is used"
+// @Success 201 {object} post_service.Post
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) Repost(ctx *gin.Context) {
+	var (
+		body = &post_service.RepostRequest{}
+	)
+
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(body); err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+			return
+		}
+	}
+
+	post, err := h.grpcClient.PostService().Repost(ctx, &post_service.RepostRequest{
+		PostId:  ctx.Param("id"),
+		OwnerId: ctx.GetHeader("sub"),
+		Content: body.Content,
+	})
+	if h.HandleDbError(ctx, err, "Error reposting post") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusCreated, post)
+}
+
+// Unrepost godoc
+// @Router /post/{id}/repost [delete]
+// @Summary Undo a repost
+// @Description Delete the repost of a post by the current user, quotes are deleted like other posts.
+// @Security BearerAuth
+// @Tags post
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Success 200 {object} user_service.SuccessResponse
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) Unrepost(ctx *gin.Context) {
+	_, err := h.grpcClient.PostService().Unrepost(ctx, &post_service.RepostRequest{
+		PostId:  ctx.Param("id"),
+		OwnerId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error deleting repost") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user_service.SuccessResponse{
+		Message: "Repost deleted successfully",
+	})
+}
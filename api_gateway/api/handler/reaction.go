@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReactToPost godoc
+// @Router /post/{id}/reactions [put]
+// @Summary React to a post
+// @Description Set the reaction of the current user to a post, a user has at most one reaction per post.
+// @Description Repeating the request changes nothing, sending another type replaces the reaction.
+// @Security BearerAuth
+// @Tags reaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param reaction body post_service.Reaction true "Reaction, only type is used: like, love, haha, wow, sad or angry"
+// @Success 200 {object} post_service.ReactionSummary
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) ReactToPost(ctx *gin.Context) {
+	h.upsertReaction(ctx, "post", ctx.Param("id"))
+}
+
+// DeletePostReaction godoc
+// @Router /post/{id}/reactions [delete]
+// @Summary Remove the reaction to a post
+// @Description Remove the reaction of the current user to a post
+// @Security BearerAuth
+// @Tags reaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Success 200 {object} post_service.ReactionSummary
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) DeletePostReaction(ctx *gin.Context) {
+	h.deleteReaction(ctx, "post", ctx.Param("id"))
+}
+
+// GetPostReactions godoc
+// @Router /post/{id}/reactions [get]
+// @Summary Get who reacted to a post
+// @Description Get the users who reacted to a post, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags reaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param type query string false "only reactions of this type"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.ReactionList
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) GetPostReactions(ctx *gin.Context) {
+	h.getReactions(ctx, "post", ctx.Param("id"))
+}
+
+// ReactToComment godoc
+// @Router /post/{id}/comments/{comment_id}/reactions [put]
+// @Summary React to a comment
+// @Description Set the reaction of the current user to a comment, a user has at most one reaction per comment.
+// @Description The comment has to belong to the post.
+// @Description Repeating the request changes nothing, sending another type replaces the reaction.
+// @Security BearerAuth
+// @Tags reaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Param reaction body post_service.Reaction true "Reaction, only type is used: like, love, haha, wow, sad or angry"
+// @Success 200 {object} post_service.ReactionSummary
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) ReactToComment(ctx *gin.Context) {
+	h.upsertReaction(ctx, "comment", ctx.Param("comment_id"))
+}
+
+// DeleteCommentReaction godoc
+// @Router /post/{id}/comments/{comment_id}/reactions [delete]
+// @Summary Remove the reaction to a comment
+// @Description Remove the reaction of the current user to a comment
+// @Security BearerAuth
+// @Tags reaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Success 200 {object} post_service.ReactionSummary
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) DeleteCommentReaction(ctx *gin.Context) {
+	h.deleteReaction(ctx, "comment", ctx.Param("comment_id"))
+}
+
+// GetCommentReactions godoc
+// @Router /post/{id}/comments/{comment_id}/reactions [get]
+// @Summary Get who reacted to a comment
+// @Description Get the users who reacted to a comment, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags reaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Param type query string false "only reactions of this type"
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.ReactionList
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 404 {object} user_service.ErrorResponse
+func (h *handler) GetCommentReactions(ctx *gin.Context) {
+	h.getReactions(ctx, "comment", ctx.Param("comment_id"))
+}
+
+func (h *handler) upsertReaction(ctx *gin.Context, targetType, targetID string) {
+	var (
+		body *post_service.Reaction
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	summary, err := h.grpcClient.ReactionService().Upsert(ctx, &post_service.Reaction{
+		TargetType: targetType,
+		TargetId:   targetID,
+		UserId:     ctx.GetHeader("sub"),
+		Type:       body.Type,
+		PostId:     ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error reacting to "+targetType) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (h *handler) deleteReaction(ctx *gin.Context, targetType, targetID string) {
+	summary, err := h.grpcClient.ReactionService().Delete(ctx, &post_service.ReactionSingleRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		UserId:     ctx.GetHeader("sub"),
+		PostId:     ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error deleting reaction to "+targetType) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (h *handler) getReactions(ctx *gin.Context, targetType, targetID string) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "20"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	reactions, err := h.grpcClient.ReactionService().GetList(ctx, &post_service.GetListReactionRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		Type:       ctx.Query("type"),
+		Cursor:     ctx.Query("cursor"),
+		Limit:      limit,
+		PostId:     ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting reactions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reactions)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPostRevisions godoc
+// @Router /post/{id}/revisions [get]
+// @Summary Get the edit history of a post
+// @Description Get the earlier versions of a post, newest first.
+// @Description A version is kept every time the content or the status of the post changes.
+// @Description Only the author, moderators and admins can read the history of a post.
+// @Security BearerAuth
+// @Tags revision
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Success 200 {object} post_service.PostRevisionList
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 403 {object} user_service.ErrorResponse
+func (h *handler) GetPostRevisions(ctx *gin.Context) {
+	revisions, err := h.grpcClient.PostRevisionService().GetList(ctx, &post_service.GetListPostRevisionRequest{
+		PostId: ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting post revisions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, revisions)
+}
+
+// DiffPostRevisions godoc
+// @Router /post/{id}/revisions/diff [get]
+// @Summary Compare two versions of a post
+// @Description Get the changes between two revisions of a post, word by word.
+// @Description Leave to empty to compare with the current version.
+// @Description Only the author, moderators and admins can read the history of a post.
+// @Security BearerAuth
+// @Tags revision
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param from query int true "revision"
+// @Param to query int false "revision, 0 is the current version"
+// @Success 200 {object} post_service.PostRevisionDiff
+// @Failure 400 {object} user_service.ErrorResponse
+// @Failure 403 {object} user_service.ErrorResponse
+func (h *handler) DiffPostRevisions(ctx *gin.Context) {
+	from, err := strconv.ParseInt(ctx.Query("from"), 10, 32)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid from", 400)
+		return
+	}
+
+	to, err := strconv.ParseInt(ctx.DefaultQuery("to", "0"), 10, 32)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid to", 400)
+		return
+	}
+
+	diff, err := h.grpcClient.PostRevisionService().Diff(ctx, &post_service.DiffPostRevisionsRequest{
+		PostId: ctx.Param("id"),
+		From:   int32(from),
+		To:     int32(to),
+	})
+	if h.HandleDbError(ctx, err, "Error comparing post revisions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diff)
+}
+
+// RestorePostRevision godoc
+// @Router /post/{id}/revisions/{revision}/restore [post]
+// @Summary Restore an earlier version of a post
+// @Description Make the content and the tags of a revision the current version of the post, the status is kept.
+// @Description The replaced version is kept as a new revision. Only the author can restore a post.
+// @Security BearerAuth
+// @Tags revision
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Post ID"
+// @Param revision path int true "Revision"
+// @Success 200 {object} post_service.Post
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) RestorePostRevision(ctx *gin.Context) {
+	revision, err := strconv.ParseInt(ctx.Param("revision"), 10, 32)
+	if err != nil || revision <= 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid revision", 400)
+		return
+	}
+
+	if !h.isPostOwner(ctx, ctx.Param("id")) {
+		return
+	}
+
+	post, err := h.grpcClient.PostRevisionService().Restore(ctx, &post_service.RestorePostRevisionRequest{
+		PostId:   ctx.Param("id"),
+		Revision: int32(revision),
+		EditorId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error restoring post revision") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, post)
+}
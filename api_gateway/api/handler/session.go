@@ -40,11 +40,15 @@ func (h *handler) GetSession(ctx *gin.Context) {
 // @Summary Get a list of users
 // @Description Get a list of users
 // @Security BearerAuth
+// @Description Pass next_cursor of the response as cursor to get the next page, page is deprecated and ignored when cursor is set.
+// @Description The total count is only returned when with_total is true.
 // @Tags session
 // @Accept  json
 // @Produce  json
-// @Param page query number true "page"
+// @Param page query number false "page"
 // @Param limit query number true "limit"
+// @Param cursor query string false "cursor"
+// @Param with_total query bool false "with_total"
 // @Param user_id query string false "user_id"
 // @Success 200 {object} user_service.GetListSessionResponse
 // @Failure 400 {object} user_service.ErrorResponse
@@ -73,8 +77,16 @@ func (h *handler) GetSessions(ctx *gin.Context) {
 		return
 	}
 
+	withTotal, err := strconv.ParseBool(ctx.DefaultQuery("with_total", "false"))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid with_total", 400)
+		return
+	}
+
 	req.Page = page
 	req.Limit = limit
+	req.Cursor = ctx.Query("cursor")
+	req.WithTotal = withTotal
 
 	resp, err = h.grpcClient.SessionService().GetList(ctx.Request.Context(), &req)
 	if h.HandleDbError(ctx, err, "Error getting session") {
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/user_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserSettings godoc
+// @Router /user/settings [get]
+// @Summary Get settings of the current user
+// @Description Get locale, timezone, default post status, privacy and notification settings of the current user
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} user_service.UserSettings
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetUserSettings(ctx *gin.Context) {
+	settings, err := h.grpcClient.UserSettingsService().GetSingle(ctx, &user_service.UserSettingsSingleRequest{
+		UserId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting user settings") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateUserSettings godoc
+// @Router /user/settings [patch]
+// @Summary Update settings of the current user
+// @Description Partially update settings of the current user, only the fields present in the body are changed
+// @Security BearerAuth
+// @Tags user
+// @Accept  json
+// @Produce  json
+// @Param settings body user_service.UpdateUserSettingsRequest true "Settings fields to change"
+// @Success 200 {object} user_service.UserSettings
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) UpdateUserSettings(ctx *gin.Context) {
+	var (
+		body *user_service.UpdateUserSettingsRequest
+	)
+
+	err := ctx.ShouldBindJSON(&body)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.UserId = ctx.GetHeader("sub")
+
+	settings, err := h.grpcClient.UserSettingsService().Update(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating user settings") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
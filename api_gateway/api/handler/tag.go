@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"user_api_gateway/config"
+	"user_api_gateway/genproto/post_service"
+	"user_api_gateway/genproto/user_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTag godoc
+// @Router /tags/{tag} [get]
+// @Summary Get a tag
+// @Description Get a tag with the number of public published posts that have it.
+// @Description The tag is matched without the leading '#' and case insensitively.
+// @Security BearerAuth
+// @Tags tag
+// @Accept  json
+// @Produce  json
+// @Param tag path string true "Tag"
+// @Success 200 {object} post_service.Tag
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetTag(ctx *gin.Context) {
+	tag, err := h.grpcClient.TagService().GetSingle(ctx, &post_service.TagSingleRequest{
+		Tag: ctx.Param("tag"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting tag") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tag)
+}
+
+// GetTagPosts godoc
+// @Router /tags/{tag}/posts [get]
+// @Summary Get posts with a tag
+// @Description Get published posts with a tag, newest first.
+// @Description Pass next_cursor of the response as cursor to get the next page.
+// @Security BearerAuth
+// @Tags tag
+// @Accept  json
+// @Produce  json
+// @Param tag path string true "Tag"
+// @Param source query string false "source" Enums(ai, hashtag, manual)
+// @Param cursor query string false "cursor"
+// @Param limit query number false "limit"
+// @Success 200 {object} post_service.PostList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetTagPosts(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	posts, err := h.grpcClient.TagService().GetPosts(ctx, &post_service.GetTagPostsRequest{
+		Tag:      ctx.Param("tag"),
+		Source:   ctx.Query("source"),
+		Cursor:   ctx.Query("cursor"),
+		Limit:    limit,
+		ViewerId: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error getting tag posts") {
+		return
+	}
+
+	h.sendPosts(ctx, http.StatusOK, posts)
+}
+
+// GetTrendingTags godoc
+// @Router /tags/trending [get]
+// @Summary Get trending tags
+// @Description Get the tags used most in recent public posts and the comments and reactions to them, highest score first.
+// @Description Recent activity counts more than older activity, the list is refreshed every few minutes.
+// @Security BearerAuth
+// @Tags tag
+// @Accept  json
+// @Produce  json
+// @Param window query string false "window" Enums(1h, 24h, 7d) default(24h)
+// @Param category query string false "only tags of this category"
+// @Param limit query number false "limit, at most 50"
+// @Success 200 {object} post_service.TrendingTagList
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) GetTrendingTags(ctx *gin.Context) {
+	limit, err := strconv.ParseUint(ctx.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid limit", 400)
+		return
+	}
+
+	tags, err := h.grpcClient.TagService().GetTrending(ctx, &post_service.GetTrendingRequest{
+		Window:   ctx.DefaultQuery("window", "24h"),
+		Category: ctx.Query("category"),
+		Limit:    limit,
+	})
+	if h.HandleDbError(ctx, err, "Error getting trending tags") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// BlockTag godoc
+// @Router /tags/{tag}/block [post]
+// @Summary Block a tag
+// @Description Block a tag so it is never shown as trending, blocking it again updates the reason.
+// @Security BearerAuth
+// @Tags tag
+// @Accept  json
+// @Produce  json
+// @Param tag path string true "Tag"
+// @Param block body post_service.BlockedTag false "Block, only reason is used"
+// @Success 200 {object} post_service.BlockedTag
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) BlockTag(ctx *gin.Context) {
+	var (
+		body = &post_service.BlockedTag{}
+	)
+
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(body); err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+			return
+		}
+	}
+
+	blocked, err := h.grpcClient.BlockedTagService().Create(ctx, &post_service.BlockedTag{
+		Tag:       ctx.Param("tag"),
+		Reason:    body.Reason,
+		BlockedBy: ctx.GetHeader("sub"),
+	})
+	if h.HandleDbError(ctx, err, "Error blocking tag") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, blocked)
+}
+
+// UnblockTag godoc
+// @Router /tags/{tag}/block [delete]
+// @Summary Unblock a tag
+// @Description Unblock a tag, it can be shown as trending again after the next refresh.
+// @Security BearerAuth
+// @Tags tag
+// @Accept  json
+// @Produce  json
+// @Param tag path string true "Tag"
+// @Success 200 {object} user_service.SuccessResponse
+// @Failure 400 {object} user_service.ErrorResponse
+func (h *handler) UnblockTag(ctx *gin.Context) {
+	_, err := h.grpcClient.BlockedTagService().Delete(ctx, &post_service.TagSingleRequest{
+		Tag: ctx.Param("tag"),
+	})
+	if h.HandleDbError(ctx, err, "Error unblocking tag") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user_service.SuccessResponse{
+		Message: "Tag unblocked successfully",
+	})
+}
@@ -65,13 +65,17 @@ func (h *handler) CreateUser(ctx *gin.Context) {
 // GetListUser godoc
 // @Router         /user/list [GET]
 // @Summary        Get list of users
-// @Description    API for getting list of users
+// @Description    API for getting list of users, newest first.
+// @Description    Pass next_cursor of the response as cursor to get the next page, page is deprecated and ignored when cursor is set.
+// @Description    The total count is only returned when with_total is true.
 // @Security       BearerAuth
 // @Tags           user
 // @Accept         json
 // @Produce        json
-// @Param          page query int true "page"
+// @Param          page query int false "page"
 // @Param          limit query int true "limit"
+// @Param          cursor query string false "cursor"
+// @Param          with_total query bool false "with_total"
 // @Param          search query string false "search"
 // @Success        200 {object} user_service.GetListUserResponse
 // @Failure        404 {object} user_service.ErrorResponse
@@ -102,8 +106,16 @@ func (h *handler) GetUsers(ctx *gin.Context) {
 		return
 	}
 
+	withTotal, err := strconv.ParseBool(ctx.DefaultQuery("with_total", "false"))
+	if err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid with_total", 400)
+		return
+	}
+
 	req.Page = page
 	req.Limit = limit
+	req.Cursor = ctx.Query("cursor")
+	req.WithTotal = withTotal
 
 	resp, err = h.grpcClient.UserService().GetList(ctx.Request.Context(), &req)
 	if h.HandleDbError(ctx, err, "Error getting users") {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"user_api_gateway/api/handler"
 	"user_api_gateway/config"
+	"user_api_gateway/pkg/blob"
 	"user_api_gateway/pkg/grpc_client"
 	"user_api_gateway/pkg/logger"
 
@@ -23,6 +24,8 @@ type Config struct {
 	GrpcClient *grpc_client.GrpcClient
 	Cfg        config.Config
 	Redis      rediscache.RedisCache
+	Blobs      blob.Store
+	Images     blob.Store
 }
 
 // NewRouter -.
@@ -36,8 +39,8 @@ type Config struct {
 // @name Authorization
 func New(cnf Config) *gin.Engine {
 	r := gin.New()
-
-	r.Static("/images", "./static/images")
+	// gin.Context is passed to the gRPC clients, the fallback exposes the request metadata to them
+	r.ContextWithFallback = true
 
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
@@ -53,9 +56,13 @@ func New(cnf Config) *gin.Engine {
 			GrpcClient: cnf.GrpcClient,
 			Cfg:        cnf.Cfg,
 			Redis:      cnf.Redis,
+			Blobs:      cnf.Blobs,
+			Images:     cnf.Images,
 		},
 	)
 
+	r.Use(handler.RequestMetadata())
+
 	// Initialize Casbin enforcer
 	e := casbin.NewEnforcer("config/rbac.conf", "config/policy.csv")
 
@@ -77,7 +84,16 @@ func New(cnf Config) *gin.Engine {
 	{
 		user.POST("/", handler.CreateUser)
 		user.GET("/list", handler.GetUsers)
+		user.GET("/settings", handler.GetUserSettings)
+		user.PATCH("/settings", handler.UpdateUserSettings)
+		user.POST("/bulk", handler.CreateBulkUserJob)
+		user.GET("/bulk/list", handler.GetBulkUserJobs)
+		user.GET("/bulk/:id", handler.GetBulkUserJob)
 		user.GET("/:id", handler.GetUser)
+		user.POST("/:id/follow", handler.FollowUser)
+		user.DELETE("/:id/follow", handler.UnfollowUser)
+		user.GET("/:id/followers", handler.GetFollowers)
+		user.GET("/:id/following", handler.GetFollowing)
 		user.PUT("/", handler.UpdateUser)
 		user.DELETE("/:id", handler.DeleteUser)
 	}
@@ -102,8 +118,82 @@ func New(cnf Config) *gin.Engine {
 		post.POST("/", handler.CreatePost)
 		post.GET("/:id", handler.GetPost)
 		post.GET("/list", handler.GetPosts)
+		post.GET("/feed", handler.GetHomeFeed)
+		post.GET("/search", handler.SearchPosts)
+		post.GET("/mentions", handler.GetMentionPosts)
 		post.PUT("/", handler.UpdatePost)
 		post.DELETE("/:id", handler.DeletePost)
+		post.PUT("/:id/schedule", handler.ReschedulePost)
+		post.DELETE("/:id/schedule", handler.CancelPostSchedule)
+		post.POST("/:id/repost", handler.Repost)
+		post.DELETE("/:id/repost", handler.Unrepost)
+		post.GET("/:id/stats", handler.GetPostStats)
+		post.POST("/:id/poll/vote", handler.VotePoll)
+		post.POST("/:id/bookmark", handler.BookmarkPost)
+		post.DELETE("/:id/bookmark", handler.UnbookmarkPost)
+
+		post.GET("/:id/revisions", handler.GetPostRevisions)
+		post.GET("/:id/revisions/diff", handler.DiffPostRevisions)
+		post.POST("/:id/revisions/:revision/restore", handler.RestorePostRevision)
+
+		post.POST("/:id/comments", handler.CreateComment)
+		post.GET("/:id/comments", handler.GetComments)
+		post.PUT("/:id/comments/:comment_id", handler.UpdateComment)
+		post.DELETE("/:id/comments/:comment_id", handler.DeleteComment)
+
+		post.PUT("/:id/reactions", handler.ReactToPost)
+		post.DELETE("/:id/reactions", handler.DeletePostReaction)
+		post.GET("/:id/reactions", handler.GetPostReactions)
+		post.PUT("/:id/comments/:comment_id/reactions", handler.ReactToComment)
+		post.DELETE("/:id/comments/:comment_id/reactions", handler.DeleteCommentReaction)
+		post.GET("/:id/comments/:comment_id/reactions", handler.GetCommentReactions)
+	}
+
+	bookmarks := protected.Group("/bookmarks")
+	{
+		bookmarks.GET("/list", handler.GetBookmarks)
+		bookmarks.POST("/collections", handler.CreateBookmarkCollection)
+		bookmarks.GET("/collections", handler.GetBookmarkCollections)
+		bookmarks.PUT("/collections/order", handler.ReorderBookmarkCollections)
+		bookmarks.GET("/collections/:id", handler.GetBookmarkCollection)
+		bookmarks.PUT("/collections/:id", handler.UpdateBookmarkCollection)
+		bookmarks.DELETE("/collections/:id", handler.DeleteBookmarkCollection)
+		bookmarks.GET("/collections/:id/posts", handler.GetBookmarkCollectionPosts)
+	}
+
+	tags := protected.Group("/tags")
+	{
+		tags.GET("/trending", handler.GetTrendingTags)
+		tags.GET("/:tag", handler.GetTag)
+		tags.GET("/:tag/posts", handler.GetTagPosts)
+		tags.POST("/:tag/block", handler.BlockTag)
+		tags.DELETE("/:tag/block", handler.UnblockTag)
+	}
+
+	reports := protected.Group("/reports")
+	{
+		reports.POST("/", handler.CreateReport)
+		reports.GET("/list", handler.GetReports)
+		reports.GET("/queue", handler.GetModerationQueue)
+	}
+
+	media := protected.Group("/media")
+	{
+		media.POST("", handler.UploadMedia)
+		media.GET("/*key", handler.GetMedia)
+	}
+
+	protected.GET("/images/*path", handler.GetImage)
+
+	moderation := protected.Group("/moderation")
+	{
+		moderation.POST("/actions", handler.CreateModerationAction)
+		moderation.GET("/actions/list", handler.GetModerationActions)
+	}
+
+	audit := protected.Group("/audit")
+	{
+		audit.GET("/list", handler.GetAuditEvents)
 	}
 
 	// Swagger endpoint
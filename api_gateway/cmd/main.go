@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"user_api_gateway/api"
 	"user_api_gateway/config"
+	"user_api_gateway/pkg/blob"
 	"user_api_gateway/pkg/grpc_client"
 	"user_api_gateway/pkg/logger"
+	"user_api_gateway/worker"
 
 	rediscache "github.com/golanguzb70/redis-cache"
 )
@@ -15,6 +18,8 @@ var (
 	cfg        config.Config
 	grpcClient *grpc_client.GrpcClient
 	redis      rediscache.RedisCache
+	blobs      blob.Store
+	images     blob.Store
 )
 
 // initDeps initializes dependencies like config, logger, Redis, and gRPC client
@@ -36,16 +41,31 @@ func initDeps() {
 	if err != nil {
 		log.Fatal("grpc dial error", logger.Error(err))
 	}
+
+	blobs, err = blob.New(cfg)
+	if err != nil {
+		log.Fatal("blob store error", logger.Error(err))
+	}
+
+	images, err = blob.NewLocal("./static/images")
+	if err != nil {
+		log.Fatal("image store error", logger.Error(err))
+	}
 }
 
 func main() {
 	initDeps()
 
+	go worker.NewImages(cfg, log, grpcClient, blobs).Run(context.Background())
+	go worker.NewBlobs(log, grpcClient, blobs).Run(context.Background())
+
 	server := api.New(api.Config{
 		Logger:     log,
 		GrpcClient: grpcClient,
 		Cfg:        cfg,
 		Redis:      redis,
+		Blobs:      blobs,
+		Images:     images,
 	})
 
 	fmt.Println("Starting server on port", cfg.HTTPPort)
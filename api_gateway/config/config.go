@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -50,6 +52,32 @@ type Config struct {
 	RedisPassword string
 
 	PostgresMaxConnections int32
+
+	// BlobBackend is where the uploaded media is stored, local (the default) or s3
+	BlobBackend string
+	BlobDir     string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+
+	// The size limits of the uploads in bytes by type, when not set 10 MiB for photos, 100 MiB
+	// for videos and 25 MiB for audio and documents
+	PhotoMaxSize    int64
+	VideoMaxSize    int64
+	AudioMaxSize    int64
+	DocumentMaxSize int64
+	// MediaURLSecret signs the URLs of the media, the JWT secret is used when it is not set
+	MediaURLSecret string
+	// MediaURLTTL is how long a signed URL works, an hour when not set
+	MediaURLTTL time.Duration
+
+	// ImageVariantWidths are the widths the uploaded photos are resized to, 320,640,1280 when not set
+	ImageVariantWidths []int
+	// ImageProcessInterval is how often the uploaded photos are looked for, 10s when not set
+	ImageProcessInterval time.Duration
 }
 
 // Load reads environment variables and returns a Config instance
@@ -88,6 +116,26 @@ func Load() Config {
 		PostServiceHost: cast.ToString(os.Getenv("POST_SERVICE_HOST")),
 		PostServicePort: cast.ToString(os.Getenv("POST_SERVICE_PORT")),
 
+		BlobBackend: cast.ToString(os.Getenv("BLOB_BACKEND")),
+		BlobDir:     cast.ToString(os.Getenv("BLOB_DIR")),
+
+		S3Endpoint:  cast.ToString(os.Getenv("S3_ENDPOINT")),
+		S3Region:    cast.ToString(os.Getenv("S3_REGION")),
+		S3Bucket:    cast.ToString(os.Getenv("S3_BUCKET")),
+		S3AccessKey: cast.ToString(os.Getenv("S3_ACCESS_KEY")),
+		S3SecretKey: cast.ToString(os.Getenv("S3_SECRET_KEY")),
+
+		PhotoMaxSize:    cast.ToInt64(os.Getenv("MEDIA_MAX_SIZE_PHOTO")),
+		VideoMaxSize:    cast.ToInt64(os.Getenv("MEDIA_MAX_SIZE_VIDEO")),
+		AudioMaxSize:    cast.ToInt64(os.Getenv("MEDIA_MAX_SIZE_AUDIO")),
+		DocumentMaxSize: cast.ToInt64(os.Getenv("MEDIA_MAX_SIZE_DOCUMENT")),
+
+		MediaURLSecret: cast.ToString(os.Getenv("MEDIA_URL_SECRET")),
+		MediaURLTTL:    cast.ToDuration(os.Getenv("MEDIA_URL_TTL")),
+
+		ImageVariantWidths:   cast.ToIntSlice(strings.Split(os.Getenv("IMAGE_VARIANT_WIDTHS"), ",")),
+		ImageProcessInterval: cast.ToDuration(os.Getenv("IMAGE_PROCESS_INTERVAL")),
+
 		LogLevel: cast.ToString(os.Getenv("LOG_LEVEL")),
 		HTTPPort: cast.ToString(os.Getenv("HTTP_PORT")),
 	}
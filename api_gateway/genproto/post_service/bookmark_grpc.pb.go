@@ -0,0 +1,506 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: bookmark.proto
+
+package post_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BookmarkService_Create_FullMethodName  = "/post_service.BookmarkService/Create"
+	BookmarkService_Delete_FullMethodName  = "/post_service.BookmarkService/Delete"
+	BookmarkService_GetList_FullMethodName = "/post_service.BookmarkService/GetList"
+)
+
+// BookmarkServiceClient is the client API for BookmarkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BookmarkService saves posts for later, the saved posts can also be sorted into collections.
+type BookmarkServiceClient interface {
+	// Create bookmarks a post, or adds it to a collection as well when collection_id is set.
+	// Bookmarking a post again changes nothing
+	Create(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Delete removes a post from a collection, or without collection_id removes the bookmark
+	// and the post from every collection. Removing a post that is not saved changes nothing
+	Delete(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetList returns the bookmarked posts of a user, or the posts of a collection, most recently saved first
+	GetList(ctx context.Context, in *GetListBookmarkRequest, opts ...grpc.CallOption) (*PostList, error)
+}
+
+type bookmarkServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBookmarkServiceClient(cc grpc.ClientConnInterface) BookmarkServiceClient {
+	return &bookmarkServiceClient{cc}
+}
+
+func (c *bookmarkServiceClient) Create(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BookmarkService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) Delete(ctx context.Context, in *BookmarkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BookmarkService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkServiceClient) GetList(ctx context.Context, in *GetListBookmarkRequest, opts ...grpc.CallOption) (*PostList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostList)
+	err := c.cc.Invoke(ctx, BookmarkService_GetList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BookmarkServiceServer is the server API for BookmarkService service.
+// All implementations should embed UnimplementedBookmarkServiceServer
+// for forward compatibility.
+//
+// BookmarkService saves posts for later, the saved posts can also be sorted into collections.
+type BookmarkServiceServer interface {
+	// Create bookmarks a post, or adds it to a collection as well when collection_id is set.
+	// Bookmarking a post again changes nothing
+	Create(context.Context, *BookmarkRequest) (*emptypb.Empty, error)
+	// Delete removes a post from a collection, or without collection_id removes the bookmark
+	// and the post from every collection. Removing a post that is not saved changes nothing
+	Delete(context.Context, *BookmarkRequest) (*emptypb.Empty, error)
+	// GetList returns the bookmarked posts of a user, or the posts of a collection, most recently saved first
+	GetList(context.Context, *GetListBookmarkRequest) (*PostList, error)
+}
+
+// UnimplementedBookmarkServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBookmarkServiceServer struct{}
+
+func (UnimplementedBookmarkServiceServer) Create(context.Context, *BookmarkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedBookmarkServiceServer) Delete(context.Context, *BookmarkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedBookmarkServiceServer) GetList(context.Context, *GetListBookmarkRequest) (*PostList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedBookmarkServiceServer) testEmbeddedByValue() {}
+
+// UnsafeBookmarkServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BookmarkServiceServer will
+// result in compilation errors.
+type UnsafeBookmarkServiceServer interface {
+	mustEmbedUnimplementedBookmarkServiceServer()
+}
+
+func RegisterBookmarkServiceServer(s grpc.ServiceRegistrar, srv BookmarkServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBookmarkServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BookmarkService_ServiceDesc, srv)
+}
+
+func _BookmarkService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).Create(ctx, req.(*BookmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).Delete(ctx, req.(*BookmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListBookmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkServiceServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkService_GetList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkServiceServer).GetList(ctx, req.(*GetListBookmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BookmarkService_ServiceDesc is the grpc.ServiceDesc for BookmarkService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookmarkService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.BookmarkService",
+	HandlerType: (*BookmarkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _BookmarkService_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _BookmarkService_Delete_Handler,
+		},
+		{
+			MethodName: "GetList",
+			Handler:    _BookmarkService_GetList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bookmark.proto",
+}
+
+const (
+	BookmarkCollectionService_Create_FullMethodName    = "/post_service.BookmarkCollectionService/Create"
+	BookmarkCollectionService_GetSingle_FullMethodName = "/post_service.BookmarkCollectionService/GetSingle"
+	BookmarkCollectionService_GetList_FullMethodName   = "/post_service.BookmarkCollectionService/GetList"
+	BookmarkCollectionService_Update_FullMethodName    = "/post_service.BookmarkCollectionService/Update"
+	BookmarkCollectionService_Delete_FullMethodName    = "/post_service.BookmarkCollectionService/Delete"
+	BookmarkCollectionService_Reorder_FullMethodName   = "/post_service.BookmarkCollectionService/Reorder"
+)
+
+// BookmarkCollectionServiceClient is the client API for BookmarkCollectionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BookmarkCollectionServiceClient interface {
+	// Create returns the existing collection if the owner already has one with the name
+	Create(ctx context.Context, in *BookmarkCollection, opts ...grpc.CallOption) (*BookmarkCollection, error)
+	GetSingle(ctx context.Context, in *BookmarkCollectionSingleRequest, opts ...grpc.CallOption) (*BookmarkCollection, error)
+	GetList(ctx context.Context, in *GetListBookmarkCollectionRequest, opts ...grpc.CallOption) (*BookmarkCollectionList, error)
+	Update(ctx context.Context, in *BookmarkCollection, opts ...grpc.CallOption) (*BookmarkCollection, error)
+	Delete(ctx context.Context, in *BookmarkCollectionSingleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Reorder puts the collections of the owner in the order of ids, collections that are not
+	// in ids follow in their current order
+	Reorder(ctx context.Context, in *ReorderBookmarkCollectionsRequest, opts ...grpc.CallOption) (*BookmarkCollectionList, error)
+}
+
+type bookmarkCollectionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBookmarkCollectionServiceClient(cc grpc.ClientConnInterface) BookmarkCollectionServiceClient {
+	return &bookmarkCollectionServiceClient{cc}
+}
+
+func (c *bookmarkCollectionServiceClient) Create(ctx context.Context, in *BookmarkCollection, opts ...grpc.CallOption) (*BookmarkCollection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookmarkCollection)
+	err := c.cc.Invoke(ctx, BookmarkCollectionService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkCollectionServiceClient) GetSingle(ctx context.Context, in *BookmarkCollectionSingleRequest, opts ...grpc.CallOption) (*BookmarkCollection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookmarkCollection)
+	err := c.cc.Invoke(ctx, BookmarkCollectionService_GetSingle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkCollectionServiceClient) GetList(ctx context.Context, in *GetListBookmarkCollectionRequest, opts ...grpc.CallOption) (*BookmarkCollectionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookmarkCollectionList)
+	err := c.cc.Invoke(ctx, BookmarkCollectionService_GetList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkCollectionServiceClient) Update(ctx context.Context, in *BookmarkCollection, opts ...grpc.CallOption) (*BookmarkCollection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookmarkCollection)
+	err := c.cc.Invoke(ctx, BookmarkCollectionService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkCollectionServiceClient) Delete(ctx context.Context, in *BookmarkCollectionSingleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BookmarkCollectionService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookmarkCollectionServiceClient) Reorder(ctx context.Context, in *ReorderBookmarkCollectionsRequest, opts ...grpc.CallOption) (*BookmarkCollectionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookmarkCollectionList)
+	err := c.cc.Invoke(ctx, BookmarkCollectionService_Reorder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BookmarkCollectionServiceServer is the server API for BookmarkCollectionService service.
+// All implementations should embed UnimplementedBookmarkCollectionServiceServer
+// for forward compatibility.
+type BookmarkCollectionServiceServer interface {
+	// Create returns the existing collection if the owner already has one with the name
+	Create(context.Context, *BookmarkCollection) (*BookmarkCollection, error)
+	GetSingle(context.Context, *BookmarkCollectionSingleRequest) (*BookmarkCollection, error)
+	GetList(context.Context, *GetListBookmarkCollectionRequest) (*BookmarkCollectionList, error)
+	Update(context.Context, *BookmarkCollection) (*BookmarkCollection, error)
+	Delete(context.Context, *BookmarkCollectionSingleRequest) (*emptypb.Empty, error)
+	// Reorder puts the collections of the owner in the order of ids, collections that are not
+	// in ids follow in their current order
+	Reorder(context.Context, *ReorderBookmarkCollectionsRequest) (*BookmarkCollectionList, error)
+}
+
+// UnimplementedBookmarkCollectionServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBookmarkCollectionServiceServer struct{}
+
+func (UnimplementedBookmarkCollectionServiceServer) Create(context.Context, *BookmarkCollection) (*BookmarkCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedBookmarkCollectionServiceServer) GetSingle(context.Context, *BookmarkCollectionSingleRequest) (*BookmarkCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSingle not implemented")
+}
+func (UnimplementedBookmarkCollectionServiceServer) GetList(context.Context, *GetListBookmarkCollectionRequest) (*BookmarkCollectionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedBookmarkCollectionServiceServer) Update(context.Context, *BookmarkCollection) (*BookmarkCollection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedBookmarkCollectionServiceServer) Delete(context.Context, *BookmarkCollectionSingleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedBookmarkCollectionServiceServer) Reorder(context.Context, *ReorderBookmarkCollectionsRequest) (*BookmarkCollectionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reorder not implemented")
+}
+func (UnimplementedBookmarkCollectionServiceServer) testEmbeddedByValue() {}
+
+// UnsafeBookmarkCollectionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BookmarkCollectionServiceServer will
+// result in compilation errors.
+type UnsafeBookmarkCollectionServiceServer interface {
+	mustEmbedUnimplementedBookmarkCollectionServiceServer()
+}
+
+func RegisterBookmarkCollectionServiceServer(s grpc.ServiceRegistrar, srv BookmarkCollectionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBookmarkCollectionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BookmarkCollectionService_ServiceDesc, srv)
+}
+
+func _BookmarkCollectionService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkCollection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkCollectionServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkCollectionService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkCollectionServiceServer).Create(ctx, req.(*BookmarkCollection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkCollectionService_GetSingle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkCollectionSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkCollectionServiceServer).GetSingle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkCollectionService_GetSingle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkCollectionServiceServer).GetSingle(ctx, req.(*BookmarkCollectionSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkCollectionService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListBookmarkCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkCollectionServiceServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkCollectionService_GetList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkCollectionServiceServer).GetList(ctx, req.(*GetListBookmarkCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkCollectionService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkCollection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkCollectionServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkCollectionService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkCollectionServiceServer).Update(ctx, req.(*BookmarkCollection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkCollectionService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookmarkCollectionSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkCollectionServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkCollectionService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkCollectionServiceServer).Delete(ctx, req.(*BookmarkCollectionSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookmarkCollectionService_Reorder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderBookmarkCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookmarkCollectionServiceServer).Reorder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookmarkCollectionService_Reorder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookmarkCollectionServiceServer).Reorder(ctx, req.(*ReorderBookmarkCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BookmarkCollectionService_ServiceDesc is the grpc.ServiceDesc for BookmarkCollectionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookmarkCollectionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.BookmarkCollectionService",
+	HandlerType: (*BookmarkCollectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _BookmarkCollectionService_Create_Handler,
+		},
+		{
+			MethodName: "GetSingle",
+			Handler:    _BookmarkCollectionService_GetSingle_Handler,
+		},
+		{
+			MethodName: "GetList",
+			Handler:    _BookmarkCollectionService_GetList_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _BookmarkCollectionService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _BookmarkCollectionService_Delete_Handler,
+		},
+		{
+			MethodName: "Reorder",
+			Handler:    _BookmarkCollectionService_Reorder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bookmark.proto",
+}
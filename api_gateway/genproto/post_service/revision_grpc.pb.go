@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: revision.proto
+
+package post_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PostRevisionService_GetList_FullMethodName = "/post_service.PostRevisionService/GetList"
+	PostRevisionService_Diff_FullMethodName    = "/post_service.PostRevisionService/Diff"
+	PostRevisionService_Restore_FullMethodName = "/post_service.PostRevisionService/Restore"
+)
+
+// PostRevisionServiceClient is the client API for PostRevisionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PostRevisionService keeps the earlier versions of the posts, a revision is saved every time
+// the content or the status of a post changes.
+type PostRevisionServiceClient interface {
+	GetList(ctx context.Context, in *GetListPostRevisionRequest, opts ...grpc.CallOption) (*PostRevisionList, error)
+	Diff(ctx context.Context, in *DiffPostRevisionsRequest, opts ...grpc.CallOption) (*PostRevisionDiff, error)
+	Restore(ctx context.Context, in *RestorePostRevisionRequest, opts ...grpc.CallOption) (*Post, error)
+}
+
+type postRevisionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPostRevisionServiceClient(cc grpc.ClientConnInterface) PostRevisionServiceClient {
+	return &postRevisionServiceClient{cc}
+}
+
+func (c *postRevisionServiceClient) GetList(ctx context.Context, in *GetListPostRevisionRequest, opts ...grpc.CallOption) (*PostRevisionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostRevisionList)
+	err := c.cc.Invoke(ctx, PostRevisionService_GetList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postRevisionServiceClient) Diff(ctx context.Context, in *DiffPostRevisionsRequest, opts ...grpc.CallOption) (*PostRevisionDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostRevisionDiff)
+	err := c.cc.Invoke(ctx, PostRevisionService_Diff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postRevisionServiceClient) Restore(ctx context.Context, in *RestorePostRevisionRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, PostRevisionService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PostRevisionServiceServer is the server API for PostRevisionService service.
+// All implementations should embed UnimplementedPostRevisionServiceServer
+// for forward compatibility.
+//
+// PostRevisionService keeps the earlier versions of the posts, a revision is saved every time
+// the content or the status of a post changes.
+type PostRevisionServiceServer interface {
+	GetList(context.Context, *GetListPostRevisionRequest) (*PostRevisionList, error)
+	Diff(context.Context, *DiffPostRevisionsRequest) (*PostRevisionDiff, error)
+	Restore(context.Context, *RestorePostRevisionRequest) (*Post, error)
+}
+
+// UnimplementedPostRevisionServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPostRevisionServiceServer struct{}
+
+func (UnimplementedPostRevisionServiceServer) GetList(context.Context, *GetListPostRevisionRequest) (*PostRevisionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedPostRevisionServiceServer) Diff(context.Context, *DiffPostRevisionsRequest) (*PostRevisionDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedPostRevisionServiceServer) Restore(context.Context, *RestorePostRevisionRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedPostRevisionServiceServer) testEmbeddedByValue() {}
+
+// UnsafePostRevisionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PostRevisionServiceServer will
+// result in compilation errors.
+type UnsafePostRevisionServiceServer interface {
+	mustEmbedUnimplementedPostRevisionServiceServer()
+}
+
+func RegisterPostRevisionServiceServer(s grpc.ServiceRegistrar, srv PostRevisionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPostRevisionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PostRevisionService_ServiceDesc, srv)
+}
+
+func _PostRevisionService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListPostRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostRevisionServiceServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostRevisionService_GetList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostRevisionServiceServer).GetList(ctx, req.(*GetListPostRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostRevisionService_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffPostRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostRevisionServiceServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostRevisionService_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostRevisionServiceServer).Diff(ctx, req.(*DiffPostRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostRevisionService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePostRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostRevisionServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostRevisionService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostRevisionServiceServer).Restore(ctx, req.(*RestorePostRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PostRevisionService_ServiceDesc is the grpc.ServiceDesc for PostRevisionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PostRevisionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.PostRevisionService",
+	HandlerType: (*PostRevisionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetList",
+			Handler:    _PostRevisionService_GetList_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _PostRevisionService_Diff_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _PostRevisionService_Restore_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "revision.proto",
+}
@@ -0,0 +1,338 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: tag.proto
+
+package post_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TagService_GetSingle_FullMethodName   = "/post_service.TagService/GetSingle"
+	TagService_GetPosts_FullMethodName    = "/post_service.TagService/GetPosts"
+	TagService_GetTrending_FullMethodName = "/post_service.TagService/GetTrending"
+)
+
+// TagServiceClient is the client API for TagService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TagServiceClient interface {
+	GetSingle(ctx context.Context, in *TagSingleRequest, opts ...grpc.CallOption) (*Tag, error)
+	GetPosts(ctx context.Context, in *GetTagPostsRequest, opts ...grpc.CallOption) (*PostList, error)
+	GetTrending(ctx context.Context, in *GetTrendingRequest, opts ...grpc.CallOption) (*TrendingTagList, error)
+}
+
+type tagServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTagServiceClient(cc grpc.ClientConnInterface) TagServiceClient {
+	return &tagServiceClient{cc}
+}
+
+func (c *tagServiceClient) GetSingle(ctx context.Context, in *TagSingleRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, TagService_GetSingle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetPosts(ctx context.Context, in *GetTagPostsRequest, opts ...grpc.CallOption) (*PostList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostList)
+	err := c.cc.Invoke(ctx, TagService_GetPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagServiceClient) GetTrending(ctx context.Context, in *GetTrendingRequest, opts ...grpc.CallOption) (*TrendingTagList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrendingTagList)
+	err := c.cc.Invoke(ctx, TagService_GetTrending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TagServiceServer is the server API for TagService service.
+// All implementations should embed UnimplementedTagServiceServer
+// for forward compatibility.
+type TagServiceServer interface {
+	GetSingle(context.Context, *TagSingleRequest) (*Tag, error)
+	GetPosts(context.Context, *GetTagPostsRequest) (*PostList, error)
+	GetTrending(context.Context, *GetTrendingRequest) (*TrendingTagList, error)
+}
+
+// UnimplementedTagServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTagServiceServer struct{}
+
+func (UnimplementedTagServiceServer) GetSingle(context.Context, *TagSingleRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSingle not implemented")
+}
+func (UnimplementedTagServiceServer) GetPosts(context.Context, *GetTagPostsRequest) (*PostList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPosts not implemented")
+}
+func (UnimplementedTagServiceServer) GetTrending(context.Context, *GetTrendingRequest) (*TrendingTagList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrending not implemented")
+}
+func (UnimplementedTagServiceServer) testEmbeddedByValue() {}
+
+// UnsafeTagServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TagServiceServer will
+// result in compilation errors.
+type UnsafeTagServiceServer interface {
+	mustEmbedUnimplementedTagServiceServer()
+}
+
+func RegisterTagServiceServer(s grpc.ServiceRegistrar, srv TagServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTagServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TagService_ServiceDesc, srv)
+}
+
+func _TagService_GetSingle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetSingle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_GetSingle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetSingle(ctx, req.(*TagSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTagPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_GetPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetPosts(ctx, req.(*GetTagPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TagService_GetTrending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagServiceServer).GetTrending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TagService_GetTrending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagServiceServer).GetTrending(ctx, req.(*GetTrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TagService_ServiceDesc is the grpc.ServiceDesc for TagService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TagService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.TagService",
+	HandlerType: (*TagServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSingle",
+			Handler:    _TagService_GetSingle_Handler,
+		},
+		{
+			MethodName: "GetPosts",
+			Handler:    _TagService_GetPosts_Handler,
+		},
+		{
+			MethodName: "GetTrending",
+			Handler:    _TagService_GetTrending_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tag.proto",
+}
+
+const (
+	BlockedTagService_Create_FullMethodName = "/post_service.BlockedTagService/Create"
+	BlockedTagService_Delete_FullMethodName = "/post_service.BlockedTagService/Delete"
+)
+
+// BlockedTagServiceClient is the client API for BlockedTagService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BlockedTagService manages the tags that are never shown as trending.
+type BlockedTagServiceClient interface {
+	Create(ctx context.Context, in *BlockedTag, opts ...grpc.CallOption) (*BlockedTag, error)
+	Delete(ctx context.Context, in *TagSingleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type blockedTagServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockedTagServiceClient(cc grpc.ClientConnInterface) BlockedTagServiceClient {
+	return &blockedTagServiceClient{cc}
+}
+
+func (c *blockedTagServiceClient) Create(ctx context.Context, in *BlockedTag, opts ...grpc.CallOption) (*BlockedTag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockedTag)
+	err := c.cc.Invoke(ctx, BlockedTagService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockedTagServiceClient) Delete(ctx context.Context, in *TagSingleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockedTagService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlockedTagServiceServer is the server API for BlockedTagService service.
+// All implementations should embed UnimplementedBlockedTagServiceServer
+// for forward compatibility.
+//
+// BlockedTagService manages the tags that are never shown as trending.
+type BlockedTagServiceServer interface {
+	Create(context.Context, *BlockedTag) (*BlockedTag, error)
+	Delete(context.Context, *TagSingleRequest) (*emptypb.Empty, error)
+}
+
+// UnimplementedBlockedTagServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBlockedTagServiceServer struct{}
+
+func (UnimplementedBlockedTagServiceServer) Create(context.Context, *BlockedTag) (*BlockedTag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedBlockedTagServiceServer) Delete(context.Context, *TagSingleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedBlockedTagServiceServer) testEmbeddedByValue() {}
+
+// UnsafeBlockedTagServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlockedTagServiceServer will
+// result in compilation errors.
+type UnsafeBlockedTagServiceServer interface {
+	mustEmbedUnimplementedBlockedTagServiceServer()
+}
+
+func RegisterBlockedTagServiceServer(s grpc.ServiceRegistrar, srv BlockedTagServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBlockedTagServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BlockedTagService_ServiceDesc, srv)
+}
+
+func _BlockedTagService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockedTag)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockedTagServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockedTagService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockedTagServiceServer).Create(ctx, req.(*BlockedTag))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockedTagService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockedTagServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockedTagService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockedTagServiceServer).Delete(ctx, req.(*TagSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BlockedTagService_ServiceDesc is the grpc.ServiceDesc for BlockedTagService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlockedTagService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.BlockedTagService",
+	HandlerType: (*BlockedTagServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _BlockedTagService_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _BlockedTagService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tag.proto",
+}
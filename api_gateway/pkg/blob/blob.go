@@ -0,0 +1,65 @@
+// Package blob stores the uploaded media files, on the local filesystem or in an S3 compatible bucket.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"user_api_gateway/config"
+)
+
+var (
+	// ErrNotFound is returned for a key that is not stored.
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidKey is returned for a key that is empty, absolute or leaves its directory.
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Object describes a stored blob.
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Store keeps blobs under slash separated keys like attachments/<owner>/<name>.jpg.
+type Store interface {
+	// Put stores size bytes of r under key, replacing what was stored there.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the blob stored under key, the caller closes it. The blob can be read from any
+	// offset, so ranges of it can be served.
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, *Object, error)
+	// Delete removes the blob stored under key, deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the store selected by cfg.BlobBackend, local (the default) or s3.
+func New(cfg config.Config) (Store, error) {
+	switch cfg.BlobBackend {
+	case "", "local":
+		return NewLocal(cfg.BlobDir)
+	case "s3":
+		return NewS3(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob backend %q", cfg.BlobBackend)
+	}
+}
+
+// checkKey rejects the keys that could escape the root of a store.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key || key == "." || key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("%w %q", ErrInvalidKey, key)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(ctx, "attachments/u1/a.png", strings.NewReader("png data"), 8, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, obj, err := store.Get(ctx, "attachments/u1/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+
+	if string(data) != "png data" || obj.Size != 8 || obj.ContentType != "image/png" {
+		t.Fatalf("got %q, %+v", data, obj)
+	}
+
+	if err := store.Put(ctx, "short", strings.NewReader("abc"), 4, ""); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("short body: got %v", err)
+	}
+	if _, _, err := store.Get(ctx, "short"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("short body was stored: %v", err)
+	}
+
+	if err := store.Delete(ctx, "attachments/u1/a.png"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(ctx, "attachments/u1/a.png"); err != nil {
+		t.Fatalf("deleting twice: %v", err)
+	}
+	if _, _, err := store.Get(ctx, "attachments/u1/a.png"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v after delete", err)
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	for _, key := range []string{"a.png", "attachments/u1/a.png"} {
+		if err := checkKey(key); err != nil {
+			t.Errorf("%q: %v", key, err)
+		}
+	}
+
+	for _, key := range []string{"", ".", "..", "/etc/passwd", "../a", "a/../../b", "a//b", "a/./b", `a\b`, "a/"} {
+		if err := checkKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("%q was accepted", key)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package blurhash
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestEncodeSolid(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 12))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	hash, err := Encode(img, 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the expected hashes were made with the reference implementation
+	if want := "LRTSUA_3fQ_3~qoffQoffQfQfQfQ"; hash != want {
+		t.Errorf("Encode() = %q, want %q", hash, want)
+	}
+}
+
+func TestEncodeGradient(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	for x := 0; x < 20; x++ {
+		for y := 0; y < 10; y++ {
+			img.Set(x, y, color.RGBA{uint8(x * 12), uint8(y * 25), 128, 255})
+		}
+	}
+
+	for _, c := range [][2]int{{1, 1}, {4, 3}, {9, 9}} {
+		hash, err := Encode(img, c[0], c[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := 4 + 2*c[0]*c[1]; len(hash) != want {
+			t.Errorf("Encode(%d, %d) has length %d, want %d", c[0], c[1], len(hash), want)
+		}
+	}
+
+	hash, _ := Encode(img, 4, 3)
+	if want := "LnF$X}2?wxbuuvR-jte=f%fjfQfj"; hash != want {
+		t.Errorf("Encode() = %q, want %q", hash, want)
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		img    image.Image
+		xc, yc int
+	}{
+		{"no x components", image.NewRGBA(image.Rect(0, 0, 4, 4)), 0, 3},
+		{"too many y components", image.NewRGBA(image.Rect(0, 0, 4, 4)), 4, 10},
+		{"empty image", image.NewRGBA(image.Rect(0, 0, 0, 4)), 4, 3},
+	}
+
+	for _, tt := range tests {
+		if _, err := Encode(tt.img, tt.xc, tt.yc); err == nil {
+			t.Errorf("%s: Encode() returned no error", tt.name)
+		}
+	}
+}
@@ -18,8 +18,24 @@ import (
 type GrpcClientI interface {
 	UserService() us.UserServiceClient
 	PostService() ps.PostServiceClient
+	PostRevisionService() ps.PostRevisionServiceClient
+	MentionService() ps.MentionServiceClient
+	BookmarkService() ps.BookmarkServiceClient
+	BookmarkCollectionService() ps.BookmarkCollectionServiceClient
+	ReportService() ps.ReportServiceClient
+	ModerationService() ps.ModerationServiceClient
 	SessionService() us.SessionServiceClient
+	UserSettingsService() us.UserSettingsServiceClient
+	BulkUserJobService() us.BulkUserJobServiceClient
+	AuditService() us.AuditServiceClient
+	FollowService() us.FollowServiceClient
 	PostAttachment() ps.PostAttachmentServiceClient
+	MediaService() ps.MediaServiceClient
+	CommentService() ps.CommentServiceClient
+	ReactionService() ps.ReactionServiceClient
+	FeedService() ps.FeedServiceClient
+	TagService() ps.TagServiceClient
+	BlockedTagService() ps.BlockedTagServiceClient
 }
 
 // GrpcClient ...
@@ -52,10 +68,26 @@ func New(cfg config.Config, redis rediscache.RedisCache) (*GrpcClient, error) {
 	return &GrpcClient{
 		cfg: cfg,
 		connections: map[string]interface{}{
-			"user_service":           us.NewUserServiceClient(connUser),
-			"session_service":        us.NewSessionServiceClient(connUser),
-			"post_service":           ps.NewPostServiceClient(connPost),
-			"postattachment_service": ps.NewPostAttachmentServiceClient(connPost),
+			"user_service":                us.NewUserServiceClient(connUser),
+			"session_service":             us.NewSessionServiceClient(connUser),
+			"user_settings_service":       us.NewUserSettingsServiceClient(connUser),
+			"bulk_user_job_service":       us.NewBulkUserJobServiceClient(connUser),
+			"audit_service":               us.NewAuditServiceClient(connUser),
+			"follow_service":              us.NewFollowServiceClient(connUser),
+			"post_service":                ps.NewPostServiceClient(connPost),
+			"post_revision_service":       ps.NewPostRevisionServiceClient(connPost),
+			"mention_service":             ps.NewMentionServiceClient(connPost),
+			"bookmark_service":            ps.NewBookmarkServiceClient(connPost),
+			"bookmark_collection_service": ps.NewBookmarkCollectionServiceClient(connPost),
+			"report_service":              ps.NewReportServiceClient(connPost),
+			"moderation_service":          ps.NewModerationServiceClient(connPost),
+			"postattachment_service":      ps.NewPostAttachmentServiceClient(connPost),
+			"media_service":               ps.NewMediaServiceClient(connPost),
+			"comment_service":             ps.NewCommentServiceClient(connPost),
+			"reaction_service":            ps.NewReactionServiceClient(connPost),
+			"feed_service":                ps.NewFeedServiceClient(connPost),
+			"tag_service":                 ps.NewTagServiceClient(connPost),
+			"blocked_tag_service":         ps.NewBlockedTagServiceClient(connPost),
 		},
 	}, nil
 }
@@ -78,6 +110,42 @@ func (g *GrpcClient) SessionService() us.SessionServiceClient {
 	return client
 }
 
+func (g *GrpcClient) UserSettingsService() us.UserSettingsServiceClient {
+	client, ok := g.connections["user_settings_service"].(us.UserSettingsServiceClient)
+	if !ok {
+		log.Println("failed to assert type for user_settings")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) BulkUserJobService() us.BulkUserJobServiceClient {
+	client, ok := g.connections["bulk_user_job_service"].(us.BulkUserJobServiceClient)
+	if !ok {
+		log.Println("failed to assert type for bulk_user_job")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) AuditService() us.AuditServiceClient {
+	client, ok := g.connections["audit_service"].(us.AuditServiceClient)
+	if !ok {
+		log.Println("failed to assert type for audit")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) FollowService() us.FollowServiceClient {
+	client, ok := g.connections["follow_service"].(us.FollowServiceClient)
+	if !ok {
+		log.Println("failed to assert type for follow")
+		return nil
+	}
+	return client
+}
+
 func (g *GrpcClient) PostService() ps.PostServiceClient {
 	client, ok := g.connections["post_service"].(ps.PostServiceClient)
 	if !ok {
@@ -96,6 +164,114 @@ func (g *GrpcClient) PostAttachmentService() ps.PostAttachmentServiceClient {
 	return client
 }
 
+func (g *GrpcClient) MediaService() ps.MediaServiceClient {
+	client, ok := g.connections["media_service"].(ps.MediaServiceClient)
+	if !ok {
+		log.Println("failed to assert type for media")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) CommentService() ps.CommentServiceClient {
+	client, ok := g.connections["comment_service"].(ps.CommentServiceClient)
+	if !ok {
+		log.Println("failed to assert type for comment")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) ReactionService() ps.ReactionServiceClient {
+	client, ok := g.connections["reaction_service"].(ps.ReactionServiceClient)
+	if !ok {
+		log.Println("failed to assert type for reaction")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) FeedService() ps.FeedServiceClient {
+	client, ok := g.connections["feed_service"].(ps.FeedServiceClient)
+	if !ok {
+		log.Println("failed to assert type for feed")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) TagService() ps.TagServiceClient {
+	client, ok := g.connections["tag_service"].(ps.TagServiceClient)
+	if !ok {
+		log.Println("failed to assert type for tag")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) BlockedTagService() ps.BlockedTagServiceClient {
+	client, ok := g.connections["blocked_tag_service"].(ps.BlockedTagServiceClient)
+	if !ok {
+		log.Println("failed to assert type for blocked tag")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) PostRevisionService() ps.PostRevisionServiceClient {
+	client, ok := g.connections["post_revision_service"].(ps.PostRevisionServiceClient)
+	if !ok {
+		log.Println("failed to assert type for post revision")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) MentionService() ps.MentionServiceClient {
+	client, ok := g.connections["mention_service"].(ps.MentionServiceClient)
+	if !ok {
+		log.Println("failed to assert type for mention")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) BookmarkService() ps.BookmarkServiceClient {
+	client, ok := g.connections["bookmark_service"].(ps.BookmarkServiceClient)
+	if !ok {
+		log.Println("failed to assert type for bookmark")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) BookmarkCollectionService() ps.BookmarkCollectionServiceClient {
+	client, ok := g.connections["bookmark_collection_service"].(ps.BookmarkCollectionServiceClient)
+	if !ok {
+		log.Println("failed to assert type for bookmark collection")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) ReportService() ps.ReportServiceClient {
+	client, ok := g.connections["report_service"].(ps.ReportServiceClient)
+	if !ok {
+		log.Println("failed to assert type for report")
+		return nil
+	}
+	return client
+}
+
+func (g *GrpcClient) ModerationService() ps.ModerationServiceClient {
+	client, ok := g.connections["moderation_service"].(ps.ModerationServiceClient)
+	if !ok {
+		log.Println("failed to assert type for moderation")
+		return nil
+	}
+	return client
+}
+
 func (g *GrpcClient) CloseConnections() {
 	for key, conn := range g.connections {
 		if c, ok := conn.(*grpc.ClientConn); ok {
@@ -0,0 +1,229 @@
+// Package imageproc prepares uploaded photos: it strips their metadata, measures them and makes
+// smaller and WebP versions of them.
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"sort"
+	"user_api_gateway/pkg/blurhash"
+
+	// decoders of the uploaded types
+	_ "image/gif"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxPixels is the size of the largest image that is decoded, larger ones are left as they are.
+	MaxPixels = 50_000_000
+	// jpegQuality is the quality of the JPEG variants and of the originals that are rotated.
+	jpegQuality = 85
+	// blurhashWidth is the width the image is shrunk to before its blurhash is computed.
+	blurhashWidth = 32
+)
+
+// ErrTooLarge is returned for an image of more than MaxPixels pixels.
+var ErrTooLarge = errors.New("the image is too large to process")
+
+// Variant is a resized or re-encoded version of an image.
+type Variant struct {
+	Data     []byte
+	MimeType string
+	// Ext is the extension of the format, e.g. .webp
+	Ext    string
+	Width  int
+	Height int
+}
+
+// Result is what Process makes of an image.
+type Result struct {
+	// Original is the image without its metadata, in its own format
+	Original []byte
+	Width    int
+	Height   int
+	Blurhash string
+	Variants []Variant
+}
+
+// Process strips the metadata of an image of mimeType and makes a variant for each of widths
+// narrower than the image, in JPEG for opaque images and PNG for the others, with a WebP
+// version of each. A small image gets one WebP version of its own size. The EXIF orientation
+// is applied to the pixels, so the image shows the right way up without it.
+func Process(data []byte, mimeType string, widths []int) (*Result, error) {
+	stripped, orientation, err := StripMetadata(data, mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(stripped))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		return nil, err
+	}
+
+	if orientation > 1 {
+		img = orient(img, orientation)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		stripped = buf.Bytes()
+	}
+
+	bounds := img.Bounds()
+	res := &Result{
+		Original: stripped,
+		Width:    bounds.Dx(),
+		Height:   bounds.Dy(),
+	}
+
+	res.Blurhash, err = blurhash.Encode(resize(img, min(blurhashWidth, res.Width), draw.ApproxBiLinear), 4, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	opaque := isOpaque(img)
+	for _, width := range variantWidths(widths, res.Width) {
+		small := resize(img, width, draw.CatmullRom)
+
+		variant, err := encode(small, opaque)
+		if err != nil {
+			return nil, err
+		}
+		res.Variants = append(res.Variants, variant)
+
+		webp, err := encodeWebP(small)
+		if err != nil {
+			return nil, err
+		}
+		res.Variants = append(res.Variants, webp)
+	}
+
+	if len(res.Variants) == 0 {
+		webp, err := encodeWebP(img)
+		if err != nil {
+			return nil, err
+		}
+		res.Variants = append(res.Variants, webp)
+	}
+
+	return res, nil
+}
+
+// variantWidths returns the widths below the width of the image, from small to large without duplicates.
+func variantWidths(widths []int, imageWidth int) []int {
+	var resp []int
+	seen := map[int]bool{}
+	for _, w := range widths {
+		if w > 0 && w < imageWidth && !seen[w] {
+			seen[w] = true
+			resp = append(resp, w)
+		}
+	}
+	sort.Ints(resp)
+	return resp
+}
+
+// resize scales img to width, keeping its aspect ratio.
+func resize(img image.Image, width int, scaler draw.Scaler) image.Image {
+	bounds := img.Bounds()
+	height := max(1, int(float64(bounds.Dy())*float64(width)/float64(bounds.Dx())+0.5))
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	scaler.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+func encode(img image.Image, opaque bool) (Variant, error) {
+	var (
+		buf     bytes.Buffer
+		variant = Variant{Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+		err     error
+	)
+
+	if opaque {
+		variant.MimeType, variant.Ext = "image/jpeg", ".jpg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		variant.MimeType, variant.Ext = "image/png", ".png"
+		err = png.Encode(&buf, img)
+	}
+	variant.Data = buf.Bytes()
+
+	return variant, err
+}
+
+// encodeWebP encodes img as a lossless WebP, no lossy encoder is available in pure Go.
+func encodeWebP(img image.Image) (Variant, error) {
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, img, nil); err != nil {
+		return Variant{}, err
+	}
+
+	return Variant{
+		Data:     buf.Bytes(),
+		MimeType: "image/webp",
+		Ext:      ".webp",
+		Width:    img.Bounds().Dx(),
+		Height:   img.Bounds().Dy(),
+	}, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// orient turns img by an EXIF orientation, 2 to 8.
+func orient(img image.Image, orientation int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	if orientation >= 5 {
+		dst = image.NewNRGBA(image.Rect(0, 0, h, w))
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			default:
+				dx, dy = x, y
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
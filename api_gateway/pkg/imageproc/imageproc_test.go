@@ -0,0 +1,280 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+func testImage(w, h int, alpha uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.NRGBA{uint8(x), uint8(y), 128, alpha})
+		}
+	}
+	return img
+}
+
+// exifSegment is an APP1 segment holding only an orientation tag.
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1}
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	seg := []byte{0xFF, 0xE1}
+	seg = binary.BigEndian.AppendUint16(seg, uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+func jpegWithMetadata(t *testing.T, w, h int, orientation uint16) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(w, h, 255), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	comment := append([]byte{0xFF, 0xFE, 0, 10}, "secret!!"...)
+
+	data := append([]byte{}, buf.Bytes()[:2]...)
+	data = append(data, exifSegment(orientation)...)
+	data = append(data, comment...)
+	return append(data, buf.Bytes()[2:]...)
+}
+
+func pngChunk(typ string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, typ...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func process(t *testing.T, data []byte, mimeType string, widths []int) *Result {
+	res, err := Process(data, mimeType, widths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestStripJPEG(t *testing.T) {
+	data := jpegWithMetadata(t, 8, 4, 1)
+
+	stripped, orientation, err := StripMetadata(data, "image/jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if orientation != 1 {
+		t.Errorf("orientation = %d, want 1", orientation)
+	}
+	if strings.Contains(string(stripped), "Exif") || strings.Contains(string(stripped), "secret!!") {
+		t.Error("the metadata was not stripped")
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 8, 4) {
+		t.Errorf("bounds = %v, want 8x4", img.Bounds())
+	}
+}
+
+func TestStripPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(8, 4, 255)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the text chunk goes right after IHDR, which is 8+25 bytes in
+	data := append([]byte{}, buf.Bytes()[:33]...)
+	data = append(data, pngChunk("tEXt", []byte("Author\x00secret!!"))...)
+	data = append(data, buf.Bytes()[33:]...)
+
+	stripped, _, err := StripMetadata(data, "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(stripped, buf.Bytes()) {
+		t.Error("the text chunk was not stripped")
+	}
+}
+
+func TestStripWebP(t *testing.T) {
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, testImage(8, 4, 255), nil); err != nil {
+		t.Fatal(err)
+	}
+	plain := buf.Bytes()
+
+	// wrap the plain VP8L bitstream in an extended container with an EXIF chunk
+	vp8x := []byte("VP8X")
+	vp8x = binary.LittleEndian.AppendUint32(vp8x, 10)
+	vp8x = append(vp8x, 0x08, 0, 0, 0, 7, 0, 0, 3, 0, 0)
+
+	exif := []byte("EXIF")
+	exif = binary.LittleEndian.AppendUint32(exif, 8)
+	exif = append(exif, "secret!!"...)
+
+	body := append([]byte("WEBP"), vp8x...)
+	body = append(body, plain[12:]...)
+	body = append(body, exif...)
+
+	data := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	data = append(data, body...)
+
+	stripped, _, err := StripMetadata(data, "image/webp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(stripped), "secret!!") {
+		t.Error("the EXIF chunk was not stripped")
+	}
+	if stripped[20]&0x08 != 0 {
+		t.Error("the EXIF flag of VP8X is still set")
+	}
+	if size := binary.LittleEndian.Uint32(stripped[4:]); size != uint32(len(stripped)-8) {
+		t.Errorf("RIFF size = %d, want %d", size, len(stripped)-8)
+	}
+
+	res := process(t, data, "image/webp", nil)
+	if res.Width != 8 || res.Height != 4 {
+		t.Errorf("size = %dx%d, want 8x4", res.Width, res.Height)
+	}
+}
+
+func TestStripMalformed(t *testing.T) {
+	_, _, err := StripMetadata([]byte("not an image"), "image/jpeg")
+	if !errors.Is(err, ErrMalformed) {
+		t.Errorf("StripMetadata() error = %v, want ErrMalformed", err)
+	}
+}
+
+func TestProcessVariants(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(200, 100, 255), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	res := process(t, buf.Bytes(), "image/jpeg", []int{100, 50, 100, 400})
+
+	if res.Width != 200 || res.Height != 100 {
+		t.Errorf("size = %dx%d, want 200x100", res.Width, res.Height)
+	}
+	if len(res.Blurhash) != 28 {
+		t.Errorf("blurhash %q has length %d, want 28", res.Blurhash, len(res.Blurhash))
+	}
+
+	var got []string
+	for _, v := range res.Variants {
+		got = append(got, v.MimeType)
+
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(v.Data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Width != v.Width || cfg.Height != v.Height {
+			t.Errorf("%s variant is %dx%d, reported %dx%d", v.MimeType, cfg.Width, cfg.Height, v.Width, v.Height)
+		}
+		if v.Height != v.Width/2 {
+			t.Errorf("%s variant of width %d has height %d", v.MimeType, v.Width, v.Height)
+		}
+	}
+
+	if want := []string{"image/jpeg", "image/webp", "image/jpeg", "image/webp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("variant types = %v, want %v", got, want)
+	}
+	if res.Variants[0].Width != 50 || res.Variants[2].Width != 100 {
+		t.Errorf("variant widths = %d, %d, want 50, 100", res.Variants[0].Width, res.Variants[2].Width)
+	}
+}
+
+func TestProcessTransparent(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(40, 20, 100)); err != nil {
+		t.Fatal(err)
+	}
+
+	res := process(t, buf.Bytes(), "image/png", []int{20})
+
+	if len(res.Variants) != 2 {
+		t.Fatalf("got %d variants, want 2", len(res.Variants))
+	}
+	if res.Variants[0].MimeType != "image/png" {
+		t.Errorf("variant type = %s, want image/png", res.Variants[0].MimeType)
+	}
+	if res.Variants[1].Ext != ".webp" {
+		t.Errorf("variant extension = %s, want .webp", res.Variants[1].Ext)
+	}
+}
+
+func TestProcessSmall(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(40, 20, 255)); err != nil {
+		t.Fatal(err)
+	}
+
+	res := process(t, buf.Bytes(), "image/png", []int{320})
+
+	if len(res.Variants) != 1 {
+		t.Fatalf("got %d variants, want 1", len(res.Variants))
+	}
+	if v := res.Variants[0]; v.MimeType != "image/webp" || v.Width != 40 {
+		t.Errorf("variant = %s of width %d, want image/webp of width 40", v.MimeType, v.Width)
+	}
+}
+
+func TestProcessOrientation(t *testing.T) {
+	res := process(t, jpegWithMetadata(t, 8, 4, 6), "image/jpeg", nil)
+
+	if res.Width != 4 || res.Height != 8 {
+		t.Errorf("size = %dx%d, want 4x8", res.Width, res.Height)
+	}
+
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(res.Original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 4 || cfg.Height != 8 {
+		t.Errorf("original is %dx%d, want 4x8", cfg.Width, cfg.Height)
+	}
+}
+
+func TestOrient(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	red := color.NRGBA{255, 0, 0, 255}
+	img.Set(0, 0, red)
+
+	// a quarter turn clockwise moves the top left pixel to the top right
+	rotated := orient(img, 6)
+	if rotated.Bounds() != image.Rect(0, 0, 1, 2) {
+		t.Errorf("rotated bounds = %v, want 1x2", rotated.Bounds())
+	}
+	if rotated.At(0, 0) != red {
+		t.Error("orientation 6 did not move the red pixel to the top right")
+	}
+
+	if orient(img, 8).At(0, 1) != red {
+		t.Error("orientation 8 did not move the red pixel to the bottom left")
+	}
+
+	if orient(img, 2).At(1, 0) != red {
+		t.Error("orientation 2 did not mirror the red pixel")
+	}
+}
@@ -0,0 +1,209 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrMalformed is returned for an image whose blocks can not be walked.
+var ErrMalformed = errors.New("malformed image")
+
+// StripMetadata removes the EXIF, XMP, IPTC and text blocks of a JPEG, PNG or WebP image without
+// decoding it, so the pixels are untouched. Other types are returned as they are. orientation is
+// the EXIF orientation of the image, 1 when it has none, the caller applies it to the pixels since
+// it is stripped with the rest.
+func StripMetadata(data []byte, mimeType string) ([]byte, int, error) {
+	switch mimeType {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		stripped, err := stripPNG(data)
+		return stripped, 1, err
+	case "image/webp":
+		stripped, err := stripWebP(data)
+		return stripped, 1, err
+	default:
+		return data, 1, nil
+	}
+}
+
+// stripJPEG keeps the JFIF, ICC profile and Adobe segments and drops the other application
+// segments and the comments. The scan data after the first SOS marker is copied as it is.
+func stripJPEG(data []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, 0, ErrMalformed
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	orientation := 1
+
+	for i := 2; ; {
+		if i+1 >= len(data) || data[i] != 0xFF {
+			return nil, 0, ErrMalformed
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			// fill byte
+			i++
+			continue
+		}
+
+		if marker == 0xDA {
+			return append(out, data[i:]...), orientation, nil
+		}
+		if marker == 0x01 || marker >= 0xD0 && marker <= 0xD7 {
+			out = append(out, data[i:i+2]...)
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, 0, ErrMalformed
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:i+4]))
+		if end > len(data) || end < i+4 {
+			return nil, 0, ErrMalformed
+		}
+
+		segment := data[i:end]
+		switch {
+		case marker == 0xE1:
+			if payload := segment[4:]; bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+				orientation = exifOrientation(payload[6:])
+			}
+		case marker == 0xE0, marker == 0xE2, marker == 0xEE:
+			out = append(out, segment...)
+		case marker >= 0xE3 && marker <= 0xEF, marker == 0xFE:
+		default:
+			out = append(out, segment...)
+		}
+		i = end
+	}
+}
+
+// exifOrientation reads the orientation tag of the first IFD of EXIF data, 1 when it is missing.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			v := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if v < 1 || v > 8 {
+				return 1
+			}
+			return v
+		}
+	}
+
+	return 1
+}
+
+// pngMetadata are the PNG chunks that carry metadata rather than pixels or color information.
+var pngMetadata = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(data, []byte(signature)) {
+		return nil, ErrMalformed
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, signature...)
+
+	for i := len(signature); i < len(data); {
+		if i+8 > len(data) {
+			return nil, ErrMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		kind := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, ErrMalformed
+		}
+
+		if !pngMetadata[kind] {
+			out = append(out, data[i:end]...)
+		}
+		if kind == "IEND" {
+			break
+		}
+		i = end
+	}
+
+	return out, nil
+}
+
+// stripWebP drops the EXIF and XMP chunks of a WebP container and clears their flags in the VP8X chunk.
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, ErrMalformed
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:12]...)
+
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, ErrMalformed
+		}
+		kind := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			// the padding of the last chunk is sometimes left out
+			if end == len(data)+1 {
+				end = len(data)
+			} else {
+				return nil, ErrMalformed
+			}
+		}
+
+		switch kind {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if len(chunk) > 8 {
+				// bit 3 is EXIF, bit 2 is XMP
+				chunk[8] &^= 0x08 | 0x04
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+
+	return out, nil
+}
@@ -0,0 +1,106 @@
+// Package mediatype tells the type of an uploaded file from its content.
+package mediatype
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// The kinds of attachment a file can be.
+const (
+	Photo    = "photo"
+	Video    = "video"
+	Audio    = "audio"
+	Document = "document"
+)
+
+var (
+	// ErrUnsupported is returned for a file whose content is of a type that can not be uploaded.
+	ErrUnsupported = errors.New("unsupported file type")
+	// ErrMismatch is returned when the declared type or the extension of a file does not match its content.
+	ErrMismatch = errors.New("the file type does not match its content")
+)
+
+// Type is a MIME type that can be uploaded.
+type Type struct {
+	MimeType string
+	Kind     string
+	// Exts are the extensions of the type, the first one is used for stored files
+	Exts []string
+}
+
+// Ext is the extension files of the type are stored with.
+func (t Type) Ext() string {
+	return t.Exts[0]
+}
+
+// types are the MIME types that can be uploaded, as the detection names them.
+var types = map[string]Type{}
+
+func init() {
+	for _, t := range []Type{
+		{"image/jpeg", Photo, []string{".jpg", ".jpeg"}},
+		{"image/png", Photo, []string{".png"}},
+		{"image/vnd.mozilla.apng", Photo, []string{".png", ".apng"}},
+		{"image/gif", Photo, []string{".gif"}},
+		{"image/webp", Photo, []string{".webp"}},
+
+		{"video/mp4", Video, []string{".mp4", ".m4v"}},
+		{"video/webm", Video, []string{".webm"}},
+		{"video/quicktime", Video, []string{".mov"}},
+
+		{"audio/mpeg", Audio, []string{".mp3"}},
+		{"audio/ogg", Audio, []string{".ogg", ".oga", ".opus"}},
+		{"audio/wav", Audio, []string{".wav"}},
+		{"audio/flac", Audio, []string{".flac"}},
+		{"audio/aac", Audio, []string{".aac"}},
+		{"audio/x-m4a", Audio, []string{".m4a"}},
+		{"audio/mp4", Audio, []string{".m4a", ".mp4"}},
+
+		{"application/pdf", Document, []string{".pdf"}},
+		{"text/plain", Document, []string{".txt"}},
+		{"text/csv", Document, []string{".csv"}},
+		{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", Document, []string{".docx"}},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Document, []string{".xlsx"}},
+		{"application/vnd.openxmlformats-officedocument.presentationml.presentation", Document, []string{".pptx"}},
+		{"application/vnd.oasis.opendocument.text", Document, []string{".odt"}},
+	} {
+		types[t.MimeType] = t
+	}
+}
+
+// Detect reads the start of r and returns the type of its content. declared is the Content-Type
+// the client sent and name the file name, both are optional but when they are set they have to
+// agree with the content. application/octet-stream is taken as no declared type.
+func Detect(r io.Reader, declared, name string) (Type, error) {
+	detected, err := mimetype.DetectReader(r)
+	if err != nil {
+		return Type{}, err
+	}
+
+	mimeType, _, _ := strings.Cut(detected.String(), ";")
+	t, ok := types[mimeType]
+	if !ok {
+		return Type{}, fmt.Errorf("%w %s", ErrUnsupported, mimeType)
+	}
+
+	if declared != "" {
+		base, _, err := mime.ParseMediaType(declared)
+		if err != nil || base != "application/octet-stream" && !detected.Is(base) {
+			return Type{}, fmt.Errorf("%w: it is %s, not %s", ErrMismatch, mimeType, declared)
+		}
+	}
+
+	if ext := strings.ToLower(path.Ext(name)); ext != "" && !slices.Contains(t.Exts, ext) {
+		return Type{}, fmt.Errorf("%w: it is %s, not %s", ErrMismatch, mimeType, ext)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,80 @@
+package mediatype
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func pngFile(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		declared string
+		filename string
+		mimeType string
+		kind     string
+		ext      string
+	}{
+		{"png", pngFile(t), "image/png", "a.PNG", "image/png", Photo, ".png"},
+		{"no declared type", pngFile(t), "", "", "image/png", Photo, ".png"},
+		{"octet-stream", pngFile(t), "application/octet-stream", "a.png", "image/png", Photo, ".png"},
+		{"pdf", []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n"), "application/pdf", "report.pdf", "application/pdf", Document, ".pdf"},
+		{"text with charset", []byte("hello there\n"), "text/plain; charset=utf-8", "notes.txt", "text/plain", Document, ".txt"},
+		{"mp3 alias", append([]byte("ID3\x03\x00\x00\x00\x00\x00\x00"), make([]byte, 64)...), "audio/mp3", "song.mp3", "audio/mpeg", Audio, ".mp3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(bytes.NewReader(tt.content), tt.declared, tt.filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.MimeType != tt.mimeType || got.Kind != tt.kind || got.Ext() != tt.ext {
+				t.Errorf("Detect() = %s %s %s, want %s %s %s", got.MimeType, got.Kind, got.Ext(), tt.mimeType, tt.kind, tt.ext)
+			}
+		})
+	}
+}
+
+func TestDetectMismatch(t *testing.T) {
+	tests := []struct {
+		name, declared, filename string
+	}{
+		{"declared type", "image/jpeg", "a.png"},
+		{"extension", "image/png", "a.jpg"},
+		{"invalid declared type", "not a type", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Detect(bytes.NewReader(pngFile(t)), tt.declared, tt.filename); !errors.Is(err, ErrMismatch) {
+				t.Errorf("Detect() error = %v, want ErrMismatch", err)
+			}
+		})
+	}
+}
+
+func TestDetectUnsupported(t *testing.T) {
+	_, err := Detect(strings.NewReader("<html><body>hi</body></html>"), "", "page.html")
+	if !errors.Is(err, ErrUnsupported) || !strings.Contains(err.Error(), "text/html") {
+		t.Errorf("Detect() error = %v, want ErrUnsupported for text/html", err)
+	}
+
+	// a program renamed to look like a photo
+	_, err = Detect(bytes.NewReader([]byte{0x7f, 'E', 'L', 'F', 2, 1, 1, 0}), "image/png", "a.png")
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Detect() error = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,75 @@
+// Package signedurl signs the URLs of private files, a signed URL names the viewer it was made for
+// and stops working when it expires. The server checks that the request is made by that viewer.
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalid is returned for a URL whose signature is missing or does not match.
+	ErrInvalid = errors.New("invalid signature")
+	// ErrExpired is returned for a URL used after it expired.
+	ErrExpired = errors.New("the url expired")
+)
+
+// Signer signs and verifies URLs with a secret key.
+type Signer struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// New returns a signer whose URLs are valid for ttl.
+func New(secret string, ttl time.Duration) *Signer {
+	return &Signer{key: []byte(secret), ttl: ttl, now: time.Now}
+}
+
+// Sign returns path with the expiry, the viewer and the signature in the query.
+// viewer is the id of the user the URL is made for, empty for anonymous viewers.
+func (s *Signer) Sign(path, viewer string) string {
+	expires := strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	if viewer != "" {
+		query.Set("viewer", viewer)
+	}
+	query.Set("signature", s.signature(path, expires, viewer))
+
+	return (&url.URL{Path: path, RawQuery: query.Encode()}).String()
+}
+
+// Verify checks the query of a request for path and returns the viewer the URL was made for
+// and when it expires.
+func (s *Signer) Verify(path string, query url.Values) (string, time.Time, error) {
+	expires, viewer, signature := query.Get("expires"), query.Get("viewer"), query.Get("signature")
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(path, expires, viewer))) {
+		return "", time.Time{}, ErrInvalid
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", time.Time{}, ErrInvalid
+	}
+
+	expiresAt := time.Unix(unix, 0)
+	if !s.now().Before(expiresAt) {
+		return "", time.Time{}, ErrExpired
+	}
+
+	return viewer, expiresAt, nil
+}
+
+func (s *Signer) signature(path, expires, viewer string) string {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(path + "\n" + expires + "\n" + viewer))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,69 @@
+package signedurl
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	s := New("secret", time.Hour)
+	s.now = func() time.Time { return now }
+
+	signed, err := url.Parse(s.Sign("/media/attachments/u1/a.jpg", "viewer-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viewer, expires, err := s.Verify(signed.Path, signed.Query())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viewer != "viewer-1" || !expires.Equal(now.Add(time.Hour)) {
+		t.Fatalf("got %q, %v", viewer, expires)
+	}
+
+	tampered := []struct {
+		name  string
+		path  string
+		query func(url.Values)
+	}{
+		{"other path", "/media/attachments/u1/b.jpg", func(url.Values) {}},
+		{"other viewer", signed.Path, func(q url.Values) { q.Set("viewer", "viewer-2") }},
+		{"no viewer", signed.Path, func(q url.Values) { q.Del("viewer") }},
+		{"later expiry", signed.Path, func(q url.Values) { q.Set("expires", "99999999999") }},
+		{"no signature", signed.Path, func(q url.Values) { q.Del("signature") }},
+	}
+	for _, tt := range tampered {
+		q := signed.Query()
+		tt.query(q)
+		if _, _, err := s.Verify(tt.path, q); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: got %v", tt.name, err)
+		}
+	}
+
+	if _, _, err := New("other", time.Hour).Verify(signed.Path, signed.Query()); !errors.Is(err, ErrInvalid) {
+		t.Errorf("other key: got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, _, err := s.Verify(signed.Path, signed.Query()); !errors.Is(err, ErrExpired) {
+		t.Errorf("expired: got %v", err)
+	}
+}
+
+func TestAnonymous(t *testing.T) {
+	s := New("secret", time.Minute)
+
+	signed, _ := url.Parse(s.Sign("/media/a.jpg", ""))
+	if signed.Query().Has("viewer") {
+		t.Fatalf("anonymous url names a viewer: %s", signed)
+	}
+
+	viewer, _, err := s.Verify(signed.Path, signed.Query())
+	if err != nil || viewer != "" {
+		t.Fatalf("got %q, %v", viewer, err)
+	}
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"time"
+	ps "user_api_gateway/genproto/post_service"
+	"user_api_gateway/pkg/blob"
+	"user_api_gateway/pkg/grpc_client"
+	"user_api_gateway/pkg/logger"
+)
+
+const (
+	blobCleanupInterval = time.Minute
+	// blobCleanupBatch is the number of released blobs listed per call.
+	blobCleanupBatch = 100
+)
+
+// Blobs deletes the files the post service released, the media left without uploads or attachments
+// and the variants of deleted attachments. Deleting a blob twice does no harm, so every replica can
+// run it.
+type Blobs struct {
+	log        logger.Logger
+	grpcClient *grpc_client.GrpcClient
+	blobs      blob.Store
+}
+
+func NewBlobs(log logger.Logger, grpcClient *grpc_client.GrpcClient, blobs blob.Store) *Blobs {
+	return &Blobs{
+		log:        log,
+		grpcClient: grpcClient,
+		blobs:      blobs,
+	}
+}
+
+// Run deletes the released blobs every interval until ctx is done.
+func (b *Blobs) Run(ctx context.Context) {
+	ticker := time.NewTicker(blobCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		b.deleteReleased(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Blobs) deleteReleased(ctx context.Context) {
+	for {
+		released, err := b.grpcClient.MediaService().ListReleased(ctx, &ps.ListReleasedRequest{Limit: blobCleanupBatch})
+		if err != nil {
+			b.log.Error("failed to list released blobs", logger.Error(err))
+			return
+		}
+
+		var deleted []string
+		for _, key := range released.Keys {
+			if err := b.blobs.Delete(ctx, key); err != nil {
+				b.log.Error("failed to delete released blob", logger.String("key", key), logger.Error(err))
+				continue
+			}
+			deleted = append(deleted, key)
+		}
+
+		if len(deleted) > 0 {
+			_, err = b.grpcClient.MediaService().ForgetReleased(ctx, &ps.ReleasedBlobList{Keys: deleted})
+			if err != nil {
+				b.log.Error("failed to forget released blobs", logger.Error(err))
+				return
+			}
+		}
+
+		// the blobs that failed are tried again on the next run
+		if len(released.Keys) < blobCleanupBatch || len(deleted) < len(released.Keys) {
+			return
+		}
+	}
+}
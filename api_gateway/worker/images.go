@@ -0,0 +1,178 @@
+// Package worker runs the background jobs of the gateway.
+package worker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"user_api_gateway/config"
+	ps "user_api_gateway/genproto/post_service"
+	"user_api_gateway/pkg/blob"
+	"user_api_gateway/pkg/grpc_client"
+	"user_api_gateway/pkg/imageproc"
+	"user_api_gateway/pkg/logger"
+)
+
+const (
+	defaultImageProcessInterval = 10 * time.Second
+	// imageProcessBatch is the number of photos claimed per call.
+	imageProcessBatch = 10
+	// imageProcessLease is how long a replica has to process a photo before another one takes it.
+	imageProcessLease = 10 * time.Minute
+)
+
+// defaultImageVariantWidths are the widths of the variants when IMAGE_VARIANT_WIDTHS is not set.
+var defaultImageVariantWidths = []int{320, 640, 1280}
+
+// strippedExts are the extensions of the copies without metadata, by the type of the photo.
+var strippedExts = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// Images processes the uploaded photos: it stores a copy of each without its metadata and its resized
+// and WebP variants under variants/<media key>/. The original stays as it was uploaded, since its key
+// is the SHA-256 of its content. It runs in the gateway since the gateway holds the blob store, the
+// post service keeps track of which photos are processed and leases them to one replica at a time.
+type Images struct {
+	cfg        config.Config
+	log        logger.Logger
+	grpcClient *grpc_client.GrpcClient
+	blobs      blob.Store
+}
+
+func NewImages(cfg config.Config, log logger.Logger, grpcClient *grpc_client.GrpcClient, blobs blob.Store) *Images {
+	return &Images{
+		cfg:        cfg,
+		log:        log,
+		grpcClient: grpcClient,
+		blobs:      blobs,
+	}
+}
+
+// Run processes the new photos every interval until ctx is done.
+func (i *Images) Run(ctx context.Context) {
+	interval := i.cfg.ImageProcessInterval
+	if interval <= 0 {
+		interval = defaultImageProcessInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		i.processDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (i *Images) processDue(ctx context.Context) {
+	for {
+		jobs, err := i.grpcClient.MediaService().ClaimUnprocessed(ctx, &ps.ClaimUnprocessedRequest{
+			Limit:        imageProcessBatch,
+			LeaseSeconds: int64(imageProcessLease.Seconds()),
+		})
+		if err != nil {
+			i.log.Error("failed to claim unprocessed photos", logger.Error(err))
+			return
+		}
+
+		for _, job := range jobs.Items {
+			i.process(ctx, job)
+		}
+
+		if len(jobs.Items) < imageProcessBatch {
+			return
+		}
+	}
+}
+
+// process handles one photo. A photo that cannot be read as an image is marked failed, other
+// errors leave it to be claimed again once its lease runs out.
+func (i *Images) process(ctx context.Context, job *ps.MediaJob) {
+	data, err := i.read(ctx, job.Key)
+	if errors.Is(err, blob.ErrNotFound) {
+		i.fail(ctx, job, err)
+		return
+	}
+	if err != nil {
+		i.log.Error("failed to read photo", logger.String("key", job.Key), logger.Error(err))
+		return
+	}
+
+	widths := i.cfg.ImageVariantWidths
+	if len(widths) == 0 {
+		widths = defaultImageVariantWidths
+	}
+
+	res, err := imageproc.Process(data, job.MimeType, widths)
+	if err != nil {
+		i.fail(ctx, job, err)
+		return
+	}
+
+	// the copy without metadata is what the attachments link to
+	processed := &ps.ProcessedMedia{
+		Key:         job.Key,
+		StrippedKey: fmt.Sprintf("variants/%s/original%s", job.Key, strippedExts[job.MimeType]),
+		Width:       int32(res.Width),
+		Height:      int32(res.Height),
+		Blurhash:    res.Blurhash,
+	}
+
+	if err := i.blobs.Put(ctx, processed.StrippedKey, bytes.NewReader(res.Original), int64(len(res.Original)), job.MimeType); err != nil {
+		i.log.Error("failed to store stripped photo", logger.String("key", job.Key), logger.Error(err))
+		return
+	}
+
+	for _, v := range res.Variants {
+		key := fmt.Sprintf("variants/%s/%d%s", job.Key, v.Width, v.Ext)
+		if err := i.blobs.Put(ctx, key, bytes.NewReader(v.Data), int64(len(v.Data)), v.MimeType); err != nil {
+			i.log.Error("failed to store photo variant", logger.String("key", job.Key), logger.Error(err))
+			return
+		}
+
+		processed.Variants = append(processed.Variants, &ps.AttachmentVariant{
+			ObjectKey: key,
+			MimeType:  v.MimeType,
+			Width:     int32(v.Width),
+			Height:    int32(v.Height),
+			Size:      int64(len(v.Data)),
+		})
+	}
+
+	if _, err := i.grpcClient.MediaService().SaveProcessed(ctx, processed); err != nil {
+		i.log.Error("failed to save processed photo", logger.String("key", job.Key), logger.Error(err))
+	}
+}
+
+func (i *Images) read(ctx context.Context, key string) ([]byte, error) {
+	r, _, err := i.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+func (i *Images) fail(ctx context.Context, job *ps.MediaJob, reason error) {
+	i.log.Error("failed to process photo", logger.String("key", job.Key), logger.Error(reason))
+
+	_, err := i.grpcClient.MediaService().FailProcessing(ctx, &ps.FailProcessingRequest{
+		Key:    job.Key,
+		Reason: reason.Error(),
+	})
+	if err != nil {
+		i.log.Error("failed to mark photo processing failed", logger.String("key", job.Key), logger.Error(err))
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"post_service/config"
 	"post_service/grpc"
 	"post_service/grpc/client"
+	"post_service/grpc/service"
 	"post_service/storage/postgres"
+	"post_service/storage/redis"
+	"post_service/worker"
 
 	"github.com/saidamir98/udevs_pkg/logger"
 )
@@ -34,12 +37,22 @@ func main() {
 	}
 	defer pgStore.CloseDB()
 
+	cache, err := redis.NewRedis(context.Background(), cfg)
+	if err != nil {
+		log.Panic("redis.NewRedis", logger.Error(err))
+	}
+	defer cache.Close()
+
 	svcs, err := client.NewGrpcClients(cfg)
 	if err != nil {
 		log.Panic("client.NewGrpcClients", logger.Error(err))
 	}
 
-	grpcServer := grpc.SetUpServer(cfg, log, pgStore, svcs)
+	go worker.NewTrending(cfg, log, pgStore, cache).Run(context.Background())
+	go worker.NewViews(cfg, log, pgStore, cache).Run(context.Background())
+	go worker.NewScheduler(cfg, log, pgStore, service.NewPostService(cfg, log, pgStore, cache, svcs)).Run(context.Background())
+
+	grpcServer := grpc.SetUpServer(cfg, log, pgStore, cache, svcs)
 
 	lis, err := net.Listen("tcp", cfg.PostServicePort)
 	if err != nil {
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -50,6 +51,13 @@ type Config struct {
 	RedisPassword string
 
 	PostgresMaxConnections int32
+
+	// TrendingRefreshInterval is how often the trending tags are recomputed, e.g. 5m.
+	TrendingRefreshInterval time.Duration
+	// PublishInterval is how often the scheduler looks for scheduled posts that are due, e.g. 30s.
+	PublishInterval time.Duration
+	// ViewFlushInterval is how often the view counts are moved from Redis to postgres, e.g. 1m.
+	ViewFlushInterval time.Duration
 }
 
 // Load reads environment variables and returns a Config instance
@@ -90,5 +98,9 @@ func Load() Config {
 
 		LogLevel: cast.ToString(os.Getenv("LOG_LEVEL")),
 		HTTPPort: cast.ToString(os.Getenv("HTTP_PORT")),
+
+		TrendingRefreshInterval: cast.ToDuration(os.Getenv("TRENDING_REFRESH_INTERVAL")),
+		PublishInterval:         cast.ToDuration(os.Getenv("PUBLISH_INTERVAL")),
+		ViewFlushInterval:       cast.ToDuration(os.Getenv("VIEW_FLUSH_INTERVAL")),
 	}
 }
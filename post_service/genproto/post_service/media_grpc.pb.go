@@ -0,0 +1,412 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: media.proto
+
+package post_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MediaService_Create_FullMethodName           = "/post_service.MediaService/Create"
+	MediaService_GetSingle_FullMethodName        = "/post_service.MediaService/GetSingle"
+	MediaService_Delete_FullMethodName           = "/post_service.MediaService/Delete"
+	MediaService_ListReleased_FullMethodName     = "/post_service.MediaService/ListReleased"
+	MediaService_ForgetReleased_FullMethodName   = "/post_service.MediaService/ForgetReleased"
+	MediaService_ClaimUnprocessed_FullMethodName = "/post_service.MediaService/ClaimUnprocessed"
+	MediaService_SaveProcessed_FullMethodName    = "/post_service.MediaService/SaveProcessed"
+	MediaService_FailProcessing_FullMethodName   = "/post_service.MediaService/FailProcessing"
+)
+
+// MediaServiceClient is the client API for MediaService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MediaService keeps the files uploaded through the gateway, attachments refer to them by key.
+// Files are stored by their content, identical uploads share one file.
+type MediaServiceClient interface {
+	// Create registers an upload of owner_id, a file already registered under key gains a reference
+	Create(ctx context.Context, in *Media, opts ...grpc.CallOption) (*Media, error)
+	GetSingle(ctx context.Context, in *MediaSingleRequest, opts ...grpc.CallOption) (*Media, error)
+	// Delete removes the upload of owner_id, the file can be removed from the blob store once
+	// the returned ref_count is 0
+	Delete(ctx context.Context, in *MediaDeleteRequest, opts ...grpc.CallOption) (*Media, error)
+	// ListReleased returns the keys of the blobs no media or attachment refers to anymore, the
+	// gateway deletes them from the blob store and forgets them with ForgetReleased
+	ListReleased(ctx context.Context, in *ListReleasedRequest, opts ...grpc.CallOption) (*ReleasedBlobList, error)
+	ForgetReleased(ctx context.Context, in *ReleasedBlobList, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ClaimUnprocessed leases uploaded photos that are not processed yet to the image worker of the
+	// gateway, a photo is processed once however many attachments use it. A photo is claimed again
+	// when it is not processed within the lease, e.g. because the gateway stopped
+	ClaimUnprocessed(ctx context.Context, in *ClaimUnprocessedRequest, opts ...grpc.CallOption) (*MediaJobList, error)
+	// SaveProcessed stores the stripped copy, dimensions and variants of a photo and marks it processed
+	SaveProcessed(ctx context.Context, in *ProcessedMedia, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// FailProcessing marks a photo that cannot be processed, so it is not tried again
+	FailProcessing(ctx context.Context, in *FailProcessingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type mediaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMediaServiceClient(cc grpc.ClientConnInterface) MediaServiceClient {
+	return &mediaServiceClient{cc}
+}
+
+func (c *mediaServiceClient) Create(ctx context.Context, in *Media, opts ...grpc.CallOption) (*Media, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Media)
+	err := c.cc.Invoke(ctx, MediaService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) GetSingle(ctx context.Context, in *MediaSingleRequest, opts ...grpc.CallOption) (*Media, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Media)
+	err := c.cc.Invoke(ctx, MediaService_GetSingle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) Delete(ctx context.Context, in *MediaDeleteRequest, opts ...grpc.CallOption) (*Media, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Media)
+	err := c.cc.Invoke(ctx, MediaService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ListReleased(ctx context.Context, in *ListReleasedRequest, opts ...grpc.CallOption) (*ReleasedBlobList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleasedBlobList)
+	err := c.cc.Invoke(ctx, MediaService_ListReleased_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ForgetReleased(ctx context.Context, in *ReleasedBlobList, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_ForgetReleased_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ClaimUnprocessed(ctx context.Context, in *ClaimUnprocessedRequest, opts ...grpc.CallOption) (*MediaJobList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MediaJobList)
+	err := c.cc.Invoke(ctx, MediaService_ClaimUnprocessed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) SaveProcessed(ctx context.Context, in *ProcessedMedia, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_SaveProcessed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) FailProcessing(ctx context.Context, in *FailProcessingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, MediaService_FailProcessing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MediaServiceServer is the server API for MediaService service.
+// All implementations should embed UnimplementedMediaServiceServer
+// for forward compatibility.
+//
+// MediaService keeps the files uploaded through the gateway, attachments refer to them by key.
+// Files are stored by their content, identical uploads share one file.
+type MediaServiceServer interface {
+	// Create registers an upload of owner_id, a file already registered under key gains a reference
+	Create(context.Context, *Media) (*Media, error)
+	GetSingle(context.Context, *MediaSingleRequest) (*Media, error)
+	// Delete removes the upload of owner_id, the file can be removed from the blob store once
+	// the returned ref_count is 0
+	Delete(context.Context, *MediaDeleteRequest) (*Media, error)
+	// ListReleased returns the keys of the blobs no media or attachment refers to anymore, the
+	// gateway deletes them from the blob store and forgets them with ForgetReleased
+	ListReleased(context.Context, *ListReleasedRequest) (*ReleasedBlobList, error)
+	ForgetReleased(context.Context, *ReleasedBlobList) (*emptypb.Empty, error)
+	// ClaimUnprocessed leases uploaded photos that are not processed yet to the image worker of the
+	// gateway, a photo is processed once however many attachments use it. A photo is claimed again
+	// when it is not processed within the lease, e.g. because the gateway stopped
+	ClaimUnprocessed(context.Context, *ClaimUnprocessedRequest) (*MediaJobList, error)
+	// SaveProcessed stores the stripped copy, dimensions and variants of a photo and marks it processed
+	SaveProcessed(context.Context, *ProcessedMedia) (*emptypb.Empty, error)
+	// FailProcessing marks a photo that cannot be processed, so it is not tried again
+	FailProcessing(context.Context, *FailProcessingRequest) (*emptypb.Empty, error)
+}
+
+// UnimplementedMediaServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMediaServiceServer struct{}
+
+func (UnimplementedMediaServiceServer) Create(context.Context, *Media) (*Media, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedMediaServiceServer) GetSingle(context.Context, *MediaSingleRequest) (*Media, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSingle not implemented")
+}
+func (UnimplementedMediaServiceServer) Delete(context.Context, *MediaDeleteRequest) (*Media, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedMediaServiceServer) ListReleased(context.Context, *ListReleasedRequest) (*ReleasedBlobList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReleased not implemented")
+}
+func (UnimplementedMediaServiceServer) ForgetReleased(context.Context, *ReleasedBlobList) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForgetReleased not implemented")
+}
+func (UnimplementedMediaServiceServer) ClaimUnprocessed(context.Context, *ClaimUnprocessedRequest) (*MediaJobList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimUnprocessed not implemented")
+}
+func (UnimplementedMediaServiceServer) SaveProcessed(context.Context, *ProcessedMedia) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveProcessed not implemented")
+}
+func (UnimplementedMediaServiceServer) FailProcessing(context.Context, *FailProcessingRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailProcessing not implemented")
+}
+func (UnimplementedMediaServiceServer) testEmbeddedByValue() {}
+
+// UnsafeMediaServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MediaServiceServer will
+// result in compilation errors.
+type UnsafeMediaServiceServer interface {
+	mustEmbedUnimplementedMediaServiceServer()
+}
+
+func RegisterMediaServiceServer(s grpc.ServiceRegistrar, srv MediaServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMediaServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MediaService_ServiceDesc, srv)
+}
+
+func _MediaService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Media)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).Create(ctx, req.(*Media))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_GetSingle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MediaSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetSingle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetSingle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetSingle(ctx, req.(*MediaSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MediaDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).Delete(ctx, req.(*MediaDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ListReleased_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReleasedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListReleased(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListReleased_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListReleased(ctx, req.(*ListReleasedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ForgetReleased_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleasedBlobList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ForgetReleased(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ForgetReleased_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ForgetReleased(ctx, req.(*ReleasedBlobList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ClaimUnprocessed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimUnprocessedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ClaimUnprocessed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ClaimUnprocessed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ClaimUnprocessed(ctx, req.(*ClaimUnprocessedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_SaveProcessed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessedMedia)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).SaveProcessed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_SaveProcessed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).SaveProcessed(ctx, req.(*ProcessedMedia))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_FailProcessing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailProcessingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).FailProcessing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_FailProcessing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).FailProcessing(ctx, req.(*FailProcessingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MediaService_ServiceDesc is the grpc.ServiceDesc for MediaService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MediaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.MediaService",
+	HandlerType: (*MediaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _MediaService_Create_Handler,
+		},
+		{
+			MethodName: "GetSingle",
+			Handler:    _MediaService_GetSingle_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _MediaService_Delete_Handler,
+		},
+		{
+			MethodName: "ListReleased",
+			Handler:    _MediaService_ListReleased_Handler,
+		},
+		{
+			MethodName: "ForgetReleased",
+			Handler:    _MediaService_ForgetReleased_Handler,
+		},
+		{
+			MethodName: "ClaimUnprocessed",
+			Handler:    _MediaService_ClaimUnprocessed_Handler,
+		},
+		{
+			MethodName: "SaveProcessed",
+			Handler:    _MediaService_SaveProcessed_Handler,
+		},
+		{
+			MethodName: "FailProcessing",
+			Handler:    _MediaService_FailProcessing_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "media.proto",
+}
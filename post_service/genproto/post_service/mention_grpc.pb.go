@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: mention.proto
+
+package post_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MentionService_GetPosts_FullMethodName = "/post_service.MentionService/GetPosts"
+)
+
+// MentionServiceClient is the client API for MentionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MentionServiceClient interface {
+	// GetPosts returns the published posts that mention a user, newest first
+	GetPosts(ctx context.Context, in *GetMentionPostsRequest, opts ...grpc.CallOption) (*PostList, error)
+}
+
+type mentionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMentionServiceClient(cc grpc.ClientConnInterface) MentionServiceClient {
+	return &mentionServiceClient{cc}
+}
+
+func (c *mentionServiceClient) GetPosts(ctx context.Context, in *GetMentionPostsRequest, opts ...grpc.CallOption) (*PostList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostList)
+	err := c.cc.Invoke(ctx, MentionService_GetPosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MentionServiceServer is the server API for MentionService service.
+// All implementations should embed UnimplementedMentionServiceServer
+// for forward compatibility.
+type MentionServiceServer interface {
+	// GetPosts returns the published posts that mention a user, newest first
+	GetPosts(context.Context, *GetMentionPostsRequest) (*PostList, error)
+}
+
+// UnimplementedMentionServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMentionServiceServer struct{}
+
+func (UnimplementedMentionServiceServer) GetPosts(context.Context, *GetMentionPostsRequest) (*PostList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPosts not implemented")
+}
+func (UnimplementedMentionServiceServer) testEmbeddedByValue() {}
+
+// UnsafeMentionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MentionServiceServer will
+// result in compilation errors.
+type UnsafeMentionServiceServer interface {
+	mustEmbedUnimplementedMentionServiceServer()
+}
+
+func RegisterMentionServiceServer(s grpc.ServiceRegistrar, srv MentionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMentionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MentionService_ServiceDesc, srv)
+}
+
+func _MentionService_GetPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMentionPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MentionServiceServer).GetPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MentionService_GetPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MentionServiceServer).GetPosts(ctx, req.(*GetMentionPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MentionService_ServiceDesc is the grpc.ServiceDesc for MentionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MentionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.MentionService",
+	HandlerType: (*MentionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPosts",
+			Handler:    _MentionService_GetPosts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mention.proto",
+}
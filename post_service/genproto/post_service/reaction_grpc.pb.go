@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: reaction.proto
+
+package post_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReactionService_Upsert_FullMethodName  = "/post_service.ReactionService/Upsert"
+	ReactionService_Delete_FullMethodName  = "/post_service.ReactionService/Delete"
+	ReactionService_GetList_FullMethodName = "/post_service.ReactionService/GetList"
+)
+
+// ReactionServiceClient is the client API for ReactionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReactionService stores one reaction per user per post or comment.
+type ReactionServiceClient interface {
+	// Upsert sets the reaction of the user, repeating the call changes nothing.
+	Upsert(ctx context.Context, in *Reaction, opts ...grpc.CallOption) (*ReactionSummary, error)
+	Delete(ctx context.Context, in *ReactionSingleRequest, opts ...grpc.CallOption) (*ReactionSummary, error)
+	// GetList returns who reacted to a post or comment, newest first.
+	GetList(ctx context.Context, in *GetListReactionRequest, opts ...grpc.CallOption) (*ReactionList, error)
+}
+
+type reactionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReactionServiceClient(cc grpc.ClientConnInterface) ReactionServiceClient {
+	return &reactionServiceClient{cc}
+}
+
+func (c *reactionServiceClient) Upsert(ctx context.Context, in *Reaction, opts ...grpc.CallOption) (*ReactionSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactionSummary)
+	err := c.cc.Invoke(ctx, ReactionService_Upsert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionServiceClient) Delete(ctx context.Context, in *ReactionSingleRequest, opts ...grpc.CallOption) (*ReactionSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactionSummary)
+	err := c.cc.Invoke(ctx, ReactionService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionServiceClient) GetList(ctx context.Context, in *GetListReactionRequest, opts ...grpc.CallOption) (*ReactionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactionList)
+	err := c.cc.Invoke(ctx, ReactionService_GetList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReactionServiceServer is the server API for ReactionService service.
+// All implementations should embed UnimplementedReactionServiceServer
+// for forward compatibility.
+//
+// ReactionService stores one reaction per user per post or comment.
+type ReactionServiceServer interface {
+	// Upsert sets the reaction of the user, repeating the call changes nothing.
+	Upsert(context.Context, *Reaction) (*ReactionSummary, error)
+	Delete(context.Context, *ReactionSingleRequest) (*ReactionSummary, error)
+	// GetList returns who reacted to a post or comment, newest first.
+	GetList(context.Context, *GetListReactionRequest) (*ReactionList, error)
+}
+
+// UnimplementedReactionServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReactionServiceServer struct{}
+
+func (UnimplementedReactionServiceServer) Upsert(context.Context, *Reaction) (*ReactionSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Upsert not implemented")
+}
+func (UnimplementedReactionServiceServer) Delete(context.Context, *ReactionSingleRequest) (*ReactionSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedReactionServiceServer) GetList(context.Context, *GetListReactionRequest) (*ReactionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedReactionServiceServer) testEmbeddedByValue() {}
+
+// UnsafeReactionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReactionServiceServer will
+// result in compilation errors.
+type UnsafeReactionServiceServer interface {
+	mustEmbedUnimplementedReactionServiceServer()
+}
+
+func RegisterReactionServiceServer(s grpc.ServiceRegistrar, srv ReactionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReactionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReactionService_ServiceDesc, srv)
+}
+
+func _ReactionService_Upsert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Reaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).Upsert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_Upsert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).Upsert(ctx, req.(*Reaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReactionService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactionSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).Delete(ctx, req.(*ReactionSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReactionService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServiceServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReactionService_GetList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServiceServer).GetList(ctx, req.(*GetListReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReactionService_ServiceDesc is the grpc.ServiceDesc for ReactionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReactionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "post_service.ReactionService",
+	HandlerType: (*ReactionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Upsert",
+			Handler:    _ReactionService_Upsert_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ReactionService_Delete_Handler,
+		},
+		{
+			MethodName: "GetList",
+			Handler:    _ReactionService_GetList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reaction.proto",
+}
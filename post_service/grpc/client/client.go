@@ -11,10 +11,16 @@ import (
 
 type ServiceManagerI interface {
 	UserService() user_service.UserServiceClient
+	FollowService() user_service.FollowServiceClient
+	UserSettingsService() user_service.UserSettingsServiceClient
+	BulkUserJobService() user_service.BulkUserJobServiceClient
 }
 
 type grpcClients struct {
-	userService user_service.UserServiceClient
+	userService         user_service.UserServiceClient
+	followService       user_service.FollowServiceClient
+	userSettingsService user_service.UserSettingsServiceClient
+	bulkUserJobService  user_service.BulkUserJobServiceClient
 }
 
 func NewGrpcClients(cfg config.Config) (ServiceManagerI, error) {
@@ -29,10 +35,25 @@ func NewGrpcClients(cfg config.Config) (ServiceManagerI, error) {
 	}
 
 	return &grpcClients{
-		userService: user_service.NewUserServiceClient(connUserService),
+		userService:         user_service.NewUserServiceClient(connUserService),
+		followService:       user_service.NewFollowServiceClient(connUserService),
+		userSettingsService: user_service.NewUserSettingsServiceClient(connUserService),
+		bulkUserJobService:  user_service.NewBulkUserJobServiceClient(connUserService),
 	}, nil
 }
 
 func (g *grpcClients) UserService() user_service.UserServiceClient {
 	return g.userService
 }
+
+func (g *grpcClients) FollowService() user_service.FollowServiceClient {
+	return g.followService
+}
+
+func (g *grpcClients) UserSettingsService() user_service.UserSettingsServiceClient {
+	return g.userSettingsService
+}
+
+func (g *grpcClients) BulkUserJobService() user_service.BulkUserJobServiceClient {
+	return g.bulkUserJobService
+}
@@ -1,23 +1,58 @@
 package grpc
 
 import (
+	"context"
 	"post_service/config"
 	"post_service/genproto/post_service"
 	"post_service/grpc/client"
+	"post_service/grpc/interceptor"
 	"post_service/grpc/service"
 	"post_service/storage"
 
 	"github.com/saidamir98/udevs_pkg/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
 )
 
-func SetUpServer(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvc client.ServiceManagerI) (grpcServer *grpc.Server) {
+func SetUpServer(cfg config.Config, log logger.LoggerI, strg storage.StorageI, cache storage.CacheI, srvc client.ServiceManagerI) (grpcServer *grpc.Server) {
 
-	grpcServer = grpc.NewServer()
+	grpcServer = grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Audit(log, strg.AuditEvent(), auditGetters(strg))),
+	)
 
-	post_service.RegisterPostServiceServer(grpcServer, service.NewPostService(cfg, log, strg, srvc))
+	post_service.RegisterPostServiceServer(grpcServer, service.NewPostService(cfg, log, strg, cache, srvc))
+	post_service.RegisterPostRevisionServiceServer(grpcServer, service.NewPostRevisionService(cfg, log, strg, srvc))
+	post_service.RegisterMentionServiceServer(grpcServer, service.NewMentionService(cfg, log, strg, srvc))
+	post_service.RegisterBookmarkServiceServer(grpcServer, service.NewBookmarkService(cfg, log, strg, srvc))
+	post_service.RegisterBookmarkCollectionServiceServer(grpcServer, service.NewBookmarkCollectionService(cfg, log, strg, srvc))
 	post_service.RegisterPostAttachmentServiceServer(grpcServer, service.NewPostAttachmentService(cfg, log, strg, srvc))
+	post_service.RegisterMediaServiceServer(grpcServer, service.NewMediaService(cfg, log, strg, srvc))
+	post_service.RegisterCommentServiceServer(grpcServer, service.NewCommentService(cfg, log, strg, srvc))
+	post_service.RegisterReactionServiceServer(grpcServer, service.NewReactionService(cfg, log, strg, srvc))
+	post_service.RegisterFeedServiceServer(grpcServer, service.NewFeedService(cfg, log, strg, cache, srvc))
+	post_service.RegisterTagServiceServer(grpcServer, service.NewTagService(cfg, log, strg, cache, srvc))
+	post_service.RegisterBlockedTagServiceServer(grpcServer, service.NewBlockedTagService(cfg, log, strg, srvc))
+	post_service.RegisterReportServiceServer(grpcServer, service.NewReportService(cfg, log, strg, srvc))
+	post_service.RegisterModerationServiceServer(grpcServer, service.NewModerationService(cfg, log, strg, srvc))
 	reflection.Register(grpcServer)
 	return
 }
+
+// auditGetters load the state of a resource before it is changed, so the audit log can store a diff.
+func auditGetters(strg storage.StorageI) map[string]interceptor.Getter {
+	return map[string]interceptor.Getter{
+		"post_service.PostService": func(ctx context.Context, id string) (proto.Message, error) {
+			return strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: id})
+		},
+		"post_service.PostRevisionService": func(ctx context.Context, id string) (proto.Message, error) {
+			return strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: id})
+		},
+		"post_service.PostAttachmentService": func(ctx context.Context, id string) (proto.Message, error) {
+			return strg.PostAttachment().GetSingle(ctx, &post_service.AttachmentSingleRequest{Id: id})
+		},
+		"post_service.CommentService": func(ctx context.Context, id string) (proto.Message, error) {
+			return strg.Comment().GetSingle(ctx, &post_service.CommentSingleRequest{Id: id})
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package interceptor_test
+
+import (
+	"context"
+	"post_service/genproto/post_service"
+	"post_service/genproto/user_service"
+	"post_service/grpc/interceptor"
+	"testing"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeAuditRepo struct {
+	events []*user_service.AuditEvent
+}
+
+func (f *fakeAuditRepo) Create(_ context.Context, event *user_service.AuditEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+// call runs method through the audit interceptor with a handler that returns resp.
+func call(t *testing.T, repo *fakeAuditRepo, getters map[string]interceptor.Getter, method string, req, resp interface{}) {
+	audit := interceptor.Audit(logger.NewLogger("test", logger.LevelError), repo, getters)
+
+	_, err := audit(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: method}, func(context.Context, interface{}) (interface{}, error) {
+		return resp, nil
+	})
+	require.NoError(t, err)
+}
+
+func TestAudit_RecordsMutatingMethods(t *testing.T) {
+	tests := []struct {
+		method   string
+		recorded bool
+		resource string
+	}{
+		{"/post_service.PostService/Create", true, "post"},
+		{"/post_service.PostService/Update", true, "post"},
+		{"/post_service.PostService/Repost", true, "post"},
+		{"/post_service.PostService/Unrepost", true, "post"},
+		{"/post_service.PostService/Reschedule", true, "post"},
+		{"/post_service.PostAttachmentService/MultipleUpsert", true, "post_attachment"},
+		{"/post_service.ReactionService/Upsert", true, "reaction"},
+		{"/post_service.BookmarkService/Delete", true, "bookmark"},
+		{"/post_service.PostService/GetSingle", false, ""},
+		{"/post_service.FeedService/GetHome", false, ""},
+		{"/post_service.MediaService/ClaimUnprocessed", false, ""},
+	}
+
+	for _, tt := range tests {
+		repo := &fakeAuditRepo{}
+		call(t, repo, nil, tt.method, &post_service.PostSingleRequest{Id: "p1"}, &post_service.Post{Id: "p1"})
+
+		if !tt.recorded {
+			assert.Empty(t, repo.events, tt.method)
+			continue
+		}
+		if assert.Len(t, repo.events, 1, tt.method) {
+			assert.Equal(t, tt.resource, repo.events[0].Resource, tt.method)
+			assert.Equal(t, "p1", repo.events[0].TargetId, tt.method)
+			assert.Equal(t, "OK", repo.events[0].Status, tt.method)
+		}
+	}
+}
+
+func TestAudit_DiffsAgainstGetter(t *testing.T) {
+	getters := map[string]interceptor.Getter{
+		"post_service.PostService": func(context.Context, string) (proto.Message, error) {
+			return &post_service.Post{Id: "p1", Content: "old", UpdatedAt: "2025-01-01T00:00:00Z"}, nil
+		},
+	}
+
+	repo := &fakeAuditRepo{}
+	call(t, repo, getters, "/post_service.PostService/Update",
+		&post_service.Post{Id: "p1", Content: "new"},
+		&post_service.Post{Id: "p1", Content: "new", UpdatedAt: "2025-01-02T00:00:00Z"})
+
+	require.Len(t, repo.events, 1)
+	changes := repo.events[0].Changes
+	require.Len(t, changes, 1)
+	assert.Equal(t, `"old"`, changes["content"].Before)
+	assert.Equal(t, `"new"`, changes["content"].After)
+}
+
+func TestDiff_RedactsSensitiveFields(t *testing.T) {
+	before := &user_service.User{Id: "u1", Password: "$2a$10$old"}
+	after := &user_service.User{Id: "u1", Password: "$2a$10$new", AccessToken: "token"}
+
+	changes := interceptor.Diff(before, after)
+
+	assert.Equal(t, `"[REDACTED]"`, changes["password"].Before)
+	assert.Equal(t, `"[REDACTED]"`, changes["password"].After)
+	assert.Equal(t, `"[REDACTED]"`, changes["access_token"].After)
+
+	job := &user_service.BulkUserJob{
+		Id:      "j1",
+		Preview: []*user_service.User{{UserName: "johndoe", Password: "$2a$10$hash"}},
+	}
+	nested := interceptor.Diff(nil, job)
+	assert.Contains(t, nested["preview"].After, "johndoe")
+	assert.NotContains(t, nested["preview"].After, "hash")
+}
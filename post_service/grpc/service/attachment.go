@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"post_service/config"
 	"post_service/genproto/post_service"
+	"post_service/pkg/cursor"
 
 	"post_service/grpc/client"
 	"post_service/storage"
 
 	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -31,6 +35,13 @@ func NewPostAttachmentService(cfg config.Config, log logger.LoggerI, strg storag
 func (s *PostAttachmentService) MultipleUpsert(ctx context.Context, req *post_service.AttachmentMultipleInsertRequest) (*post_service.AttachmentList, error) {
 	s.log.Info("---CreatePostAttachment--->>>", logger.Any("req", req))
 
+	if len(req.Attachments) == 0 {
+		return &post_service.AttachmentList{}, nil
+	}
+	if err := resolveMedia(ctx, s.strg, req.PostId, req.Attachments); err != nil {
+		return &post_service.AttachmentList{}, err
+	}
+
 	resp, err := s.strg.PostAttachment().MultipleUpsert(ctx, req)
 	if err != nil {
 		s.log.Error("---CreatePostAttachment--->>>", logger.Error(err))
@@ -43,6 +54,10 @@ func (s *PostAttachmentService) MultipleUpsert(ctx context.Context, req *post_se
 func (s *PostAttachmentService) Create(ctx context.Context, req *post_service.Attachment) (*post_service.Attachment, error) {
 	s.log.Info("---CreatePostAttachment--->>>", logger.Any("req", req))
 
+	if err := resolveMedia(ctx, s.strg, req.PostId, []*post_service.Attachment{req}); err != nil {
+		return &post_service.Attachment{}, err
+	}
+
 	resp, err := s.strg.PostAttachment().Create(ctx, req)
 	if err != nil {
 		s.log.Error("---CreatePostAttachment--->>>", logger.Error(err))
@@ -67,7 +82,12 @@ func (s *PostAttachmentService) GetSingle(ctx context.Context, req *post_service
 func (s *PostAttachmentService) GetList(ctx context.Context, req *post_service.GetListAttachmentRequest) (*post_service.AttachmentList, error) {
 	s.log.Info("---GetAllPostAttachments--->>>", logger.Any("req", req))
 
+	req.Limit = listLimit(req.Limit)
+
 	resp, err := s.strg.PostAttachment().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.AttachmentList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
 	if err != nil {
 		s.log.Error("---GetAllPostAttachments--->>>", logger.Error(err))
 		return &post_service.AttachmentList{}, err
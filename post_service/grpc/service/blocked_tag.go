@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/grpc/client"
+	"post_service/pkg/hashtag"
+	"post_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type BlockedTagService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewBlockedTagService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *BlockedTagService {
+	return &BlockedTagService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create blocks a tag, blocked tags are never shown as trending.
+func (s *BlockedTagService) Create(ctx context.Context, req *post_service.BlockedTag) (*post_service.BlockedTag, error) {
+	s.log.Info("---CreateBlockedTag--->>>", logger.Any("req", req))
+
+	if req.Tag = hashtag.Normalize(req.Tag); req.Tag == "" {
+		return &post_service.BlockedTag{}, status.Error(codes.InvalidArgument, "invalid tag")
+	}
+
+	resp, err := s.strg.BlockedTag().Create(ctx, req)
+	if err != nil {
+		s.log.Error("---CreateBlockedTag--->>>", logger.Error(err))
+		return &post_service.BlockedTag{}, err
+	}
+
+	return resp, nil
+}
+
+// Delete unblocks a tag, it can trend again after the next refresh.
+func (s *BlockedTagService) Delete(ctx context.Context, req *post_service.TagSingleRequest) (*emptypb.Empty, error) {
+	s.log.Info("---DeleteBlockedTag--->>>", logger.Any("req", req))
+
+	if req.Tag = hashtag.Normalize(req.Tag); req.Tag == "" {
+		return &emptypb.Empty{}, status.Error(codes.InvalidArgument, "invalid tag")
+	}
+
+	_, err := s.strg.BlockedTag().Delete(ctx, req)
+	if err != nil {
+		s.log.Error("---DeleteBlockedTag--->>>", logger.Error(err))
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
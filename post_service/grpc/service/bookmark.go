@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxCollectionName is the length of the name of a bookmark collection, in characters.
+const maxCollectionName = 100
+
+type BookmarkService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewBookmarkService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *BookmarkService {
+	return &BookmarkService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create bookmarks a post the user can read.
+func (s *BookmarkService) Create(ctx context.Context, req *post_service.BookmarkRequest) (*emptypb.Empty, error) {
+	s.log.Info("---CreateBookmark--->>>", logger.Any("req", req))
+
+	if req.UserId == "" {
+		return &emptypb.Empty{}, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if _, err := visiblePost(ctx, s.strg, req.PostId, req.UserId); err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	err := s.strg.Bookmark().Create(ctx, req)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &emptypb.Empty{}, status.Error(codes.NotFound, "collection not found")
+	}
+	if err != nil {
+		s.log.Error("---CreateBookmark--->>>", logger.Error(err))
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BookmarkService) Delete(ctx context.Context, req *post_service.BookmarkRequest) (*emptypb.Empty, error) {
+	s.log.Info("---DeleteBookmark--->>>", logger.Any("req", req))
+
+	if req.UserId == "" || req.PostId == "" {
+		return &emptypb.Empty{}, status.Error(codes.InvalidArgument, "user_id and post_id are required")
+	}
+
+	if err := s.strg.Bookmark().Delete(ctx, req); err != nil {
+		s.log.Error("---DeleteBookmark--->>>", logger.Error(err))
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetList returns one page of the bookmarks of the viewer, or of a collection the viewer can read.
+func (s *BookmarkService) GetList(ctx context.Context, req *post_service.GetListBookmarkRequest) (*post_service.PostList, error) {
+	s.log.Info("---GetAllBookmarks--->>>", logger.Any("req", req))
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+	req.Limit = listLimit(req.Limit)
+
+	if req.CollectionId != "" {
+		if _, err := readableCollection(ctx, s.strg, req.CollectionId, req.ViewerId); err != nil {
+			return &post_service.PostList{}, err
+		}
+	} else if req.UserId == "" || req.UserId != req.ViewerId {
+		return &post_service.PostList{}, status.Error(codes.PermissionDenied, "bookmarks are only listed for their owner")
+	}
+
+	resp, err := s.strg.Bookmark().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.PostList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetAllBookmarks--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	if err := embedOriginals(ctx, s.strg, resp.Items, req.ViewerId, maxRepostDepth); err != nil {
+		s.log.Error("---GetAllBookmarks--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	return resp, nil
+}
+
+type BookmarkCollectionService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewBookmarkCollectionService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *BookmarkCollectionService {
+	return &BookmarkCollectionService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+func (s *BookmarkCollectionService) Create(ctx context.Context, req *post_service.BookmarkCollection) (*post_service.BookmarkCollection, error) {
+	s.log.Info("---CreateBookmarkCollection--->>>", logger.Any("req", req))
+
+	if err := validateCollection(req); err != nil {
+		return &post_service.BookmarkCollection{}, err
+	}
+
+	resp, err := s.strg.BookmarkCollection().Create(ctx, req)
+	if err != nil {
+		s.log.Error("---CreateBookmarkCollection--->>>", logger.Error(err))
+		return &post_service.BookmarkCollection{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *BookmarkCollectionService) GetSingle(ctx context.Context, req *post_service.BookmarkCollectionSingleRequest) (*post_service.BookmarkCollection, error) {
+	s.log.Info("---GetSingleBookmarkCollection--->>>", logger.Any("req", req))
+
+	resp, err := readableCollection(ctx, s.strg, req.Id, viewerOf(ctx, req.ViewerId))
+	if err != nil {
+		return &post_service.BookmarkCollection{}, err
+	}
+
+	return resp, nil
+}
+
+// GetList returns the collections of a user in their order, only the shared ones for other users.
+func (s *BookmarkCollectionService) GetList(ctx context.Context, req *post_service.GetListBookmarkCollectionRequest) (*post_service.BookmarkCollectionList, error) {
+	s.log.Info("---GetAllBookmarkCollections--->>>", logger.Any("req", req))
+
+	if req.OwnerId == "" {
+		return &post_service.BookmarkCollectionList{}, status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+
+	resp, err := s.strg.BookmarkCollection().GetList(ctx, req)
+	if err != nil {
+		s.log.Error("---GetAllBookmarkCollections--->>>", logger.Error(err))
+		return &post_service.BookmarkCollectionList{}, err
+	}
+
+	return resp, nil
+}
+
+// Update renames a collection of the owner and shares or unshares it.
+func (s *BookmarkCollectionService) Update(ctx context.Context, req *post_service.BookmarkCollection) (*post_service.BookmarkCollection, error) {
+	s.log.Info("---UpdateBookmarkCollection--->>>", logger.Any("req", req))
+
+	if err := validateCollection(req); err != nil {
+		return &post_service.BookmarkCollection{}, err
+	}
+
+	resp, err := s.strg.BookmarkCollection().Update(ctx, req)
+	if isUniqueViolation(err) {
+		return &post_service.BookmarkCollection{}, status.Error(codes.AlreadyExists, "a collection with this name already exists")
+	}
+	if err != nil {
+		s.log.Error("---UpdateBookmarkCollection--->>>", logger.Error(err))
+		return &post_service.BookmarkCollection{}, notFound(err, "collection not found")
+	}
+
+	return resp, nil
+}
+
+// Delete deletes a collection of the viewer, the posts in it stay bookmarked.
+func (s *BookmarkCollectionService) Delete(ctx context.Context, req *post_service.BookmarkCollectionSingleRequest) (*emptypb.Empty, error) {
+	s.log.Info("---DeleteBookmarkCollection--->>>", logger.Any("req", req))
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+
+	if err := s.strg.BookmarkCollection().Delete(ctx, req); err != nil {
+		s.log.Error("---DeleteBookmarkCollection--->>>", logger.Error(err))
+		return &emptypb.Empty{}, notFound(err, "collection not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BookmarkCollectionService) Reorder(ctx context.Context, req *post_service.ReorderBookmarkCollectionsRequest) (*post_service.BookmarkCollectionList, error) {
+	s.log.Info("---ReorderBookmarkCollections--->>>", logger.Any("req", req))
+
+	if req.OwnerId == "" {
+		return &post_service.BookmarkCollectionList{}, status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+	for _, id := range req.Ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return &post_service.BookmarkCollectionList{}, status.Errorf(codes.InvalidArgument, "invalid collection id %q", id)
+		}
+	}
+
+	resp, err := s.strg.BookmarkCollection().Reorder(ctx, req)
+	if err != nil {
+		s.log.Error("---ReorderBookmarkCollections--->>>", logger.Error(err))
+		return &post_service.BookmarkCollectionList{}, err
+	}
+
+	return resp, nil
+}
+
+// readableCollection returns a collection that is shared or owned by the viewer, other collections are not found.
+func readableCollection(ctx context.Context, strg storage.StorageI, id, viewerID string) (*post_service.BookmarkCollection, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+
+	collection, err := strg.BookmarkCollection().GetSingle(ctx, &post_service.BookmarkCollectionSingleRequest{Id: id})
+	if err != nil {
+		return nil, notFound(err, "collection not found")
+	}
+	if !collection.Shared && collection.OwnerId != viewerID {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+
+	return collection, nil
+}
+
+func validateCollection(req *post_service.BookmarkCollection) error {
+	if req.OwnerId == "" {
+		return status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	if utf8.RuneCountInString(req.Name) > maxCollectionName {
+		return status.Errorf(codes.InvalidArgument, "name can be at most %d characters long", maxCollectionName)
+	}
+
+	return nil
+}
+
+// isUniqueViolation tells whether err is a unique constraint violation of postgres.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
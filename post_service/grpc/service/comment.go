@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// maxCommentDepth is the depth of the deepest reply, top level comments have depth 0.
+	maxCommentDepth = 4
+	// maxCommentLength is the maximum number of characters in a comment.
+	maxCommentLength = 2000
+
+	defaultCommentLimit = 20
+	maxCommentLimit     = 100
+)
+
+type CommentService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewCommentService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *CommentService {
+	return &CommentService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create adds a comment to a post, or a reply when parent_id is set.
+func (s *CommentService) Create(ctx context.Context, req *post_service.Comment) (*post_service.Comment, error) {
+	s.log.Info("---CreateComment--->>>", logger.Any("req", req))
+
+	if err := validateCommentContent(req); err != nil {
+		return &post_service.Comment{}, err
+	}
+
+	if _, err := visiblePost(ctx, s.strg, req.PostId, viewerOf(ctx, req.AuthorId)); err != nil {
+		s.log.Error("---CreateComment--->>>", logger.Error(err))
+		return &post_service.Comment{}, err
+	}
+
+	req.Depth = 0
+	if req.ParentId != "" {
+		parent, err := s.strg.Comment().GetSingle(ctx, &post_service.CommentSingleRequest{Id: req.ParentId})
+		if err != nil {
+			s.log.Error("---CreateComment--->>>", logger.Error(err))
+			return &post_service.Comment{}, notFound(err, "parent comment not found")
+		}
+
+		if parent.PostId != req.PostId {
+			return &post_service.Comment{}, status.Error(codes.InvalidArgument, "parent comment belongs to another post")
+		}
+
+		if parent.Status != "active" {
+			return &post_service.Comment{}, status.Error(codes.InvalidArgument, "cannot reply to a deleted comment")
+		}
+
+		if parent.Depth >= maxCommentDepth {
+			return &post_service.Comment{}, status.Errorf(codes.InvalidArgument, "replies can be nested at most %d levels deep", maxCommentDepth)
+		}
+
+		req.Depth = parent.Depth + 1
+	}
+
+	resp, err := s.strg.Comment().Create(ctx, req)
+	if err != nil {
+		s.log.Error("---CreateComment--->>>", logger.Error(err))
+		return &post_service.Comment{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *CommentService) GetSingle(ctx context.Context, req *post_service.CommentSingleRequest) (*post_service.Comment, error) {
+	s.log.Info("---GetSingleComment--->>>", logger.Any("req", req))
+
+	resp, err := s.strg.Comment().GetSingle(ctx, req)
+	if err != nil {
+		s.log.Error("---GetSingleComment--->>>", logger.Error(err))
+		return &post_service.Comment{}, notFound(err, "comment not found")
+	}
+
+	return resp, nil
+}
+
+// GetList returns one page of top level comments of a post, or of replies to parent_id.
+func (s *CommentService) GetList(ctx context.Context, req *post_service.GetListCommentRequest) (*post_service.CommentList, error) {
+	s.log.Info("---GetAllComments--->>>", logger.Any("req", req))
+
+	if req.Limit == 0 {
+		req.Limit = defaultCommentLimit
+	}
+	req.Limit = min(req.Limit, maxCommentLimit)
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+	if _, err := visiblePost(ctx, s.strg, req.PostId, req.ViewerId); err != nil {
+		return &post_service.CommentList{}, err
+	}
+
+	resp, err := s.strg.Comment().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.CommentList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetAllComments--->>>", logger.Error(err))
+		return &post_service.CommentList{}, err
+	}
+
+	return resp, nil
+}
+
+// Update edits the content of a comment, only the author can edit it.
+func (s *CommentService) Update(ctx context.Context, req *post_service.Comment) (*post_service.Comment, error) {
+	s.log.Info("---UpdateComment--->>>", logger.Any("req", req))
+
+	if err := validateCommentContent(req); err != nil {
+		return &post_service.Comment{}, err
+	}
+
+	comment, err := s.strg.Comment().GetSingle(ctx, &post_service.CommentSingleRequest{Id: req.Id})
+	if err != nil {
+		s.log.Error("---UpdateComment--->>>", logger.Error(err))
+		return &post_service.Comment{}, notFound(err, "comment not found")
+	}
+
+	if comment.AuthorId != req.AuthorId {
+		return &post_service.Comment{}, status.Error(codes.PermissionDenied, "only the author can edit the comment")
+	}
+
+	resp, err := s.strg.Comment().Update(ctx, req)
+	if err != nil {
+		s.log.Error("---UpdateComment--->>>", logger.Error(err))
+		return &post_service.Comment{}, notFound(err, "comment not found")
+	}
+
+	return resp, nil
+}
+
+// Delete deletes a comment of the requester, moderators can also remove comments of other users.
+func (s *CommentService) Delete(ctx context.Context, req *post_service.DeleteCommentRequest) (*emptypb.Empty, error) {
+	s.log.Info("---DeleteComment--->>>", logger.Any("req", req))
+
+	comment, err := s.strg.Comment().GetSingle(ctx, &post_service.CommentSingleRequest{Id: req.Id})
+	if err != nil {
+		s.log.Error("---DeleteComment--->>>", logger.Error(err))
+		return &emptypb.Empty{}, notFound(err, "comment not found")
+	}
+
+	switch {
+	case comment.AuthorId == req.RequestedBy:
+		// a moderator deleting their own comment is a regular delete
+		req.Moderator = false
+	case !req.Moderator:
+		return &emptypb.Empty{}, status.Error(codes.PermissionDenied, "only the author or a moderator can delete the comment")
+	}
+
+	resp, err := s.strg.Comment().Delete(ctx, req)
+	if err != nil {
+		s.log.Error("---DeleteComment--->>>", logger.Error(err))
+		return &emptypb.Empty{}, notFound(err, "comment not found")
+	}
+
+	return resp, nil
+}
+
+func validateCommentContent(req *post_service.Comment) error {
+	req.Content = strings.TrimSpace(req.Content)
+
+	if req.Content == "" {
+		return status.Error(codes.InvalidArgument, "content is required")
+	}
+
+	if utf8.RuneCountInString(req.Content) > maxCommentLength {
+		return status.Errorf(codes.InvalidArgument, "content is longer than %d characters", maxCommentLength)
+	}
+
+	return nil
+}
+
+// notFound turns pgx.ErrNoRows into a NotFound status, other errors are returned as is.
+func notFound(err error, message string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return status.Error(codes.NotFound, message)
+	}
+	return err
+}
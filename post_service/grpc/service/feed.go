@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/genproto/user_service"
+	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"post_service/storage/redis"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// feedFanoutThreshold is the follower count from which posts are no longer pushed to the timelines
+	// of the followers, the followers pull them from postgres when they read the feed.
+	feedFanoutThreshold = 10000
+	// feedFollowBatch is the page size used to load followers and followed accounts.
+	feedFollowBatch = 1000
+
+	defaultFeedLimit = 20
+	maxFeedLimit     = 100
+)
+
+type FeedService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	cache    storage.CacheI
+	services client.ServiceManagerI
+}
+
+func NewFeedService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, cache storage.CacheI, srvs client.ServiceManagerI) *FeedService {
+	return &FeedService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		cache:    cache,
+		services: srvs,
+	}
+}
+
+// GetHome returns published posts of the accounts the viewer follows and of the viewer, newest first.
+//
+// Posts of regular accounts are pushed to the redis timeline of every follower when they are published,
+// posts of accounts with at least feedFanoutThreshold followers are pulled from postgres on read.
+// Every page is read from postgres by id, so deleted, unpublished and unfollowed posts never show up
+// even though they stay in the cached timelines.
+func (s *FeedService) GetHome(ctx context.Context, req *post_service.GetHomeFeedRequest) (*post_service.PostList, error) {
+	s.log.Info("---GetHomeFeed--->>>", logger.Any("req", req))
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+	if req.ViewerId == "" {
+		return &post_service.PostList{}, status.Error(codes.InvalidArgument, "viewer_id is required")
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultFeedLimit
+	}
+	limit := int(min(req.Limit, maxFeedLimit))
+
+	var before *storage.TimelineEntry
+	if req.Cursor != "" {
+		createdAt, id, err := cursor.Decode(req.Cursor)
+		if err != nil {
+			return &post_service.PostList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		before = &storage.TimelineEntry{PostID: id, CreatedAt: createdAt}
+	}
+
+	following, popular, err := s.following(ctx, req.ViewerId)
+	if err != nil {
+		s.log.Error("---GetHomeFeed--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	owners := append([]string{req.ViewerId}, following...)
+	pushed := append([]string{req.ViewerId}, without(following, popular)...)
+
+	// without a timeline every page is pulled from postgres, which is slower but still correct
+	cached := s.ensureTimeline(ctx, req.ViewerId, pushed)
+
+	// one extra post tells whether there is a next page
+	var items []storage.FeedItem
+	for len(items) <= limit {
+		query := &storage.FeedQuery{
+			ViewerID: req.ViewerId,
+			Owners:   owners,
+			Pulled:   popular,
+			Before:   before,
+			Limit:    limit + 1 - len(items),
+		}
+
+		var entries []storage.TimelineEntry
+		if cached {
+			entries, err = s.cache.Timeline().GetBefore(ctx, req.ViewerId, before, limit+1)
+			if err != nil {
+				s.log.Error("---GetHomeFeed--->>>", logger.Error(err))
+				cached, entries = false, nil
+			}
+		}
+
+		for _, e := range entries {
+			query.PostIDs = append(query.PostIDs, e.PostID)
+		}
+
+		oldest := len(entries) - 1
+		exhausted := len(entries) < limit+1
+		switch {
+		case !exhausted:
+			// the pulled posts must not skip over timeline entries that were not read yet
+			query.From = &entries[oldest]
+		case oldest >= 0:
+			// the timeline is trimmed, older posts of every account come from postgres
+			query.PullBelow = &entries[oldest]
+		default:
+			query.PullAll = true
+		}
+
+		page, err := s.strg.Post().GetFeed(ctx, query)
+		if err != nil {
+			s.log.Error("---GetHomeFeed--->>>", logger.Error(err))
+			return &post_service.PostList{}, err
+		}
+		items = append(items, page...)
+
+		if exhausted {
+			break
+		}
+		before = &entries[oldest]
+	}
+
+	resp := &post_service.PostList{}
+	if len(items) > limit {
+		last := items[limit-1]
+		resp.NextCursor = cursor.Encode(last.CreatedAt, last.Post.Id)
+		items = items[:limit]
+	}
+
+	for _, item := range items {
+		resp.Items = append(resp.Items, item.Post)
+	}
+	resp.Count = int64(len(resp.Items))
+
+	if err := embedOriginals(ctx, s.strg, resp.Items, req.ViewerId, maxRepostDepth); err != nil {
+		s.log.Error("---GetHomeFeed--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	if err := attachPolls(ctx, s.strg, resp.Items, req.ViewerId); err != nil {
+		s.log.Error("---GetHomeFeed--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	if err := attachPreviews(ctx, s.strg, resp.Items); err != nil {
+		s.log.Error("---GetHomeFeed--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	countViews(ctx, s.log, s.cache.View().AddImpressions, req.ViewerId, resp.Items...)
+
+	return resp, nil
+}
+
+// Reset drops the cached timeline of the user, it is rebuilt on the next read.
+func (s *FeedService) Reset(ctx context.Context, req *post_service.FeedResetRequest) (*emptypb.Empty, error) {
+	s.log.Info("---ResetFeed--->>>", logger.Any("req", req))
+
+	if err := s.cache.Timeline().Delete(ctx, req.UserId); err != nil {
+		s.log.Error("---ResetFeed--->>>", logger.Error(err))
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// following returns every account the user follows and, separately, the ones whose posts are not pushed.
+func (s *FeedService) following(ctx context.Context, userID string) ([]string, []string, error) {
+	var (
+		all, popular []string
+		next         string
+	)
+
+	for {
+		page, err := s.services.FollowService().GetList(ctx, &user_service.GetListFollowRequest{
+			UserId:    userID,
+			Direction: "following",
+			Cursor:    next,
+			Limit:     feedFollowBatch,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, u := range page.Items {
+			all = append(all, u.UserId)
+			if u.FollowerCount >= feedFanoutThreshold {
+				popular = append(popular, u.UserId)
+			}
+		}
+
+		if page.NextCursor == "" {
+			return all, popular, nil
+		}
+		next = page.NextCursor
+	}
+}
+
+// ensureTimeline builds the timeline of the user from postgres if it is not cached.
+// It returns false if the timeline can't be used.
+func (s *FeedService) ensureTimeline(ctx context.Context, userID string, owners []string) bool {
+	exists, err := s.cache.Timeline().Exists(ctx, userID)
+	if err != nil {
+		s.log.Error("---EnsureTimeline--->>>", logger.Error(err))
+		return false
+	}
+	if exists {
+		return true
+	}
+
+	entries, err := s.strg.Post().GetTimelineEntries(ctx, &storage.TimelineEntryFilter{
+		OwnerIDs: owners,
+		Limit:    redis.TimelineSize,
+	})
+	if err != nil {
+		s.log.Error("---EnsureTimeline--->>>", logger.Error(err))
+		return false
+	}
+
+	if err := s.cache.Timeline().Replace(ctx, userID, entries); err != nil {
+		s.log.Error("---EnsureTimeline--->>>", logger.Error(err))
+		return false
+	}
+
+	return true
+}
+
+// FanOut pushes a published post to the timeline of its author and, unless the author has too many
+// followers, to the timelines of the followers. It runs after the request is answered, or after the
+// scheduler published the post.
+func (s *PostService) FanOut(post *post_service.Post) {
+	ctx := context.Background()
+
+	entries, err := s.strg.Post().GetTimelineEntries(ctx, &storage.TimelineEntryFilter{PostIDs: []string{post.Id}})
+	if err != nil {
+		s.log.Error("---FanOutPost--->>>", logger.Error(err), logger.String("post_id", post.Id))
+		return
+	}
+
+	// the post was deleted or unpublished in the meantime
+	if len(entries) == 0 {
+		return
+	}
+	entry := entries[0]
+
+	if err := s.cache.Timeline().Push(ctx, []string{post.OwnerId}, entry); err != nil {
+		s.log.Error("---FanOutPost--->>>", logger.Error(err), logger.String("post_id", post.Id))
+	}
+
+	stats, err := s.services.FollowService().GetStats(ctx, &user_service.FollowStatsRequest{UserId: post.OwnerId})
+	if err != nil {
+		s.log.Error("---FanOutPost--->>>", logger.Error(err), logger.String("post_id", post.Id))
+		return
+	}
+
+	if stats.FollowerCount >= feedFanoutThreshold {
+		return
+	}
+
+	var next string
+	for {
+		page, err := s.services.FollowService().GetList(ctx, &user_service.GetListFollowRequest{
+			UserId:    post.OwnerId,
+			Direction: "followers",
+			Cursor:    next,
+			Limit:     feedFollowBatch,
+		})
+		if err != nil {
+			s.log.Error("---FanOutPost--->>>", logger.Error(err), logger.String("post_id", post.Id))
+			return
+		}
+
+		followers := make([]string, 0, len(page.Items))
+		for _, u := range page.Items {
+			followers = append(followers, u.UserId)
+		}
+
+		if err := s.cache.Timeline().Push(ctx, followers, entry); err != nil {
+			s.log.Error("---FanOutPost--->>>", logger.Error(err), logger.String("post_id", post.Id))
+		}
+
+		if page.NextCursor == "" {
+			return
+		}
+		next = page.NextCursor
+	}
+}
+
+// without returns the items of list that are not in exclude.
+func without(list, exclude []string) []string {
+	skip := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		skip[id] = true
+	}
+
+	var out []string
+	for _, id := range list {
+		if !skip[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"post_service/genproto/post_service"
+	"post_service/pkg/unfurl"
+	"post_service/storage"
+	"time"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+const (
+	// previewTTL is how long a fetched preview is used before the page is fetched again.
+	previewTTL = 24 * time.Hour
+	// failedPreviewTTL is how long a URL whose preview could not be fetched is left alone.
+	failedPreviewTTL = time.Hour
+)
+
+// saveLinks stores the URLs in the content of a post and fetches their previews in the background.
+func saveLinks(ctx context.Context, log logger.LoggerI, strg storage.StorageI, fetcher *unfurl.Fetcher, post *post_service.Post) error {
+	urls := unfurl.ExtractURLs(post.Content)
+
+	if err := strg.LinkPreview().ReplacePostLinks(ctx, post.Id, urls); err != nil {
+		return err
+	}
+
+	if len(urls) > 0 {
+		go fetchPreviews(context.WithoutCancel(ctx), log, strg, fetcher, urls)
+	}
+
+	return nil
+}
+
+// fetchPreviews fetches the previews of the urls that are not cached, one at a time.
+func fetchPreviews(ctx context.Context, log logger.LoggerI, strg storage.StorageI, fetcher *unfurl.Fetcher, urls []string) {
+	stale, err := strg.LinkPreview().Stale(ctx, urls, previewTTL, failedPreviewTTL)
+	if err != nil {
+		log.Error("---FetchLinkPreviews--->>>", logger.Error(err))
+		return
+	}
+
+	for _, url := range stale {
+		var preview *post_service.LinkPreview
+
+		p, err := fetcher.Fetch(ctx, url)
+		if err != nil {
+			log.Warn("---FetchLinkPreviews--->>>", logger.Error(err), logger.String("url", url))
+		} else {
+			preview = &post_service.LinkPreview{
+				Url:         p.URL,
+				Title:       p.Title,
+				Description: p.Description,
+				ImageUrl:    p.ImageURL,
+				SiteName:    p.SiteName,
+			}
+		}
+
+		if err := strg.LinkPreview().Save(ctx, url, preview); err != nil {
+			log.Error("---FetchLinkPreviews--->>>", logger.Error(err), logger.String("url", url))
+		}
+	}
+}
+
+// attachPreviews sets the link previews of the posts.
+func attachPreviews(ctx context.Context, strg storage.StorageI, posts []*post_service.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id)
+	}
+
+	previews, err := strg.LinkPreview().GetByPostIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		post.LinkPreviews = previews[post.Id]
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/grpc/client"
+	"post_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// mediaTypes are the values of the attachment_type enum.
+var mediaTypes = map[string]bool{
+	"photo":    true,
+	"video":    true,
+	"audio":    true,
+	"document": true,
+}
+
+type MediaService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewMediaService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *MediaService {
+	return &MediaService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create registers an upload of a file the gateway stores in the blob store, a file that was
+// uploaded before gains a reference.
+func (s *MediaService) Create(ctx context.Context, req *post_service.Media) (*post_service.Media, error) {
+	s.log.Info("---CreateMedia--->>>", logger.Any("req", req))
+
+	switch {
+	case req.Key == "":
+		return &post_service.Media{}, status.Error(codes.InvalidArgument, "key is required")
+	case req.OwnerId == "":
+		return &post_service.Media{}, status.Error(codes.InvalidArgument, "owner_id is required")
+	case req.MimeType == "":
+		return &post_service.Media{}, status.Error(codes.InvalidArgument, "mime_type is required")
+	case !mediaTypes[req.Type]:
+		return &post_service.Media{}, status.Errorf(codes.InvalidArgument, "invalid type %q", req.Type)
+	case req.Size <= 0:
+		return &post_service.Media{}, status.Error(codes.InvalidArgument, "size must be positive")
+	case req.Sha256 != "" && !isSHA256(req.Sha256):
+		return &post_service.Media{}, status.Error(codes.InvalidArgument, "sha256 must be 64 hex digits")
+	}
+
+	resp, err := s.strg.Media().Create(ctx, req)
+	if isUniqueViolation(err) {
+		return &post_service.Media{}, status.Error(codes.AlreadyExists, "the content is stored under another key")
+	}
+	if err != nil {
+		s.log.Error("---CreateMedia--->>>", logger.Error(err))
+		return &post_service.Media{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *MediaService) GetSingle(ctx context.Context, req *post_service.MediaSingleRequest) (*post_service.Media, error) {
+	s.log.Info("---GetSingleMedia--->>>", logger.Any("req", req))
+
+	media, err := s.strg.Media().GetByKeys(ctx, []string{req.Key})
+	if err != nil {
+		s.log.Error("---GetSingleMedia--->>>", logger.Error(err))
+		return &post_service.Media{}, err
+	}
+	if len(media) == 0 {
+		return &post_service.Media{}, status.Error(codes.NotFound, "media not found")
+	}
+
+	return media[0], nil
+}
+
+// Delete removes an upload, the gateway removes the file from the blob store when no upload is left.
+func (s *MediaService) Delete(ctx context.Context, req *post_service.MediaDeleteRequest) (*post_service.Media, error) {
+	s.log.Info("---DeleteMedia--->>>", logger.Any("req", req))
+
+	if req.Key == "" || req.OwnerId == "" {
+		return &post_service.Media{}, status.Error(codes.InvalidArgument, "key and owner_id are required")
+	}
+
+	resp, err := s.strg.Media().Delete(ctx, req)
+	if err != nil {
+		s.log.Error("---DeleteMedia--->>>", logger.Error(err))
+		return &post_service.Media{}, notFound(err, "media not found")
+	}
+
+	return resp, nil
+}
+
+// ListReleased returns the blobs the gateway can delete, they are released with the last upload or
+// attachment of a file and with the attachments that had variants.
+func (s *MediaService) ListReleased(ctx context.Context, req *post_service.ListReleasedRequest) (*post_service.ReleasedBlobList, error) {
+	s.log.Info("---ListReleasedMedia--->>>", logger.Any("req", req))
+
+	if req.Limit <= 0 {
+		return &post_service.ReleasedBlobList{}, status.Error(codes.InvalidArgument, "limit must be positive")
+	}
+
+	keys, err := s.strg.Media().ListReleased(ctx, req.Limit)
+	if err != nil {
+		s.log.Error("---ListReleasedMedia--->>>", logger.Error(err))
+		return &post_service.ReleasedBlobList{}, err
+	}
+
+	return &post_service.ReleasedBlobList{Keys: keys}, nil
+}
+
+func (s *MediaService) ForgetReleased(ctx context.Context, req *post_service.ReleasedBlobList) (*emptypb.Empty, error) {
+	s.log.Info("---ForgetReleasedMedia--->>>", logger.Any("req", req))
+
+	if err := s.strg.Media().ForgetReleased(ctx, req.Keys); err != nil {
+		s.log.Error("---ForgetReleasedMedia--->>>", logger.Error(err))
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ClaimUnprocessed leases the photos to the image worker of the gateway, which holds the blob store.
+func (s *MediaService) ClaimUnprocessed(ctx context.Context, req *post_service.ClaimUnprocessedRequest) (*post_service.MediaJobList, error) {
+	s.log.Info("---ClaimUnprocessedMedia--->>>", logger.Any("req", req))
+
+	if req.Limit <= 0 || req.LeaseSeconds <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and lease_seconds must be positive")
+	}
+
+	resp, err := s.strg.Media().ClaimUnprocessed(ctx, req)
+	if err != nil {
+		s.log.Error("---ClaimUnprocessedMedia--->>>", logger.Error(err))
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *MediaService) SaveProcessed(ctx context.Context, req *post_service.ProcessedMedia) (*emptypb.Empty, error) {
+	s.log.Info("---SaveProcessedMedia--->>>", logger.String("key", req.Key))
+
+	if err := s.strg.Media().SaveProcessed(ctx, req); err != nil {
+		s.log.Error("---SaveProcessedMedia--->>>", logger.Error(err))
+		return nil, notFound(err, "media not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *MediaService) FailProcessing(ctx context.Context, req *post_service.FailProcessingRequest) (*emptypb.Empty, error) {
+	s.log.Info("---FailMediaProcessing--->>>", logger.Any("req", req))
+
+	if err := s.strg.Media().FailProcessing(ctx, req); err != nil {
+		s.log.Error("---FailMediaProcessing--->>>", logger.Error(err))
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func isSHA256(s string) bool {
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == sha256.Size
+}
+
+// resolveMedia points the attachments of a post to files uploaded by the owner of the post, the
+// filepath and the type of an attachment are taken from its file.
+func resolveMedia(ctx context.Context, strg storage.StorageI, postID string, attachments []*post_service.Attachment) error {
+	post, err := strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: postID})
+	if err != nil {
+		return notFound(err, "post not found")
+	}
+
+	keys := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment.ObjectKey == "" {
+			return status.Error(codes.InvalidArgument, "object_key is required, upload the file first")
+		}
+		keys = append(keys, attachment.ObjectKey)
+	}
+
+	media, err := strg.Media().GetUploaded(ctx, post.OwnerId, keys)
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[string]*post_service.Media, len(media))
+	for _, m := range media {
+		byKey[m.Key] = m
+	}
+
+	for _, attachment := range attachments {
+		m, ok := byKey[attachment.ObjectKey]
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unknown object_key %q", attachment.ObjectKey)
+		}
+		attachment.Filepath = m.Key
+		attachment.ContentType = m.Type
+	}
+
+	return nil
+}
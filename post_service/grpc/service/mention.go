@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/genproto/user_service"
+	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/pkg/mention"
+	"post_service/storage"
+	"strings"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxMentions is the number of distinct users a post links to, later mentions stay plain text.
+const maxMentions = 20
+
+type MentionService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewMentionService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *MentionService {
+	return &MentionService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// GetPosts returns one page of published posts that mention a user, newest first.
+func (s *MentionService) GetPosts(ctx context.Context, req *post_service.GetMentionPostsRequest) (*post_service.PostList, error) {
+	s.log.Info("---GetMentionPosts--->>>", logger.Any("req", req))
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+	if req.UserId == "" {
+		return &post_service.PostList{}, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	req.Limit = listLimit(req.Limit)
+
+	resp, err := s.strg.Mention().GetPosts(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.PostList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetMentionPosts--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	if err := embedOriginals(ctx, s.strg, resp.Items, req.ViewerId, maxRepostDepth); err != nil {
+		s.log.Error("---GetMentionPosts--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	return resp, nil
+}
+
+// saveMentions links the mentions in the content of a post to the mentioned users and stores them.
+// Unknown users and users who do not allow mentions are not linked, the author always can be.
+func saveMentions(ctx context.Context, log logger.LoggerI, strg storage.StorageI, services client.ServiceManagerI, post *post_service.Post) error {
+	var (
+		parsed = mention.Parse(post.Content)
+		users  = map[string]string{}
+	)
+
+	usernames := mention.Usernames(parsed)
+	if len(usernames) > maxMentions {
+		usernames = usernames[:maxMentions]
+	}
+
+	for _, username := range usernames {
+		user, err := services.UserService().GetSingle(ctx, &user_service.UserSingleRequest{Username: username})
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				log.Error("---ResolveMention--->>>", logger.Error(err), logger.String("username", username))
+			}
+			continue
+		}
+
+		if user.Id != post.OwnerId {
+			settings, err := services.UserSettingsService().GetSingle(ctx, &user_service.UserSettingsSingleRequest{UserId: user.Id})
+			if err != nil {
+				log.Error("---ResolveMention--->>>", logger.Error(err), logger.String("username", username))
+				continue
+			}
+			if !settings.GetPrivacy().GetAllowMentions() {
+				continue
+			}
+		}
+
+		users[strings.ToLower(username)] = user.Id
+	}
+
+	post.Mentions = nil
+	for _, m := range parsed {
+		if id, ok := users[strings.ToLower(m.Username)]; ok {
+			post.Mentions = append(post.Mentions, &post_service.Mention{
+				UserId:   id,
+				Username: m.Username,
+				Offset:   int32(m.Offset),
+				Length:   int32(m.Length),
+			})
+		}
+	}
+
+	return strg.Mention().Replace(ctx, post.Id, post.Mentions)
+}
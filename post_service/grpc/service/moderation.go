@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/genproto/user_service"
+	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/pkg/poststatus"
+	"post_service/storage"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxReportDetails is the length of the details of a report, in characters.
+const maxReportDetails = 1000
+
+var (
+	// reportTargets are the values of the report_target enum.
+	reportTargets = map[string]bool{
+		"post": true,
+		"user": true,
+	}
+
+	// reportReasons are the values of the report_reason enum.
+	reportReasons = map[string]bool{
+		"spam":           true,
+		"harassment":     true,
+		"hate":           true,
+		"violence":       true,
+		"sexual":         true,
+		"misinformation": true,
+		"impersonation":  true,
+		"other":          true,
+	}
+
+	// reportStatuses are the values of the report_status enum.
+	reportStatuses = map[string]bool{
+		"open":      true,
+		"resolved":  true,
+		"dismissed": true,
+	}
+
+	// moderationActions are the values of the moderation_action_type enum with the targets they apply to.
+	moderationActions = map[string]map[string]bool{
+		"hide_post":   {"post": true},
+		"delete_post": {"post": true},
+		"warn_user":   {"post": true, "user": true},
+		"block_user":  {"post": true, "user": true},
+		"dismiss":     {"post": true, "user": true},
+	}
+)
+
+type ReportService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewReportService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *ReportService {
+	return &ReportService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create reports a post the reporter can read or another user. Reporting the same target again
+// while the report is open updates the report.
+func (s *ReportService) Create(ctx context.Context, req *post_service.Report) (*post_service.Report, error) {
+	s.log.Info("---CreateReport--->>>", logger.Any("req", req))
+
+	if err := validateReport(req); err != nil {
+		return &post_service.Report{}, err
+	}
+
+	switch req.TargetType {
+	case "post":
+		post, err := visiblePost(ctx, s.strg, req.TargetId, req.ReporterId)
+		if err != nil {
+			return &post_service.Report{}, err
+		}
+		if post.OwnerId == req.ReporterId {
+			return &post_service.Report{}, status.Error(codes.InvalidArgument, "you can not report your own post")
+		}
+	case "user":
+		if req.TargetId == req.ReporterId {
+			return &post_service.Report{}, status.Error(codes.InvalidArgument, "you can not report yourself")
+		}
+		if err := s.userExists(ctx, req.TargetId); err != nil {
+			return &post_service.Report{}, err
+		}
+	}
+
+	resp, err := s.strg.Report().Create(ctx, req)
+	if err != nil {
+		s.log.Error("---CreateReport--->>>", logger.Error(err))
+		return &post_service.Report{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *ReportService) userExists(ctx context.Context, id string) error {
+	_, err := s.services.UserService().GetSingle(ctx, &user_service.UserSingleRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		s.log.Error("---GetReportedUser--->>>", logger.Error(err))
+	}
+	return err
+}
+
+// GetList returns one page of reports, newest first.
+func (s *ReportService) GetList(ctx context.Context, req *post_service.GetListReportRequest) (*post_service.ReportList, error) {
+	s.log.Info("---GetAllReports--->>>", logger.Any("req", req))
+
+	if req.TargetType != "" && !reportTargets[req.TargetType] {
+		return &post_service.ReportList{}, status.Errorf(codes.InvalidArgument, "invalid target_type %q", req.TargetType)
+	}
+	if req.Status != "" && !reportStatuses[req.Status] {
+		return &post_service.ReportList{}, status.Errorf(codes.InvalidArgument, "invalid status %q", req.Status)
+	}
+	if err := validateTargetID(req.TargetId, true); err != nil {
+		return &post_service.ReportList{}, err
+	}
+	req.Limit = listLimit(req.Limit)
+
+	resp, err := s.strg.Report().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.ReportList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetAllReports--->>>", logger.Error(err))
+		return &post_service.ReportList{}, err
+	}
+
+	return resp, nil
+}
+
+// GetQueue returns the targets with open reports, the longest waiting first. Reported posts are
+// embedded whatever their status and visibility, so moderators can review them.
+func (s *ReportService) GetQueue(ctx context.Context, req *post_service.GetModerationQueueRequest) (*post_service.ModerationQueue, error) {
+	s.log.Info("---GetModerationQueue--->>>", logger.Any("req", req))
+
+	if req.TargetType != "" && !reportTargets[req.TargetType] {
+		return &post_service.ModerationQueue{}, status.Errorf(codes.InvalidArgument, "invalid target_type %q", req.TargetType)
+	}
+	if req.Reason != "" && !reportReasons[req.Reason] {
+		return &post_service.ModerationQueue{}, status.Errorf(codes.InvalidArgument, "invalid reason %q", req.Reason)
+	}
+	req.Limit = listLimit(req.Limit)
+
+	resp, err := s.strg.Report().GetQueue(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.ModerationQueue{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetModerationQueue--->>>", logger.Error(err))
+		return &post_service.ModerationQueue{}, err
+	}
+
+	for _, item := range resp.Items {
+		if item.TargetType != "post" {
+			continue
+		}
+
+		// a deleted post is left out, its reports are still listed
+		post, err := s.strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: item.TargetId})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			s.log.Error("---GetModerationQueue--->>>", logger.Error(err))
+			return &post_service.ModerationQueue{}, err
+		}
+		item.Post = post
+	}
+
+	return resp, nil
+}
+
+type ModerationService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewModerationService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *ModerationService {
+	return &ModerationService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create applies the decision of a moderator to a reported target and closes its open reports.
+// user_id is set to the owner of a post target or to a user target.
+func (s *ModerationService) Create(ctx context.Context, req *post_service.ModerationAction) (*post_service.ModerationAction, error) {
+	s.log.Info("---CreateModerationAction--->>>", logger.Any("req", req))
+
+	if err := validateModerationAction(req); err != nil {
+		return &post_service.ModerationAction{}, err
+	}
+
+	switch req.TargetType {
+	case "post":
+		post, err := s.strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: req.TargetId})
+		if err != nil {
+			return &post_service.ModerationAction{}, notFound(err, "post not found")
+		}
+		if req.Action == "hide_post" && post.Status != poststatus.Published {
+			return &post_service.ModerationAction{}, status.Error(codes.FailedPrecondition, storage.ErrPostNotPublished.Error())
+		}
+		req.UserId = post.OwnerId
+	case "user":
+		req.UserId = req.TargetId
+	}
+
+	if req.Action == "block_user" {
+		if req.UserId == req.ModeratorId {
+			return &post_service.ModerationAction{}, status.Error(codes.InvalidArgument, "you can not block yourself")
+		}
+		if err := s.canBlock(ctx, req.ModeratorId, req.UserId); err != nil {
+			return &post_service.ModerationAction{}, err
+		}
+	}
+
+	resp, err := s.strg.Moderation().Create(ctx, req)
+	if errors.Is(err, storage.ErrPostNotPublished) {
+		return &post_service.ModerationAction{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.log.Error("---CreateModerationAction--->>>", logger.Error(err))
+		return &post_service.ModerationAction{}, err
+	}
+
+	if req.Action == "block_user" {
+		if err := s.block(ctx, resp); err != nil {
+			return &post_service.ModerationAction{}, err
+		}
+	}
+
+	return resp, nil
+}
+
+// block starts the job that blocks the user of a recorded block_user action, blocking also ends the
+// sessions of the user and user_service owns both. The action is recorded first so no user is
+// blocked without it, it is removed again when the job can not be started.
+func (s *ModerationService) block(ctx context.Context, action *post_service.ModerationAction) error {
+	job, err := s.services.BulkUserJobService().Create(ctx, &user_service.BulkUserActionRequest{
+		Action:      "block",
+		Filter:      &user_service.BulkUserFilter{Ids: []string{action.UserId}},
+		RequestedBy: action.ModeratorId,
+	})
+	if err != nil {
+		s.log.Error("---CreateModerationAction--->>>", logger.Error(err))
+		if err := s.strg.Moderation().Delete(ctx, action.Id); err != nil {
+			s.log.Error("---CreateModerationAction--->>>", logger.String("action_id", action.Id), logger.Error(err))
+		}
+		return err
+	}
+
+	// the job runs already, so the action stands even when its job id is not stored
+	action.JobId = job.Id
+	if err := s.strg.Moderation().SetJob(ctx, action.Id, job.Id); err != nil {
+		s.log.Error("---CreateModerationAction--->>>", logger.String("action_id", action.Id), logger.Error(err))
+	}
+
+	return nil
+}
+
+// canBlock returns PermissionDenied unless the user is a plain user or the moderator is an admin,
+// so moderators can not block each other or the admins.
+func (s *ModerationService) canBlock(ctx context.Context, moderatorID, userID string) error {
+	user, err := s.services.UserService().GetSingle(ctx, &user_service.UserSingleRequest{Id: userID})
+	if status.Code(err) == codes.NotFound {
+		return status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		s.log.Error("---CreateModerationAction--->>>", logger.Error(err))
+		return err
+	}
+	if user.UserRole == "user" {
+		return nil
+	}
+
+	moderator, err := s.services.UserService().GetSingle(ctx, &user_service.UserSingleRequest{Id: moderatorID})
+	if err != nil {
+		s.log.Error("---CreateModerationAction--->>>", logger.Error(err))
+		return err
+	}
+	if moderator.UserRole != "admin" {
+		return status.Errorf(codes.PermissionDenied, "only admins can block a %s", user.UserRole)
+	}
+
+	return nil
+}
+
+// GetList returns one page of moderation actions, newest first.
+func (s *ModerationService) GetList(ctx context.Context, req *post_service.GetListModerationActionRequest) (*post_service.ModerationActionList, error) {
+	s.log.Info("---GetAllModerationActions--->>>", logger.Any("req", req))
+
+	if req.TargetType != "" && !reportTargets[req.TargetType] {
+		return &post_service.ModerationActionList{}, status.Errorf(codes.InvalidArgument, "invalid target_type %q", req.TargetType)
+	}
+	if err := validateTargetID(req.TargetId, true); err != nil {
+		return &post_service.ModerationActionList{}, err
+	}
+	if req.UserId != "" {
+		if _, err := uuid.Parse(req.UserId); err != nil {
+			return &post_service.ModerationActionList{}, status.Errorf(codes.InvalidArgument, "invalid user_id %q", req.UserId)
+		}
+	}
+	req.Limit = listLimit(req.Limit)
+
+	resp, err := s.strg.Moderation().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.ModerationActionList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetAllModerationActions--->>>", logger.Error(err))
+		return &post_service.ModerationActionList{}, err
+	}
+
+	return resp, nil
+}
+
+func validateReport(req *post_service.Report) error {
+	if req.ReporterId == "" {
+		return status.Error(codes.InvalidArgument, "reporter_id is required")
+	}
+	if !reportTargets[req.TargetType] {
+		return status.Errorf(codes.InvalidArgument, "invalid target_type %q, expected post or user", req.TargetType)
+	}
+	if err := validateTargetID(req.TargetId, false); err != nil {
+		return err
+	}
+	if !reportReasons[req.Reason] {
+		return status.Errorf(codes.InvalidArgument, "invalid reason %q", req.Reason)
+	}
+	if utf8.RuneCountInString(req.Details) > maxReportDetails {
+		return status.Errorf(codes.InvalidArgument, "details are longer than %d characters", maxReportDetails)
+	}
+	return nil
+}
+
+func validateModerationAction(req *post_service.ModerationAction) error {
+	if req.ModeratorId == "" {
+		return status.Error(codes.InvalidArgument, "moderator_id is required")
+	}
+	if !reportTargets[req.TargetType] {
+		return status.Errorf(codes.InvalidArgument, "invalid target_type %q, expected post or user", req.TargetType)
+	}
+	if err := validateTargetID(req.TargetId, false); err != nil {
+		return err
+	}
+
+	targets, ok := moderationActions[req.Action]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown action %q, expected hide_post, delete_post, warn_user, block_user or dismiss", req.Action)
+	}
+	if !targets[req.TargetType] {
+		return status.Errorf(codes.InvalidArgument, "%s can not be applied to a %s", req.Action, req.TargetType)
+	}
+	if utf8.RuneCountInString(req.Note) > maxReportDetails {
+		return status.Errorf(codes.InvalidArgument, "note is longer than %d characters", maxReportDetails)
+	}
+	return nil
+}
+
+func validateTargetID(id string, optional bool) error {
+	if id == "" && optional {
+		return nil
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid target_id %q", id)
+	}
+	return nil
+}
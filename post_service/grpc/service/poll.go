@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/genproto/post_service"
+	"post_service/pkg/poll"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validatePoll checks the poll of a new post after it is scheduled, and stores its closing time in UTC.
+func validatePoll(post *post_service.Post) error {
+	if post.Poll == nil {
+		return nil
+	}
+
+	published := time.Now()
+	if post.PublishAt != "" {
+		published, _ = time.Parse(time.RFC3339, post.PublishAt)
+	}
+
+	closesAt, err := poll.Validate(post.Poll, published)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	post.Poll.ClosesAt = closesAt.Format(time.RFC3339)
+
+	return nil
+}
+
+// attachPolls sets the polls of the posts as the viewer sees them.
+func attachPolls(ctx context.Context, strg storage.StorageI, posts []*post_service.Post, viewerID string) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id)
+	}
+
+	polls, err := strg.Poll().GetByPostIDs(ctx, ids, viewerID)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		if p, ok := polls[post.Id]; ok {
+			poll.Redact(p)
+			post.Poll = p
+		}
+	}
+
+	return nil
+}
+
+// Vote records the choices of the user in the poll of a published post they can read and returns
+// the poll with its results.
+func (s *PostService) Vote(ctx context.Context, req *post_service.PollVoteRequest) (*post_service.Poll, error) {
+	s.log.Info("---VotePoll--->>>", logger.Any("req", req))
+
+	if req.UserId == "" {
+		return &post_service.Poll{}, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	post, err := visiblePost(ctx, s.strg, req.PostId, req.UserId)
+	if err != nil {
+		return &post_service.Poll{}, err
+	}
+	if post.Status != "published" {
+		return &post_service.Poll{}, status.Error(codes.FailedPrecondition, "only polls of published posts can be voted in")
+	}
+
+	current, err := s.poll(ctx, post.Id, req.UserId)
+	if err != nil {
+		return &post_service.Poll{}, err
+	}
+	if len(current.ViewerChoices) > 0 {
+		return &post_service.Poll{}, status.Error(codes.AlreadyExists, "you already voted in this poll")
+	}
+	if current.Closed {
+		return &post_service.Poll{}, status.Error(codes.FailedPrecondition, storage.ErrPollClosed.Error())
+	}
+
+	req.OptionIds, err = poll.ValidateVote(current, req.OptionIds)
+	if err != nil {
+		return &post_service.Poll{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.strg.Poll().Vote(ctx, req)
+	switch {
+	case errors.Is(err, storage.ErrPollClosed):
+		return &post_service.Poll{}, status.Error(codes.FailedPrecondition, err.Error())
+	case isUniqueViolation(err):
+		return &post_service.Poll{}, status.Error(codes.AlreadyExists, "you already voted in this poll")
+	case errors.Is(err, pgx.ErrNoRows):
+		return &post_service.Poll{}, status.Error(codes.InvalidArgument, "option is not in the poll")
+	case err != nil:
+		s.log.Error("---VotePoll--->>>", logger.Error(err))
+		return &post_service.Poll{}, err
+	}
+
+	return s.poll(ctx, post.Id, req.UserId)
+}
+
+func (s *PostService) poll(ctx context.Context, postID, viewerID string) (*post_service.Poll, error) {
+	polls, err := s.strg.Poll().GetByPostIDs(ctx, []string{postID}, viewerID)
+	if err != nil {
+		s.log.Error("---GetPoll--->>>", logger.Error(err))
+		return nil, err
+	}
+
+	p, ok := polls[postID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "the post has no poll")
+	}
+	poll.Redact(p)
+
+	return p, nil
+}
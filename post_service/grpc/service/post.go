@@ -2,76 +2,273 @@ package service
 
 import (
 	"context"
+	"errors"
 	"post_service/config"
 	"post_service/genproto/post_service"
+	"post_service/genproto/user_service"
 	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/pkg/poststatus"
+	"post_service/pkg/schedule"
+	"post_service/pkg/unfurl"
 	"post_service/storage"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	defaultListLimit = 10
+	maxListLimit     = 100
+
+	maxSearchQueryLength = 256
+
+	// maxRepostDepth is how many levels of reposted posts are embedded, e.g. the quoted post of a quote
+	// and the post quoted by that one.
+	maxRepostDepth = 2
+)
+
 type PostService struct {
 	cfg      config.Config
 	log      logger.LoggerI
 	strg     storage.StorageI
+	cache    storage.CacheI
 	services client.ServiceManagerI
+	unfurler *unfurl.Fetcher
 }
 
-func NewPostService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *PostService {
+func NewPostService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, cache storage.CacheI, srvs client.ServiceManagerI) *PostService {
 	return &PostService{
 		cfg:      cfg,
 		log:      log,
 		strg:     strg,
+		cache:    cache,
 		services: srvs,
+		unfurler: unfurl.NewFetcher(unfurl.Options{}),
 	}
 }
 
 func (s *PostService) Create(ctx context.Context, req *post_service.Post) (*post_service.Post, error) {
 	s.log.Info("---CreatePost--->>>", logger.Any("req", req))
 
+	if req.Status == "" {
+		req.Status = poststatus.Draft
+	}
+	// a new post starts out as a draft
+	if err := validateStatus(poststatus.Draft, req); err != nil {
+		return &post_service.Post{}, err
+	}
+	if err := validateVisibility(req); err != nil {
+		return &post_service.Post{}, err
+	}
+	if req.Visibility == "" {
+		req.Visibility = "public"
+	}
+
+	if err := s.schedule(ctx, req); err != nil {
+		return &post_service.Post{}, err
+	}
+	if err := validatePoll(req); err != nil {
+		return &post_service.Post{}, err
+	}
+
 	resp, err := s.strg.Post().Create(ctx, req)
 	if err != nil {
 		s.log.Error("---CreatePost--->>>", logger.Error(err))
 		return &post_service.Post{}, err
 	}
 
+	if err := attachPolls(ctx, s.strg, []*post_service.Post{resp}, resp.OwnerId); err != nil {
+		s.log.Error("---CreatePost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if err := saveMentions(ctx, s.log, s.strg, s.services, resp); err != nil {
+		s.log.Error("---CreatePost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if err := saveLinks(ctx, s.log, s.strg, s.unfurler, resp); err != nil {
+		s.log.Error("---CreatePost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if resp.Status == "published" {
+		go s.FanOut(resp)
+	}
+
 	return resp, nil
 }
 
 func (s *PostService) GetSingle(ctx context.Context, req *post_service.PostSingleRequest) (*post_service.Post, error) {
 	s.log.Info("---GetSinglePost--->>>", logger.Any("req", req))
 
-	resp, err := s.strg.Post().GetSingle(ctx, req)
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+
+	resp, err := s.strg.Post().GetVisible(ctx, req)
 	if err != nil {
+		s.log.Error("---GetSinglePost--->>>", logger.Error(err))
+		return &post_service.Post{}, notFound(err, "post not found")
+	}
+
+	if err := embedOriginals(ctx, s.strg, []*post_service.Post{resp}, req.ViewerId, maxRepostDepth); err != nil {
 		s.log.Error("---GetSinglePost--->>>", logger.Error(err))
 		return &post_service.Post{}, err
 	}
 
+	if err := attachPolls(ctx, s.strg, []*post_service.Post{resp}, req.ViewerId); err != nil {
+		s.log.Error("---GetSinglePost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if err := attachPreviews(ctx, s.strg, []*post_service.Post{resp}); err != nil {
+		s.log.Error("---GetSinglePost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if req.RecordView {
+		countViews(ctx, s.log, s.cache.View().AddViews, req.ViewerId, resp)
+	}
+
+	return resp, nil
+}
+
+// GetStats returns the view counts of a post to its owner, or to an admin.
+func (s *PostService) GetStats(ctx context.Context, req *post_service.PostStatsRequest) (*post_service.PostStats, error) {
+	s.log.Info("---GetPostStats--->>>", logger.Any("req", req))
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+
+	var (
+		post *post_service.Post
+		err  error
+	)
+
+	// admins see the stats of posts they can not read, like drafts and hidden posts
+	if req.Admin {
+		post, err = s.strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: req.PostId})
+		err = notFound(err, "post not found")
+	} else {
+		post, err = visiblePost(ctx, s.strg, req.PostId, req.ViewerId)
+	}
+	if err != nil {
+		return &post_service.PostStats{}, err
+	}
+	if post.OwnerId != req.ViewerId && !req.Admin {
+		return &post_service.PostStats{}, status.Error(codes.PermissionDenied, "only the owner can see the stats of a post")
+	}
+
+	resp, err := s.strg.PostStats().GetSingle(ctx, post.Id)
+	if err != nil {
+		s.log.Error("---GetPostStats--->>>", logger.Error(err))
+		return &post_service.PostStats{}, err
+	}
+
 	return resp, nil
 }
 
 func (s *PostService) GetList(ctx context.Context, req *post_service.GetListPostRequest) (*post_service.PostList, error) {
 	s.log.Info("---GetAllPosts--->>>", logger.Any("req", req))
 
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+	req.Limit = listLimit(req.Limit)
+
 	resp, err := s.strg.Post().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.PostList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
 	if err != nil {
 		s.log.Error("---GetAllPosts--->>>", logger.Error(err))
 		return &post_service.PostList{}, err
 	}
 
+	if err := embedOriginals(ctx, s.strg, resp.Items, req.ViewerId, maxRepostDepth); err != nil {
+		s.log.Error("---GetAllPosts--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	if err := attachPolls(ctx, s.strg, resp.Items, req.ViewerId); err != nil {
+		s.log.Error("---GetAllPosts--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	if err := attachPreviews(ctx, s.strg, resp.Items); err != nil {
+		s.log.Error("---GetAllPosts--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
 	return resp, nil
 }
 
 func (s *PostService) Update(ctx context.Context, req *post_service.Post) (*post_service.Post, error) {
 	s.log.Info("---UpdatePost--->>>", logger.Any("req", req))
 
+	if req.EditorId == "" {
+		req.EditorId = req.OwnerId
+	}
+	if err := validateVisibility(req); err != nil {
+		return &post_service.Post{}, err
+	}
+
+	current, err := s.strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: req.Id})
+	if err != nil {
+		return &post_service.Post{}, notFound(err, "post not found")
+	}
+	if current.OwnerId != req.OwnerId {
+		return &post_service.Post{}, status.Error(codes.PermissionDenied, "only the owner can edit the post")
+	}
+	if current.Type == "repost" {
+		return &post_service.Post{}, status.Error(codes.InvalidArgument, "a repost can not be edited")
+	}
+	if current.Status == poststatus.Hidden {
+		return &post_service.Post{}, status.Error(codes.FailedPrecondition, storage.ErrPostHidden.Error())
+	}
+
+	if req.Status == "" {
+		req.Status = current.Status
+	}
+	if err := validateStatus(current.Status, req); err != nil {
+		return &post_service.Post{}, err
+	}
+
+	if err := s.schedule(ctx, req); err != nil {
+		return &post_service.Post{}, err
+	}
+
 	resp, err := s.strg.Post().Update(ctx, req)
+	if errors.Is(err, storage.ErrPostHidden) {
+		return &post_service.Post{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, poststatus.ErrTransition) {
+		// the status changed after it was checked
+		return &post_service.Post{}, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
+		s.log.Error("---UpdatePost--->>>", logger.Error(err))
+		return &post_service.Post{}, notFound(err, "post not found")
+	}
+
+	if err := saveMentions(ctx, s.log, s.strg, s.services, resp); err != nil {
 		s.log.Error("---UpdatePost--->>>", logger.Error(err))
 		return &post_service.Post{}, err
 	}
 
+	if err := saveLinks(ctx, s.log, s.strg, s.unfurler, resp); err != nil {
+		s.log.Error("---UpdatePost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	// pushing a post that is already in the timelines changes nothing
+	if resp.Status == "published" {
+		go s.FanOut(resp)
+	}
+
 	return resp, nil
 }
 
@@ -87,6 +284,223 @@ func (s *PostService) Delete(ctx context.Context, req *post_service.PostSingleRe
 	return &emptypb.Empty{}, nil
 }
 
+// Repost reposts a published post, or quotes it when the request has content. Reposting a repost
+// reposts the post it refers to.
+func (s *PostService) Repost(ctx context.Context, req *post_service.RepostRequest) (*post_service.Post, error) {
+	s.log.Info("---RepostPost--->>>", logger.Any("req", req))
+
+	req.Content = strings.TrimSpace(req.Content)
+	if req.OwnerId == "" {
+		return &post_service.Post{}, status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+
+	original, err := s.publishedPost(ctx, req.PostId, req.OwnerId)
+	if err != nil {
+		return &post_service.Post{}, err
+	}
+	if original.Type == "repost" {
+		if original, err = s.publishedPost(ctx, original.RepostOf, req.OwnerId); err != nil {
+			return &post_service.Post{}, err
+		}
+		req.PostId = original.Id
+	}
+
+	resp, err := s.strg.Post().Repost(ctx, req)
+	if err != nil {
+		s.log.Error("---RepostPost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if resp.Type == "quote" {
+		if err := saveMentions(ctx, s.log, s.strg, s.services, resp); err != nil {
+			s.log.Error("---RepostPost--->>>", logger.Error(err))
+			return &post_service.Post{}, err
+		}
+
+		if err := saveLinks(ctx, s.log, s.strg, s.unfurler, resp); err != nil {
+			s.log.Error("---RepostPost--->>>", logger.Error(err))
+			return &post_service.Post{}, err
+		}
+	}
+
+	go s.FanOut(resp)
+
+	if err := embedOriginals(ctx, s.strg, []*post_service.Post{resp}, req.OwnerId, maxRepostDepth); err != nil {
+		s.log.Error("---RepostPost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	return resp, nil
+}
+
+// Unrepost deletes the repost of a post by the owner of the request.
+func (s *PostService) Unrepost(ctx context.Context, req *post_service.RepostRequest) (*emptypb.Empty, error) {
+	s.log.Info("---UnrepostPost--->>>", logger.Any("req", req))
+
+	_, err := s.strg.Post().DeleteRepost(ctx, req)
+	if err != nil {
+		s.log.Error("---UnrepostPost--->>>", logger.Error(err))
+		return &emptypb.Empty{}, notFound(err, "repost not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// publishedPost returns a published post the viewer can read, other posts are not found like deleted ones.
+func (s *PostService) publishedPost(ctx context.Context, id, viewerID string) (*post_service.Post, error) {
+	post, err := visiblePost(ctx, s.strg, id, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != "published" {
+		return nil, status.Error(codes.NotFound, "post not found")
+	}
+
+	return post, nil
+}
+
+// embedOriginals sets the original of the reposts and quotes among posts, and of their originals
+// until depth levels are embedded. Originals that were deleted or are not published are left out.
+func embedOriginals(ctx context.Context, strg storage.StorageI, posts []*post_service.Post, viewerID string, depth int) error {
+	if depth == 0 {
+		return nil
+	}
+
+	var (
+		ids  []string
+		seen = map[string]bool{}
+	)
+	for _, post := range posts {
+		if post.RepostOf != "" && !seen[post.RepostOf] {
+			seen[post.RepostOf] = true
+			ids = append(ids, post.RepostOf)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	originals, err := strg.Post().GetByIDs(ctx, ids, viewerID)
+	if err != nil {
+		return err
+	}
+
+	if err := embedOriginals(ctx, strg, originals, viewerID, depth-1); err != nil {
+		return err
+	}
+
+	byID := make(map[string]*post_service.Post, len(originals))
+	for _, original := range originals {
+		byID[original.Id] = original
+	}
+	for _, post := range posts {
+		post.Original = byID[post.RepostOf]
+	}
+
+	return nil
+}
+
+// Reschedule sets the publish time of a draft or scheduled post, the post becomes scheduled.
+func (s *PostService) Reschedule(ctx context.Context, req *post_service.ReschedulePostRequest) (*post_service.Post, error) {
+	s.log.Info("---ReschedulePost--->>>", logger.Any("req", req))
+
+	post, err := s.strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: req.Id})
+	if err != nil {
+		return &post_service.Post{}, notFound(err, "post not found")
+	}
+
+	if err := poststatus.Check(post.Status, poststatus.Scheduled); err != nil {
+		return &post_service.Post{}, status.Errorf(codes.FailedPrecondition, "a %s post can not be scheduled", post.Status)
+	}
+
+	req.PublishAt, err = s.publishAt(ctx, post.OwnerId, req.PublishAt)
+	if err != nil {
+		return &post_service.Post{}, err
+	}
+
+	resp, err := s.strg.Post().Reschedule(ctx, req)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the scheduler published it in the meantime
+		return &post_service.Post{}, status.Error(codes.FailedPrecondition, "the post is already published")
+	}
+	if err != nil {
+		s.log.Error("---ReschedulePost--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	return resp, nil
+}
+
+// CancelSchedule turns a scheduled post back into a draft.
+func (s *PostService) CancelSchedule(ctx context.Context, req *post_service.PostSingleRequest) (*post_service.Post, error) {
+	s.log.Info("---CancelPostSchedule--->>>", logger.Any("req", req))
+
+	post, err := s.strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: req.Id})
+	if err != nil {
+		return &post_service.Post{}, notFound(err, "post not found")
+	}
+
+	if post.Status != "scheduled" {
+		return &post_service.Post{}, status.Error(codes.FailedPrecondition, "the post is not scheduled")
+	}
+
+	resp, err := s.strg.Post().CancelSchedule(ctx, req)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &post_service.Post{}, status.Error(codes.FailedPrecondition, "the post is not scheduled")
+	}
+	if err != nil {
+		s.log.Error("---CancelPostSchedule--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	return resp, nil
+}
+
+// schedule checks the publish time of a scheduled post and converts it to UTC,
+// posts in other statuses have no publish time.
+func (s *PostService) schedule(ctx context.Context, req *post_service.Post) error {
+	if req.Status != "scheduled" {
+		req.PublishAt = ""
+		return nil
+	}
+
+	if req.PublishAt == "" {
+		return status.Error(codes.InvalidArgument, "publish_at is required for scheduled posts")
+	}
+
+	publishAt, err := s.publishAt(ctx, req.OwnerId, req.PublishAt)
+	if err != nil {
+		return err
+	}
+	req.PublishAt = publishAt
+
+	return nil
+}
+
+// publishAt reads a publish time in the timezone of the owner and returns it in UTC as RFC3339.
+func (s *PostService) publishAt(ctx context.Context, ownerID, value string) (string, error) {
+	var timezone string
+	if schedule.IsLocal(value) {
+		settings, err := s.services.UserSettingsService().GetSingle(ctx, &user_service.UserSettingsSingleRequest{UserId: ownerID})
+		if err != nil {
+			s.log.Error("---GetOwnerTimezone--->>>", logger.Error(err))
+			return "", err
+		}
+		timezone = settings.Timezone
+	}
+
+	t, err := schedule.Parse(value, timezone)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if !t.After(time.Now()) {
+		return "", status.Error(codes.InvalidArgument, "publish_at must be in the future")
+	}
+
+	return t.Format(time.RFC3339), nil
+}
+
 func (s *PostService) GetDefaultTags(ctx context.Context, req *post_service.GetDefaultTagsRequest) (*post_service.GetDefaultTagsResponse, error) {
 	s.log.Info("---GetDefaultTags--->>>", logger.Any("req", req))
 
@@ -98,3 +512,90 @@ func (s *PostService) GetDefaultTags(ctx context.Context, req *post_service.GetD
 
 	return resp, nil
 }
+
+// Search returns the posts matching the query and the filters, the best matches first.
+func (s *PostService) Search(ctx context.Context, req *post_service.SearchPostRequest) (*post_service.PostSearchResult, error) {
+	s.log.Info("---SearchPosts--->>>", logger.Any("req", req))
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+	if req.Status == "" {
+		req.Status = "published"
+	}
+	if err := validateSearch(req); err != nil {
+		return &post_service.PostSearchResult{}, err
+	}
+	req.Limit = listLimit(req.Limit)
+
+	resp, err := s.strg.Post().Search(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.PostSearchResult{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---SearchPosts--->>>", logger.Error(err))
+		return &post_service.PostSearchResult{}, err
+	}
+
+	posts := make([]*post_service.Post, 0, len(resp.Items))
+	for _, hit := range resp.Items {
+		posts = append(posts, hit.Post)
+	}
+	if err := embedOriginals(ctx, s.strg, posts, req.ViewerId, maxRepostDepth); err != nil {
+		s.log.Error("---SearchPosts--->>>", logger.Error(err))
+		return &post_service.PostSearchResult{}, err
+	}
+
+	return resp, nil
+}
+
+// validateStatus checks that a post in status current can be given the status of post,
+// hidden is only set by the moderators.
+func validateStatus(current string, post *post_service.Post) error {
+	if post.Status == poststatus.Hidden {
+		return status.Error(codes.InvalidArgument, "only moderators can hide a post")
+	}
+	if !poststatus.Valid(post.Status) {
+		return status.Errorf(codes.InvalidArgument, "invalid status %q", post.Status)
+	}
+	if err := poststatus.Check(current, post.Status); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func validateSearch(req *post_service.SearchPostRequest) error {
+	req.Query = strings.TrimSpace(req.Query)
+	if len(req.Query) > maxSearchQueryLength {
+		return status.Errorf(codes.InvalidArgument, "query is longer than %d characters", maxSearchQueryLength)
+	}
+
+	if !poststatus.Valid(req.Status) {
+		return status.Errorf(codes.InvalidArgument, "invalid status %q", req.Status)
+	}
+
+	if req.OwnerId != "" {
+		if _, err := uuid.Parse(req.OwnerId); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid owner_id %q", req.OwnerId)
+		}
+	}
+
+	for _, date := range []string{req.CreatedFrom, req.CreatedTo} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			if _, err := time.Parse(time.RFC3339, date); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid date %q, expected YYYY-MM-DD or RFC3339", date)
+			}
+		}
+	}
+
+	return nil
+}
+
+// listLimit applies the default and the maximum page size of the list RPCs.
+func listLimit(limit uint64) uint64 {
+	if limit == 0 {
+		return defaultListLimit
+	}
+	return min(limit, maxListLimit)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultReactionLimit = 20
+	maxReactionLimit     = 100
+)
+
+var (
+	reactionTypes = map[string]bool{
+		"like":  true,
+		"love":  true,
+		"haha":  true,
+		"wow":   true,
+		"sad":   true,
+		"angry": true,
+	}
+
+	reactionTargets = map[string]bool{
+		"post":    true,
+		"comment": true,
+	}
+)
+
+type ReactionService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewReactionService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *ReactionService {
+	return &ReactionService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+func (s *ReactionService) Upsert(ctx context.Context, req *post_service.Reaction) (*post_service.ReactionSummary, error) {
+	s.log.Info("---UpsertReaction--->>>", logger.Any("req", req))
+
+	if !reactionTargets[req.TargetType] {
+		return &post_service.ReactionSummary{}, status.Errorf(codes.InvalidArgument, "invalid target type %q, expected post or comment", req.TargetType)
+	}
+
+	if !reactionTypes[req.Type] {
+		return &post_service.ReactionSummary{}, status.Errorf(codes.InvalidArgument, "invalid reaction %q, expected like, love, haha, wow, sad or angry", req.Type)
+	}
+
+	if err := s.checkTarget(ctx, req.TargetType, req.TargetId, req.PostId, viewerOf(ctx, req.UserId)); err != nil {
+		return &post_service.ReactionSummary{}, err
+	}
+
+	resp, err := s.strg.Reaction().Upsert(ctx, req)
+	if err != nil {
+		s.log.Error("---UpsertReaction--->>>", logger.Error(err))
+		return &post_service.ReactionSummary{}, notFound(err, req.TargetType+" not found")
+	}
+
+	return resp, nil
+}
+
+func (s *ReactionService) Delete(ctx context.Context, req *post_service.ReactionSingleRequest) (*post_service.ReactionSummary, error) {
+	s.log.Info("---DeleteReaction--->>>", logger.Any("req", req))
+
+	if !reactionTargets[req.TargetType] {
+		return &post_service.ReactionSummary{}, status.Errorf(codes.InvalidArgument, "invalid target type %q, expected post or comment", req.TargetType)
+	}
+
+	if err := s.checkTarget(ctx, req.TargetType, req.TargetId, req.PostId, viewerOf(ctx, req.UserId)); err != nil {
+		return &post_service.ReactionSummary{}, err
+	}
+
+	resp, err := s.strg.Reaction().Delete(ctx, req)
+	if err != nil {
+		s.log.Error("---DeleteReaction--->>>", logger.Error(err))
+		return &post_service.ReactionSummary{}, notFound(err, req.TargetType+" not found")
+	}
+
+	return resp, nil
+}
+
+func (s *ReactionService) GetList(ctx context.Context, req *post_service.GetListReactionRequest) (*post_service.ReactionList, error) {
+	s.log.Info("---GetAllReactions--->>>", logger.Any("req", req))
+
+	if !reactionTargets[req.TargetType] {
+		return &post_service.ReactionList{}, status.Errorf(codes.InvalidArgument, "invalid target type %q, expected post or comment", req.TargetType)
+	}
+
+	if req.Type != "" && !reactionTypes[req.Type] {
+		return &post_service.ReactionList{}, status.Errorf(codes.InvalidArgument, "invalid reaction %q", req.Type)
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultReactionLimit
+	}
+	req.Limit = min(req.Limit, maxReactionLimit)
+
+	if err := s.checkTarget(ctx, req.TargetType, req.TargetId, req.PostId, viewerOf(ctx, "")); err != nil {
+		return &post_service.ReactionList{}, err
+	}
+
+	resp, err := s.strg.Reaction().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.ReactionList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetAllReactions--->>>", logger.Error(err))
+		return &post_service.ReactionList{}, err
+	}
+
+	return resp, nil
+}
+
+// checkTarget returns NotFound unless the viewer can read the post of the target. A comment target
+// has to belong to postID, its post decides whether it is visible.
+func (s *ReactionService) checkTarget(ctx context.Context, targetType, targetID, postID, viewerID string) error {
+	if targetType == "comment" {
+		comment, err := s.strg.Comment().GetSingle(ctx, &post_service.CommentSingleRequest{Id: targetID})
+		if err != nil {
+			return notFound(err, "comment not found")
+		}
+		if comment.PostId != postID {
+			return status.Error(codes.NotFound, "comment not found")
+		}
+		targetID = comment.PostId
+	}
+
+	_, err := visiblePost(ctx, s.strg, targetID, viewerID)
+	return err
+}
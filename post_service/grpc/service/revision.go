@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/grpc/client"
+	"post_service/pkg/textdiff"
+	"post_service/pkg/unfurl"
+	"post_service/storage"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PostRevisionService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+	unfurler *unfurl.Fetcher
+}
+
+func NewPostRevisionService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *PostRevisionService {
+	return &PostRevisionService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+		unfurler: unfurl.NewFetcher(unfurl.Options{}),
+	}
+}
+
+func (s *PostRevisionService) GetList(ctx context.Context, req *post_service.GetListPostRevisionRequest) (*post_service.PostRevisionList, error) {
+	s.log.Info("---GetAllPostRevisions--->>>", logger.Any("req", req))
+
+	if err := s.canReadHistory(ctx, req.PostId); err != nil {
+		return &post_service.PostRevisionList{}, err
+	}
+
+	resp, err := s.strg.PostRevision().GetList(ctx, req)
+	if err != nil {
+		s.log.Error("---GetAllPostRevisions--->>>", logger.Error(err))
+		return &post_service.PostRevisionList{}, err
+	}
+
+	return resp, nil
+}
+
+// Diff compares the content and the status of two versions of a post.
+func (s *PostRevisionService) Diff(ctx context.Context, req *post_service.DiffPostRevisionsRequest) (*post_service.PostRevisionDiff, error) {
+	s.log.Info("---DiffPostRevisions--->>>", logger.Any("req", req))
+
+	if req.From <= 0 || req.To < 0 {
+		return &post_service.PostRevisionDiff{}, status.Error(codes.InvalidArgument, "from must be a revision and to a revision or 0 for the current version")
+	}
+
+	if err := s.canReadHistory(ctx, req.PostId); err != nil {
+		return &post_service.PostRevisionDiff{}, err
+	}
+
+	from, err := s.version(ctx, req.PostId, req.From)
+	if err != nil {
+		return &post_service.PostRevisionDiff{}, err
+	}
+
+	to, err := s.version(ctx, req.PostId, req.To)
+	if err != nil {
+		return &post_service.PostRevisionDiff{}, err
+	}
+
+	resp := &post_service.PostRevisionDiff{
+		From:       req.From,
+		To:         req.To,
+		StatusFrom: from.Status,
+		StatusTo:   to.Status,
+	}
+	for _, c := range textdiff.Diff(from.Content, to.Content) {
+		resp.Chunks = append(resp.Chunks, &post_service.DiffChunk{Op: string(c.Op), Text: c.Text})
+	}
+
+	return resp, nil
+}
+
+// canReadHistory checks that the viewer may read the earlier versions of a post. They can hold content
+// the author removed, so only the author and moderators can read them.
+func (s *PostRevisionService) canReadHistory(ctx context.Context, postID string) error {
+	viewerID := viewerOf(ctx, "")
+
+	post, err := visiblePost(ctx, s.strg, postID, viewerID)
+	if err != nil {
+		return err
+	}
+
+	if viewerID == "" || (post.OwnerId != viewerID && !isModerator(ctx)) {
+		return status.Error(codes.PermissionDenied, "only the author and moderators can read the history of a post")
+	}
+
+	return nil
+}
+
+// version returns a revision of a post, or its current version for revision 0.
+func (s *PostRevisionService) version(ctx context.Context, postID string, revision int32) (*post_service.PostRevision, error) {
+	if revision == 0 {
+		post, err := s.strg.Post().GetSingle(ctx, &post_service.PostSingleRequest{Id: postID})
+		if err != nil {
+			return nil, notFound(err, "post not found")
+		}
+		return &post_service.PostRevision{PostId: post.Id, Content: post.Content, Status: post.Status}, nil
+	}
+
+	rev, err := s.strg.PostRevision().GetSingle(ctx, &post_service.PostRevisionSingleRequest{PostId: postID, Revision: revision})
+	if err != nil {
+		s.log.Error("---GetPostRevision--->>>", logger.Error(err))
+		return nil, notFound(err, "revision not found")
+	}
+
+	return rev, nil
+}
+
+// Restore makes the content of a revision the current content of the post, the current
+// content is kept as a new revision.
+func (s *PostRevisionService) Restore(ctx context.Context, req *post_service.RestorePostRevisionRequest) (*post_service.Post, error) {
+	s.log.Info("---RestorePostRevision--->>>", logger.Any("req", req))
+
+	resp, err := s.strg.PostRevision().Restore(ctx, req)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &post_service.Post{}, status.Error(codes.NotFound, "revision not found")
+	}
+	if errors.Is(err, storage.ErrPostHidden) {
+		return &post_service.Post{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.log.Error("---RestorePostRevision--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if err := saveMentions(ctx, s.log, s.strg, s.services, resp); err != nil {
+		s.log.Error("---RestorePostRevision--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	if err := saveLinks(ctx, s.log, s.strg, s.unfurler, resp); err != nil {
+		s.log.Error("---RestorePostRevision--->>>", logger.Error(err))
+		return &post_service.Post{}, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/grpc/client"
+	"post_service/pkg/cursor"
+	"post_service/pkg/hashtag"
+	"post_service/storage"
+	"post_service/worker"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tagSources are the values of the tag_source enum.
+var tagSources = map[string]bool{
+	"ai":      true,
+	"hashtag": true,
+	"manual":  true,
+}
+
+const (
+	defaultTrendingWindow = "24h"
+	defaultTrendingLimit  = 10
+)
+
+type TagService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	cache    storage.CacheI
+	services client.ServiceManagerI
+}
+
+func NewTagService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, cache storage.CacheI, srvs client.ServiceManagerI) *TagService {
+	return &TagService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		cache:    cache,
+		services: srvs,
+	}
+}
+
+// GetSingle returns the tag with the number of published posts that have it.
+func (s *TagService) GetSingle(ctx context.Context, req *post_service.TagSingleRequest) (*post_service.Tag, error) {
+	s.log.Info("---GetSingleTag--->>>", logger.Any("req", req))
+
+	if req.Tag = hashtag.Normalize(req.Tag); req.Tag == "" {
+		return &post_service.Tag{}, status.Error(codes.InvalidArgument, "invalid tag")
+	}
+
+	resp, err := s.strg.Tag().GetSingle(ctx, req)
+	if err != nil {
+		s.log.Error("---GetSingleTag--->>>", logger.Error(err))
+		return &post_service.Tag{}, err
+	}
+
+	return resp, nil
+}
+
+// GetPosts returns one page of published posts with the tag, newest first.
+func (s *TagService) GetPosts(ctx context.Context, req *post_service.GetTagPostsRequest) (*post_service.PostList, error) {
+	s.log.Info("---GetTagPosts--->>>", logger.Any("req", req))
+
+	req.ViewerId = viewerOf(ctx, req.ViewerId)
+	if req.Tag = hashtag.Normalize(req.Tag); req.Tag == "" {
+		return &post_service.PostList{}, status.Error(codes.InvalidArgument, "invalid tag")
+	}
+	if req.Source != "" && !tagSources[req.Source] {
+		return &post_service.PostList{}, status.Errorf(codes.InvalidArgument, "invalid source %q", req.Source)
+	}
+	req.Limit = listLimit(req.Limit)
+
+	resp, err := s.strg.Tag().GetPosts(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &post_service.PostList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetTagPosts--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	if err := embedOriginals(ctx, s.strg, resp.Items, req.ViewerId, maxRepostDepth); err != nil {
+		s.log.Error("---GetTagPosts--->>>", logger.Error(err))
+		return &post_service.PostList{}, err
+	}
+
+	return resp, nil
+}
+
+// GetTrending returns the trending tags of a window, computed by the trending worker.
+// Tags blocked since the last refresh are left out.
+func (s *TagService) GetTrending(ctx context.Context, req *post_service.GetTrendingRequest) (*post_service.TrendingTagList, error) {
+	s.log.Info("---GetTrendingTags--->>>", logger.Any("req", req))
+
+	if req.Window == "" {
+		req.Window = defaultTrendingWindow
+	}
+	if _, ok := worker.TrendingWindows[req.Window]; !ok {
+		return &post_service.TrendingTagList{}, status.Errorf(codes.InvalidArgument, "invalid window %q, expected 1h, 24h or 7d", req.Window)
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultTrendingLimit
+	}
+	limit := int(min(req.Limit, worker.TrendingSize))
+
+	tags, err := s.cache.Trending().Get(ctx, req.Window, req.Category, worker.TrendingSize)
+	if err != nil {
+		s.log.Error("---GetTrendingTags--->>>", logger.Error(err))
+		return &post_service.TrendingTagList{}, err
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Tag)
+	}
+
+	blocked, err := s.strg.BlockedTag().Blocked(ctx, names)
+	if err != nil {
+		s.log.Error("---GetTrendingTags--->>>", logger.Error(err))
+		return &post_service.TrendingTagList{}, err
+	}
+
+	resp := &post_service.TrendingTagList{}
+	for _, tag := range tags {
+		if len(resp.Items) == limit {
+			break
+		}
+		if blocked[tag.Tag] {
+			continue
+		}
+		resp.Items = append(resp.Items, &post_service.TrendingTag{Tag: tag.Tag, Score: tag.Score})
+	}
+
+	return resp, nil
+}
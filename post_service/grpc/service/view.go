@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"post_service/genproto/post_service"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+// countViews counts the posts as seen by the viewer with add, e.g. storage.ViewRepoI.AddViews.
+// A repost is counted as its original, which is what the viewer sees, and the viewer's own posts are
+// not counted. Counting is best effort, a failure is logged and does not fail the request.
+func countViews(ctx context.Context, log logger.LoggerI, add func(ctx context.Context, viewerID string, postIDs ...string) error, viewerID string, posts ...*post_service.Post) {
+	if viewerID == "" {
+		return
+	}
+
+	var ids []string
+	for _, post := range posts {
+		id, ownerID := post.Id, post.OwnerId
+		if post.Type == "repost" && post.Original != nil {
+			id, ownerID = post.Original.Id, post.Original.OwnerId
+		}
+		if ownerID != viewerID {
+			ids = append(ids, id)
+		}
+	}
+
+	if err := add(context.WithoutCancel(ctx), viewerID, ids...); err != nil {
+		log.Error("---CountViews--->>>", logger.Error(err))
+	}
+}
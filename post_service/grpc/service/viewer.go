@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"post_service/genproto/post_service"
+	"post_service/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// visibilities are the values of Post.visibility.
+var visibilities = map[string]bool{
+	"public":    true,
+	"followers": true,
+	"private":   true,
+}
+
+// viewerOf returns the id of the user a request is made for. The gateway sends the authenticated user
+// as user_id metadata, fallback is used by calls without it, like the ones between services.
+func viewerOf(ctx context.Context, fallback string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get("user_id"); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return fallback
+}
+
+// isModerator reports whether the request is made by a moderator. The gateway sends the role of the
+// authenticated user as user_role metadata, admins inherit the moderator role.
+func isModerator(ctx context.Context) bool {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if roles := md.Get("user_role"); len(roles) > 0 {
+			return roles[0] == "moderator" || roles[0] == "admin"
+		}
+	}
+	return false
+}
+
+// visiblePost returns a post the viewer can read. Posts hidden from the viewer are not found,
+// like deleted ones, so their existence is not revealed.
+func visiblePost(ctx context.Context, strg storage.StorageI, id, viewerID string) (*post_service.Post, error) {
+	if id == "" {
+		return nil, status.Error(codes.NotFound, "post not found")
+	}
+
+	post, err := strg.Post().GetVisible(ctx, &post_service.PostSingleRequest{Id: id, ViewerId: viewerID})
+	if err != nil {
+		return nil, notFound(err, "post not found")
+	}
+
+	return post, nil
+}
+
+// validateVisibility checks the visibility of a post, an empty one is public for new posts and
+// unchanged for updates.
+func validateVisibility(post *post_service.Post) error {
+	if post.Visibility != "" && !visibilities[post.Visibility] {
+		return status.Errorf(codes.InvalidArgument, "invalid visibility %q", post.Visibility)
+	}
+	return nil
+}
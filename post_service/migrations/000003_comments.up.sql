@@ -0,0 +1,24 @@
+CREATE TYPE comment_status AS ENUM (
+    'active',
+    'deleted',
+    'removed'
+);
+
+CREATE TABLE IF NOT EXISTS comments (
+    id uuid PRIMARY KEY,
+    post_id uuid NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    parent_id uuid REFERENCES comments(id) ON DELETE CASCADE,
+    author_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content text NOT NULL,
+    depth smallint NOT NULL DEFAULT 0,
+    status comment_status NOT NULL DEFAULT 'active',
+    reply_count integer NOT NULL DEFAULT 0,
+    removed_by uuid REFERENCES users(id) ON DELETE SET NULL,
+    edited_at timestamp,
+    created_at timestamp NOT NULL DEFAULT 'now()',
+    updated_at timestamp NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX IF NOT EXISTS comments_thread_idx ON comments (post_id, parent_id, created_at, id);
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS comment_count integer NOT NULL DEFAULT 0;
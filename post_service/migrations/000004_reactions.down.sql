@@ -0,0 +1,5 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS reaction_counts;
+ALTER TABLE posts DROP COLUMN IF EXISTS reaction_counts;
+DROP TABLE IF EXISTS reactions;
+DROP TYPE IF EXISTS reaction_target;
+DROP TYPE IF EXISTS reaction_type;
@@ -0,0 +1,30 @@
+CREATE TYPE reaction_type AS ENUM (
+    'like',
+    'love',
+    'haha',
+    'wow',
+    'sad',
+    'angry'
+);
+
+CREATE TYPE reaction_target AS ENUM (
+    'post',
+    'comment'
+);
+
+-- one reaction per user per target, the primary key makes repeated reactions idempotent
+CREATE TABLE IF NOT EXISTS reactions (
+    target_type reaction_target NOT NULL,
+    target_id uuid NOT NULL,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type reaction_type NOT NULL,
+    created_at timestamp NOT NULL DEFAULT 'now()',
+    updated_at timestamp NOT NULL DEFAULT 'now()',
+    PRIMARY KEY (target_type, target_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS reactions_target_idx ON reactions (target_type, target_id, created_at DESC, user_id DESC);
+
+-- counters per reaction type, kept in sync by the reaction repo
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reaction_counts jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS reaction_counts jsonb NOT NULL DEFAULT '{}';
@@ -0,0 +1,7 @@
+-- tags weigh more than the content when search results are ranked
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(json_to_tsvector('english', coalesce(tags, '[]'::json), '["string"]'), 'A') ||
+    setweight(to_tsvector('english', content), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS posts_search_vector_idx ON posts USING GIN (search_vector);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS post_tags;
+DROP TYPE IF EXISTS tag_source;
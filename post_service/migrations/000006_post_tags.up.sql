@@ -0,0 +1,34 @@
+CREATE TYPE tag_source AS ENUM (
+    'ai',
+    'hashtag',
+    'manual'
+);
+
+-- tags are stored normalized: lower case without the leading '#'
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id uuid NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    tag varchar(100) NOT NULL,
+    source tag_source NOT NULL,
+    created_at timestamp NOT NULL DEFAULT 'now()',
+    PRIMARY KEY (post_id, tag, source)
+);
+
+CREATE INDEX IF NOT EXISTS post_tags_tag_idx ON post_tags (tag, post_id);
+
+-- posts.tags holds {"<key>": {"values": [...]}}, CreatePost and older versions of UpdatePost used different keys
+INSERT INTO post_tags (post_id, tag, source, created_at)
+SELECT DISTINCT p.id, t.tag, k.source::tag_source, p.created_at
+FROM posts p
+CROSS JOIN (VALUES
+    ('gemini_tags', 'ai'),
+    ('geminiTags', 'ai'),
+    ('content_hashtags', 'hashtag'),
+    ('getTagBody', 'hashtag'),
+    ('manual_tags', 'manual')
+) AS k(key, source)
+CROSS JOIN LATERAL json_array_elements_text(
+    CASE WHEN json_typeof(p.tags -> k.key -> 'values') = 'array' THEN p.tags -> k.key -> 'values' END
+) AS v(value)
+CROSS JOIN LATERAL (SELECT lower(ltrim(btrim(v.value), '#')) AS tag) t
+WHERE t.tag <> '' AND length(t.tag) <= 100 AND t.tag !~ '\s'
+ON CONFLICT DO NOTHING;
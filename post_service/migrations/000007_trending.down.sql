@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS reactions_created_at_idx;
+DROP INDEX IF EXISTS comments_created_at_idx;
+DROP INDEX IF EXISTS posts_created_at_idx;
+DROP TABLE IF EXISTS blocked_tags;
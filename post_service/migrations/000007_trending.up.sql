@@ -0,0 +1,12 @@
+-- tags that are never shown as trending, e.g. spam or abusive tags
+CREATE TABLE IF NOT EXISTS blocked_tags (
+    tag varchar(100) PRIMARY KEY,
+    reason text NOT NULL DEFAULT '',
+    blocked_by uuid REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp NOT NULL DEFAULT now()
+);
+
+-- the trending job scans the events of the last 7 days
+CREATE INDEX IF NOT EXISTS posts_created_at_idx ON posts (created_at);
+CREATE INDEX IF NOT EXISTS comments_created_at_idx ON comments (created_at);
+CREATE INDEX IF NOT EXISTS reactions_created_at_idx ON reactions (created_at) WHERE target_type = 'post';
@@ -0,0 +1,20 @@
+DROP INDEX IF EXISTS posts_publish_at_idx;
+
+UPDATE posts SET status = 'draft' WHERE status = 'scheduled';
+
+ALTER TABLE posts DROP COLUMN IF EXISTS publish_at;
+
+-- enum values can not be dropped, the type is recreated without scheduled
+ALTER TYPE post_status RENAME TO post_status_old;
+
+CREATE TYPE post_status AS ENUM (
+    'draft',
+    'published'
+);
+
+ALTER TABLE posts
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE post_status USING status::text::post_status,
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+DROP TYPE post_status_old;
@@ -0,0 +1,7 @@
+ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'scheduled';
+
+-- publish_at is only set while a post is scheduled, in UTC
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS publish_at timestamp;
+
+-- the scheduler looks up the posts that are due, the new enum value can not be used in the same transaction
+CREATE INDEX IF NOT EXISTS posts_publish_at_idx ON posts (publish_at) WHERE publish_at IS NOT NULL;
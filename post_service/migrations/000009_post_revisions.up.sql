@@ -0,0 +1,16 @@
+-- the versions a post had before it was changed, the current version stays in posts
+CREATE TABLE IF NOT EXISTS post_revisions (
+    post_id uuid NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    revision integer NOT NULL,
+    content text NOT NULL,
+    status post_status NOT NULL,
+    tags json,
+    editor_id uuid REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp NOT NULL,
+    PRIMARY KEY (post_id, revision)
+);
+
+-- edited_at is only set when the content changes, edited_by is the author of the current version
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS edited_at timestamp,
+    ADD COLUMN IF NOT EXISTS edited_by uuid REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS posts_repost_once_idx;
+DROP INDEX IF EXISTS posts_repost_of_idx;
+
+DELETE FROM posts WHERE type = 'repost';
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS repost_count,
+    DROP COLUMN IF EXISTS repost_of,
+    DROP COLUMN IF EXISTS type;
+
+DROP TYPE IF EXISTS post_type;
@@ -0,0 +1,17 @@
+CREATE TYPE post_type AS ENUM (
+    'post',
+    'repost',
+    'quote'
+);
+
+-- a repost has no content of its own, a quote adds a comment to the reposted post
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS type post_type NOT NULL DEFAULT 'post',
+    ADD COLUMN IF NOT EXISTS repost_of uuid REFERENCES posts(id) ON DELETE SET NULL,
+    -- published reposts and quotes of the post
+    ADD COLUMN IF NOT EXISTS repost_count bigint NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS posts_repost_of_idx ON posts (repost_of) WHERE repost_of IS NOT NULL;
+
+-- a user reposts a post at most once, quoting it is not limited
+CREATE UNIQUE INDEX IF NOT EXISTS posts_repost_once_idx ON posts (owner_id, repost_of) WHERE type = 'repost';
@@ -0,0 +1,11 @@
+-- the users mentioned in a post, start and length are in characters and include the '@'
+CREATE TABLE IF NOT EXISTS post_mentions (
+    post_id uuid NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username varchar(50) NOT NULL,
+    start integer NOT NULL,
+    length integer NOT NULL,
+    PRIMARY KEY (post_id, start)
+);
+
+CREATE INDEX IF NOT EXISTS post_mentions_user_id_idx ON post_mentions (user_id, post_id);
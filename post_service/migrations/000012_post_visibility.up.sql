@@ -0,0 +1,8 @@
+CREATE TYPE post_visibility AS ENUM (
+    'public',
+    'followers',
+    'private'
+);
+
+-- who besides the owner can read a published post
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS visibility post_visibility NOT NULL DEFAULT 'public';
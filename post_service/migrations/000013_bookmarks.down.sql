@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bookmark_collection_posts;
+DROP TABLE IF EXISTS bookmark_collections;
+DROP TABLE IF EXISTS bookmarks;
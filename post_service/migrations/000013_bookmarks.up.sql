@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id uuid NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS bookmarks_user_created_idx ON bookmarks (user_id, created_at DESC, post_id DESC);
+
+CREATE TABLE IF NOT EXISTS bookmark_collections (
+    id uuid PRIMARY KEY,
+    owner_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name varchar(100) NOT NULL,
+    shared boolean NOT NULL DEFAULT false,
+    position integer NOT NULL DEFAULT 0,
+    created_at timestamp NOT NULL DEFAULT now(),
+    updated_at timestamp NOT NULL DEFAULT now()
+);
+
+-- names are unique per owner regardless of case, creating a collection twice returns the first one
+CREATE UNIQUE INDEX IF NOT EXISTS bookmark_collections_name_idx ON bookmark_collections (owner_id, lower(name));
+
+-- a post in a collection is also bookmarked, the entries go away with the bookmark, the collection or the post
+CREATE TABLE IF NOT EXISTS bookmark_collection_posts (
+    collection_id uuid NOT NULL REFERENCES bookmark_collections(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL,
+    post_id uuid NOT NULL,
+    created_at timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, post_id),
+    FOREIGN KEY (user_id, post_id) REFERENCES bookmarks (user_id, post_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS bookmark_collection_posts_created_idx ON bookmark_collection_posts (collection_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS bookmark_collection_posts_bookmark_idx ON bookmark_collection_posts (user_id, post_id);
@@ -0,0 +1,29 @@
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS moderation_actions;
+
+DROP TYPE IF EXISTS moderation_action_type;
+DROP TYPE IF EXISTS report_status;
+DROP TYPE IF EXISTS report_reason;
+DROP TYPE IF EXISTS report_target;
+
+UPDATE posts SET status = 'draft' WHERE status = 'hidden';
+-- revisions record what the post looked like, a hidden post was published before it was hidden
+UPDATE post_revisions SET status = 'published' WHERE status = 'hidden';
+
+-- enum values can not be dropped, the type is recreated without hidden
+ALTER TYPE post_status RENAME TO post_status_old;
+
+CREATE TYPE post_status AS ENUM (
+    'draft',
+    'published',
+    'scheduled'
+);
+
+ALTER TABLE posts
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE post_status USING status::text::post_status,
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+ALTER TABLE post_revisions ALTER COLUMN status TYPE post_status USING status::text::post_status;
+
+DROP TYPE post_status_old;
@@ -0,0 +1,68 @@
+-- hidden posts were taken down by a moderator, only their owner still sees them
+ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'hidden';
+
+CREATE TYPE report_target AS ENUM (
+    'post',
+    'user'
+);
+
+CREATE TYPE report_reason AS ENUM (
+    'spam',
+    'harassment',
+    'hate',
+    'violence',
+    'sexual',
+    'misinformation',
+    'impersonation',
+    'other'
+);
+
+CREATE TYPE report_status AS ENUM (
+    'open',
+    'resolved',
+    'dismissed'
+);
+
+CREATE TYPE moderation_action_type AS ENUM (
+    'hide_post',
+    'delete_post',
+    'warn_user',
+    'block_user',
+    'dismiss'
+);
+
+-- the decisions of the moderators, rows are never changed or deleted so they serve as the audit trail
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id uuid PRIMARY KEY,
+    target_type report_target NOT NULL,
+    target_id uuid NOT NULL,
+    action moderation_action_type NOT NULL,
+    user_id uuid REFERENCES users(id) ON DELETE SET NULL,
+    moderator_id uuid REFERENCES users(id) ON DELETE SET NULL,
+    note text NOT NULL DEFAULT '',
+    report_count integer NOT NULL DEFAULT 0,
+    created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS moderation_actions_target_idx ON moderation_actions (target_type, target_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS moderation_actions_user_idx ON moderation_actions (user_id, created_at DESC, id DESC);
+
+-- target_id has no foreign key, reports outlive deleted posts
+CREATE TABLE IF NOT EXISTS reports (
+    id uuid PRIMARY KEY,
+    target_type report_target NOT NULL,
+    target_id uuid NOT NULL,
+    reporter_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason report_reason NOT NULL,
+    details text NOT NULL DEFAULT '',
+    status report_status NOT NULL DEFAULT 'open',
+    action_id uuid REFERENCES moderation_actions(id) ON DELETE SET NULL,
+    created_at timestamp NOT NULL DEFAULT now(),
+    updated_at timestamp NOT NULL DEFAULT now(),
+    resolved_at timestamp
+);
+
+-- a reporter has one open report per target
+CREATE UNIQUE INDEX IF NOT EXISTS reports_open_once_idx ON reports (reporter_id, target_type, target_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS reports_open_target_idx ON reports (target_type, target_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS reports_created_at_idx ON reports (created_at DESC, id DESC);
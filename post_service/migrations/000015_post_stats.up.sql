@@ -0,0 +1,9 @@
+-- view counts of posts, flushed from the counters in Redis
+CREATE TABLE IF NOT EXISTS post_stats (
+    post_id uuid PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    view_count bigint NOT NULL DEFAULT 0,
+    impression_count bigint NOT NULL DEFAULT 0,
+    -- a HyperLogLog estimate, it is only replaced by a larger one
+    unique_viewers bigint NOT NULL DEFAULT 0,
+    updated_at timestamp NOT NULL DEFAULT now()
+);
@@ -0,0 +1,32 @@
+-- a post has at most one poll, the counters are changed in the same transaction as the votes
+CREATE TABLE IF NOT EXISTS polls (
+    post_id uuid PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    multiple boolean NOT NULL DEFAULT false,
+    closes_at timestamp NOT NULL,
+    voter_count bigint NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id uuid PRIMARY KEY,
+    post_id uuid NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    position integer NOT NULL,
+    text text NOT NULL,
+    vote_count bigint NOT NULL DEFAULT 0,
+    UNIQUE (post_id, position)
+);
+
+-- one row per voter makes a second vote of the same user fail
+CREATE TABLE IF NOT EXISTS poll_voters (
+    post_id uuid NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    post_id uuid NOT NULL,
+    option_id uuid NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL,
+    PRIMARY KEY (option_id, user_id),
+    FOREIGN KEY (post_id, user_id) REFERENCES poll_voters(post_id, user_id) ON DELETE CASCADE
+);
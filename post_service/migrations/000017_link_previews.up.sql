@@ -0,0 +1,23 @@
+CREATE TYPE link_preview_status AS ENUM (
+    'ok',
+    'failed'
+);
+
+-- previews are cached per URL and shared by every post that links to it
+CREATE TABLE IF NOT EXISTS link_previews (
+    url text PRIMARY KEY,
+    status link_preview_status NOT NULL,
+    title text NOT NULL DEFAULT '',
+    description text NOT NULL DEFAULT '',
+    image_url text NOT NULL DEFAULT '',
+    site_name text NOT NULL DEFAULT '',
+    fetched_at timestamp NOT NULL DEFAULT now()
+);
+
+-- the URLs in the content of a post, in order
+CREATE TABLE IF NOT EXISTS post_links (
+    post_id uuid NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    position integer NOT NULL,
+    PRIMARY KEY (post_id, url)
+);
@@ -0,0 +1,25 @@
+UPDATE posts SET status = 'published' WHERE status = 'archived';
+UPDATE post_revisions SET status = 'published' WHERE status = 'archived';
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS archived_at;
+
+-- enum values can not be dropped, the type is recreated without archived
+ALTER TYPE post_status RENAME TO post_status_old;
+
+CREATE TYPE post_status AS ENUM (
+    'draft',
+    'published',
+    'scheduled',
+    'hidden'
+);
+
+ALTER TABLE posts
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE post_status USING status::text::post_status,
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+ALTER TABLE post_revisions ALTER COLUMN status TYPE post_status USING status::text::post_status;
+
+DROP TYPE post_status_old;
@@ -0,0 +1,10 @@
+-- archived posts were taken down by their owner, only their owner still sees them
+ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'archived';
+
+-- published_at is when the post was first published, archived_at when it was archived, in UTC
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS published_at timestamp,
+    ADD COLUMN IF NOT EXISTS archived_at timestamp;
+
+-- posts published before are dated at their creation, the new enum value can not be used in the same transaction
+UPDATE posts SET published_at = created_at WHERE status = 'published';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS post_attachment_object_key_idx;
+
+ALTER TABLE post_attachment DROP COLUMN IF EXISTS object_key;
+
+DROP TABLE IF EXISTS media;
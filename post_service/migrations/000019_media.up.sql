@@ -0,0 +1,16 @@
+-- the files uploaded through the gateway, key is the object key in the blob store
+CREATE TABLE IF NOT EXISTS media (
+    key varchar PRIMARY KEY,
+    owner_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mime_type varchar NOT NULL,
+    type attachment_type NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS media_owner_id_idx ON media (owner_id);
+
+-- attachments added before uploads keep their client supplied filepath and have no object key
+ALTER TABLE post_attachment ADD COLUMN IF NOT EXISTS object_key varchar REFERENCES media(key) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS post_attachment_object_key_idx ON post_attachment (object_key);
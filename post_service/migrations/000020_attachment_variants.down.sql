@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS attachment_variants;
+
+DROP INDEX IF EXISTS post_attachment_unprocessed_idx;
+
+ALTER TABLE post_attachment
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS blurhash,
+    DROP COLUMN IF EXISTS processing_started_at,
+    DROP COLUMN IF EXISTS processed_at,
+    DROP COLUMN IF EXISTS processing_error;
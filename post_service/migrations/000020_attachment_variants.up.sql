@@ -0,0 +1,25 @@
+-- set by the image processing worker, processed_at stays NULL until the attachment is processed
+ALTER TABLE post_attachment
+    ADD COLUMN IF NOT EXISTS width int,
+    ADD COLUMN IF NOT EXISTS height int,
+    ADD COLUMN IF NOT EXISTS blurhash varchar,
+    ADD COLUMN IF NOT EXISTS processing_started_at timestamp,
+    ADD COLUMN IF NOT EXISTS processed_at timestamp,
+    ADD COLUMN IF NOT EXISTS processing_error varchar;
+
+CREATE INDEX IF NOT EXISTS post_attachment_unprocessed_idx ON post_attachment (created_at)
+    WHERE processed_at IS NULL AND object_key IS NOT NULL AND content_type = 'photo';
+
+-- the resized and re-encoded versions of a photo attachment
+CREATE TABLE IF NOT EXISTS attachment_variants (
+    id uuid PRIMARY KEY,
+    attachment_id uuid NOT NULL REFERENCES post_attachment(id) ON DELETE CASCADE,
+    object_key varchar NOT NULL,
+    mime_type varchar NOT NULL,
+    width int NOT NULL,
+    height int NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS attachment_variants_attachment_id_idx ON attachment_variants (attachment_id);
@@ -0,0 +1,40 @@
+-- a shared upload goes back to its first uploader
+ALTER TABLE media ADD COLUMN IF NOT EXISTS owner_id uuid REFERENCES users(id) ON DELETE CASCADE;
+
+UPDATE media m SET owner_id = (
+    SELECT mu.owner_id FROM media_uploads mu
+    WHERE mu.key = m.key
+    ORDER BY mu.created_at
+    LIMIT 1
+);
+
+DELETE FROM media WHERE owner_id IS NULL;
+
+ALTER TABLE media ALTER COLUMN owner_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS media_owner_id_idx ON media (owner_id);
+
+DROP TABLE IF EXISTS media_uploads;
+
+DROP INDEX IF EXISTS media_sha256_idx;
+
+ALTER TABLE media
+    DROP COLUMN IF EXISTS sha256,
+    DROP COLUMN IF EXISTS ref_count;
+
+DELETE FROM post_attachment WHERE content_type IN ('audio', 'document');
+DELETE FROM media WHERE type IN ('audio', 'document');
+
+-- enum values can not be dropped, the type is recreated without audio and document
+ALTER TYPE attachment_type RENAME TO attachment_type_old;
+
+CREATE TYPE attachment_type AS ENUM (
+    'photo',
+    'video'
+);
+
+ALTER TABLE post_attachment ALTER COLUMN content_type TYPE attachment_type USING content_type::text::attachment_type;
+
+ALTER TABLE media ALTER COLUMN type TYPE attachment_type USING type::text::attachment_type;
+
+DROP TYPE attachment_type_old;
@@ -0,0 +1,30 @@
+ALTER TYPE attachment_type ADD VALUE IF NOT EXISTS 'audio';
+ALTER TYPE attachment_type ADD VALUE IF NOT EXISTS 'document';
+
+-- identical uploads share one media row, keyed by the SHA-256 of their content. ref_count is the
+-- number of uploads of the content, the rows of media_uploads
+ALTER TABLE media
+    ADD COLUMN IF NOT EXISTS sha256 varchar,
+    ADD COLUMN IF NOT EXISTS ref_count int NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS media_sha256_idx ON media (sha256);
+
+-- who uploaded which media, attachments can only use media their post owner uploaded
+CREATE TABLE IF NOT EXISTS media_uploads (
+    key varchar NOT NULL REFERENCES media(key) ON DELETE CASCADE,
+    owner_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (key, owner_id)
+);
+
+CREATE INDEX IF NOT EXISTS media_uploads_owner_id_idx ON media_uploads (owner_id);
+
+INSERT INTO media_uploads (key, owner_id, created_at)
+SELECT key, owner_id, created_at FROM media
+ON CONFLICT DO NOTHING;
+
+UPDATE media SET ref_count = 1;
+
+DROP INDEX IF EXISTS media_owner_id_idx;
+
+ALTER TABLE media DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS released_blobs;
+
+UPDATE media m SET ref_count = (SELECT COUNT(*) FROM media_uploads mu WHERE mu.key = m.key);
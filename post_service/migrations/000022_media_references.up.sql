@@ -0,0 +1,10 @@
+-- ref_count also counts the attachments that use the media, so a file stays while a post shows it
+UPDATE media m SET ref_count =
+    (SELECT COUNT(*) FROM media_uploads mu WHERE mu.key = m.key) +
+    (SELECT COUNT(*) FROM post_attachment pa WHERE pa.object_key = m.key);
+
+-- the blobs nothing refers to anymore, the gateway deletes them from the blob store
+CREATE TABLE IF NOT EXISTS released_blobs (
+    key varchar PRIMARY KEY,
+    created_at timestamp NOT NULL DEFAULT now()
+);
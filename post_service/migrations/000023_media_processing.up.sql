@@ -0,0 +1,44 @@
+-- photos are processed once per media instead of once per attachment. The original stays under the
+-- media key, since the key is the SHA-256 of its content, the copy without metadata and the variants
+-- are stored under variants/<media key>/
+ALTER TABLE media
+    ADD COLUMN IF NOT EXISTS stripped_key varchar,
+    ADD COLUMN IF NOT EXISTS width int,
+    ADD COLUMN IF NOT EXISTS height int,
+    ADD COLUMN IF NOT EXISTS blurhash varchar,
+    ADD COLUMN IF NOT EXISTS processing_started_at timestamp,
+    ADD COLUMN IF NOT EXISTS processed_at timestamp,
+    ADD COLUMN IF NOT EXISTS processing_error varchar;
+
+CREATE INDEX IF NOT EXISTS media_unprocessed_idx ON media (created_at)
+    WHERE processed_at IS NULL AND type = 'photo';
+
+CREATE TABLE IF NOT EXISTS media_variants (
+    id uuid PRIMARY KEY,
+    media_key varchar NOT NULL REFERENCES media(key) ON DELETE CASCADE,
+    object_key varchar NOT NULL,
+    mime_type varchar NOT NULL,
+    width int NOT NULL,
+    height int NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS media_variants_media_key_idx ON media_variants (media_key);
+
+-- the variants made per attachment are deleted, the photos are processed again
+INSERT INTO released_blobs (key)
+SELECT object_key FROM attachment_variants
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS attachment_variants;
+
+DROP INDEX IF EXISTS post_attachment_unprocessed_idx;
+
+ALTER TABLE post_attachment
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS blurhash,
+    DROP COLUMN IF EXISTS processing_started_at,
+    DROP COLUMN IF EXISTS processed_at,
+    DROP COLUMN IF EXISTS processing_error;
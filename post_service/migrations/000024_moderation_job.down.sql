@@ -0,0 +1 @@
+ALTER TABLE moderation_actions DROP COLUMN IF EXISTS job_id;
@@ -0,0 +1,2 @@
+-- the bulk job of user_service that applies a block_user action
+ALTER TABLE moderation_actions ADD COLUMN IF NOT EXISTS job_id uuid;
@@ -0,0 +1,53 @@
+// Package cursor encodes the position of the last row of a page, so the next page can be
+// fetched with a keyset condition instead of an OFFSET.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalid is returned for cursors that were not produced by Encode.
+var ErrInvalid = errors.New("invalid cursor")
+
+type position struct {
+	Rank      float32   `json:"r,omitempty"`
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// Encode returns an opaque cursor pointing at the row with the given created_at and id.
+func Encode(createdAt time.Time, id string) string {
+	body, _ := json.Marshal(position{CreatedAt: createdAt, ID: id})
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+// EncodeRanked returns an opaque cursor for lists ordered by a rank before created_at and id,
+// such as search results.
+func EncodeRanked(rank float32, createdAt time.Time, id string) string {
+	body, _ := json.Marshal(position{Rank: rank, CreatedAt: createdAt, ID: id})
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+// Decode returns the created_at and id stored in the cursor.
+func Decode(cursor string) (time.Time, string, error) {
+	_, createdAt, id, err := DecodeRanked(cursor)
+	return createdAt, id, err
+}
+
+// DecodeRanked returns the rank, created_at and id stored in the cursor.
+func DecodeRanked(cursor string) (float32, time.Time, string, error) {
+	body, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, time.Time{}, "", ErrInvalid
+	}
+
+	var p position
+	if err := json.Unmarshal(body, &p); err != nil || p.ID == "" {
+		return 0, time.Time{}, "", ErrInvalid
+	}
+
+	return p.Rank, p.CreatedAt, p.ID, nil
+}
@@ -0,0 +1,23 @@
+// Package hashtag normalizes tags, so #GoLang, golang and #golang refer to the same tag.
+package hashtag
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxLength is the longest tag that is stored, in characters.
+const MaxLength = 100
+
+// Normalize returns the tag in lower case without the leading '#'.
+// It returns an empty string for tags that are empty, too long or contain spaces.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimLeft(strings.TrimSpace(tag), "#"))
+
+	if tag == "" || utf8.RuneCountInString(tag) > MaxLength || strings.IndexFunc(tag, unicode.IsSpace) >= 0 {
+		return ""
+	}
+
+	return tag
+}
@@ -0,0 +1,24 @@
+package hashtag_test
+
+import (
+	"post_service/pkg/hashtag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	for in, want := range map[string]string{
+		"#GoLang":          "golang",
+		"golang":           "golang",
+		"  ##DataScience ": "datascience",
+		"#ИскусственныйИнтелл": "искусственныйинтелл",
+		"#":                      "",
+		"":                       "",
+		"#two words":             "",
+		strings.Repeat("a", 101): "",
+	} {
+		assert.Equal(t, want, hashtag.Normalize(in), in)
+	}
+}
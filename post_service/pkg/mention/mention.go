@@ -0,0 +1,70 @@
+// Package mention finds the @username mentions of a text.
+package mention
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxLength is the longest username, in characters.
+const MaxLength = 50
+
+// Mention is an @username in a text. Offset and Length count characters, not bytes,
+// and include the '@'.
+type Mention struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+// Parse returns the mentions of text in order. A mention is an '@' that does not follow a username
+// character, so e-mail addresses are skipped, and the username characters after it: letters, digits,
+// '_' and '.', without a trailing '.'.
+func Parse(text string) []Mention {
+	var (
+		mentions []Mention
+		runes    = []rune(text)
+	)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isUsernameRune(runes[i-1])) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isUsernameRune(runes[end]) {
+			end++
+		}
+		for end > i+1 && runes[end-1] == '.' {
+			end--
+		}
+
+		username := string(runes[i+1 : end])
+		if username != "" && end-i-1 <= MaxLength {
+			mentions = append(mentions, Mention{Username: username, Offset: i, Length: end - i})
+		}
+		i = end - 1
+	}
+
+	return mentions
+}
+
+// Usernames returns the distinct usernames of mentions in order, ignoring case.
+func Usernames(mentions []Mention) []string {
+	var (
+		usernames []string
+		seen      = map[string]bool{}
+	)
+	for _, m := range mentions {
+		key := strings.ToLower(m.Username)
+		if !seen[key] {
+			seen[key] = true
+			usernames = append(usernames, m.Username)
+		}
+	}
+	return usernames
+}
+
+func isUsernameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
@@ -0,0 +1,41 @@
+package mention_test
+
+import (
+	"post_service/pkg/mention"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	mentions := mention.Parse("Thanks @alice and @bob_99. Mail me at me@example.com, @ alone, (@Ünal)")
+
+	assert.Equal(t, []mention.Mention{
+		{Username: "alice", Offset: 7, Length: 6},
+		{Username: "bob_99", Offset: 18, Length: 7},
+		{Username: "Ünal", Offset: 64, Length: 5},
+	}, mentions)
+}
+
+func TestParse_Offsets(t *testing.T) {
+	text := "привет @иван.петров!"
+
+	mentions := mention.Parse(text)
+
+	if assert.Len(t, mentions, 1) {
+		m := mentions[0]
+		assert.Equal(t, "@иван.петров", string([]rune(text)[m.Offset:m.Offset+m.Length]), "offsets count characters")
+	}
+}
+
+func TestParse_TooLong(t *testing.T) {
+	assert.Empty(t, mention.Parse("@"+strings.Repeat("a", mention.MaxLength+1)))
+	assert.Len(t, mention.Parse("@"+strings.Repeat("a", mention.MaxLength)), 1)
+}
+
+func TestUsernames(t *testing.T) {
+	usernames := mention.Usernames(mention.Parse("@Alice @bob @alice @carol @Bob"))
+
+	assert.Equal(t, []string{"Alice", "bob", "carol"}, usernames)
+}
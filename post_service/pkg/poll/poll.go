@@ -0,0 +1,106 @@
+// Package poll checks the polls of posts and the votes cast in them.
+package poll
+
+import (
+	"errors"
+	"fmt"
+	"post_service/genproto/post_service"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MinOptions = 2
+	MaxOptions = 10
+	// MaxOptionLength is the length of the text of an option, in characters.
+	MaxOptionLength = 100
+	// MaxDuration is how long a poll can stay open after the post is published.
+	MaxDuration = 30 * 24 * time.Hour
+)
+
+// Validate checks a new poll, trims the text of its options and returns its closing time.
+// published is when the post is or will be published.
+func Validate(p *post_service.Poll, published time.Time) (time.Time, error) {
+	if len(p.Options) < MinOptions || len(p.Options) > MaxOptions {
+		return time.Time{}, fmt.Errorf("a poll has %d to %d options", MinOptions, MaxOptions)
+	}
+
+	seen := make(map[string]bool, len(p.Options))
+	for _, option := range p.Options {
+		option.Text = strings.TrimSpace(option.Text)
+		if option.Text == "" {
+			return time.Time{}, errors.New("the text of an option is required")
+		}
+		if utf8.RuneCountInString(option.Text) > MaxOptionLength {
+			return time.Time{}, fmt.Errorf("the text of an option is longer than %d characters", MaxOptionLength)
+		}
+
+		key := strings.ToLower(option.Text)
+		if seen[key] {
+			return time.Time{}, fmt.Errorf("option %q is listed twice", option.Text)
+		}
+		seen[key] = true
+	}
+
+	closesAt, err := time.Parse(time.RFC3339, strings.TrimSpace(p.ClosesAt))
+	if err != nil {
+		return time.Time{}, errors.New("invalid closes_at, expected RFC3339")
+	}
+	if !closesAt.After(published) {
+		return time.Time{}, errors.New("closes_at must be after the post is published")
+	}
+	if closesAt.Sub(published) > MaxDuration {
+		return time.Time{}, fmt.Errorf("a poll can stay open for at most %d days", int(MaxDuration.Hours()/24))
+	}
+
+	return closesAt.UTC(), nil
+}
+
+// ValidateVote checks the options chosen in a poll and returns them without duplicates.
+func ValidateVote(p *post_service.Poll, optionIDs []string) ([]string, error) {
+	if p.Closed {
+		return nil, errors.New("the poll is closed")
+	}
+
+	options := make(map[string]bool, len(p.Options))
+	for _, option := range p.Options {
+		options[option.Id] = true
+	}
+
+	var (
+		chosen []string
+		seen   = map[string]bool{}
+	)
+	for _, id := range optionIDs {
+		if !options[id] {
+			return nil, fmt.Errorf("option %q is not in the poll", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			chosen = append(chosen, id)
+		}
+	}
+
+	if len(chosen) == 0 {
+		return nil, errors.New("choose at least one option")
+	}
+	if len(chosen) > 1 && !p.Multiple {
+		return nil, errors.New("only one option can be chosen in this poll")
+	}
+
+	return chosen, nil
+}
+
+// Redact hides the results from a user who did not vote while the poll is open.
+func Redact(p *post_service.Poll) {
+	p.ResultsVisible = p.Closed || len(p.ViewerChoices) > 0
+	if p.ResultsVisible {
+		return
+	}
+
+	p.VoterCount = 0
+	for _, option := range p.Options {
+		option.VoteCount = 0
+	}
+}
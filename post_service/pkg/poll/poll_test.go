@@ -0,0 +1,103 @@
+package poll_test
+
+import (
+	"post_service/genproto/post_service"
+	"post_service/pkg/poll"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var published = time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+
+func options(texts ...string) []*post_service.PollOption {
+	var opts []*post_service.PollOption
+	for _, text := range texts {
+		opts = append(opts, &post_service.PollOption{Text: text})
+	}
+	return opts
+}
+
+func TestValidate(t *testing.T) {
+	p := &post_service.Poll{
+		Options:  options(" tea ", "coffee"),
+		ClosesAt: "2025-03-15T14:30:00+05:00",
+	}
+
+	closesAt, err := poll.Validate(p, published)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 15, 9, 30, 0, 0, time.UTC), closesAt)
+	assert.Equal(t, "tea", p.Options[0].Text, "the text is trimmed")
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	tomorrow := "2025-03-15T09:30:00Z"
+	for name, p := range map[string]*post_service.Poll{
+		"one option":       {Options: options("yes"), ClosesAt: tomorrow},
+		"eleven options":   {Options: options("1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"), ClosesAt: tomorrow},
+		"empty option":     {Options: options("yes", " "), ClosesAt: tomorrow},
+		"long option":      {Options: options("yes", string(make([]rune, poll.MaxOptionLength+1))), ClosesAt: tomorrow},
+		"duplicate option": {Options: options("Yes", "yes"), ClosesAt: tomorrow},
+		"no closes_at":     {Options: options("yes", "no")},
+		"closes before":    {Options: options("yes", "no"), ClosesAt: "2025-03-14T09:30:00Z"},
+		"open too long":    {Options: options("yes", "no"), ClosesAt: "2025-04-14T09:30:00Z"},
+	} {
+		_, err := poll.Validate(p, published)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestValidateVote(t *testing.T) {
+	p := &post_service.Poll{Options: []*post_service.PollOption{{Id: "a"}, {Id: "b"}, {Id: "c"}}}
+
+	chosen, err := poll.ValidateVote(p, []string{"b", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, chosen, "duplicates are dropped")
+
+	_, err = poll.ValidateVote(p, []string{"a", "b"})
+	assert.Error(t, err, "single choice")
+
+	_, err = poll.ValidateVote(p, nil)
+	assert.Error(t, err, "no option")
+
+	_, err = poll.ValidateVote(p, []string{"x"})
+	assert.Error(t, err, "unknown option")
+
+	p.Multiple = true
+	chosen, err = poll.ValidateVote(p, []string{"c", "a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a"}, chosen)
+
+	p.Closed = true
+	_, err = poll.ValidateVote(p, []string{"a"})
+	assert.Error(t, err, "closed")
+}
+
+func TestRedact(t *testing.T) {
+	newPoll := func() *post_service.Poll {
+		return &post_service.Poll{
+			VoterCount: 3,
+			Options:    []*post_service.PollOption{{Id: "a", VoteCount: 2}, {Id: "b", VoteCount: 1}},
+		}
+	}
+
+	p := newPoll()
+	poll.Redact(p)
+	assert.False(t, p.ResultsVisible)
+	assert.Zero(t, p.VoterCount)
+	assert.Zero(t, p.Options[0].VoteCount)
+
+	p = newPoll()
+	p.ViewerChoices = []string{"b"}
+	poll.Redact(p)
+	assert.True(t, p.ResultsVisible, "voters see the results")
+	assert.Equal(t, int64(2), p.Options[0].VoteCount)
+
+	p = newPoll()
+	p.Closed = true
+	poll.Redact(p)
+	assert.True(t, p.ResultsVisible, "everyone sees the results of a closed poll")
+	assert.Equal(t, int64(3), p.VoterCount)
+}
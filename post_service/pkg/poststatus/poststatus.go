@@ -0,0 +1,53 @@
+// Package poststatus defines the statuses of a post and the changes allowed between them.
+package poststatus
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	Draft     = "draft"
+	Scheduled = "scheduled"
+	Published = "published"
+	// Archived posts are taken down by their owner, only the owner still sees them.
+	Archived = "archived"
+	// Hidden posts are taken down by a moderator and are only set by the moderation actions.
+	Hidden = "hidden"
+)
+
+// ErrTransition is returned for a change of status that is not allowed.
+var ErrTransition = errors.New("status change not allowed")
+
+// transitions are the statuses a post in a status can be given by its owner, keeping the
+// status is always allowed. A published post never goes back to draft, archiving takes it down
+// and publishing it again brings it back.
+var transitions = map[string][]string{
+	Draft:     {Scheduled, Published},
+	Scheduled: {Draft, Published},
+	Published: {Archived},
+	Archived:  {Published},
+	Hidden:    {},
+}
+
+// Valid tells whether status is a value of the post_status enum.
+func Valid(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// Check returns an error wrapping ErrTransition when a post in status from can not be given status to.
+// Statuses that are not valid are never allowed.
+func Check(from, to string) error {
+	if from == to && Valid(to) {
+		return nil
+	}
+
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: a %s post can not become %s", ErrTransition, from, to)
+}
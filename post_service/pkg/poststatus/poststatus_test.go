@@ -0,0 +1,49 @@
+package poststatus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{Draft, Draft, true},
+		{Draft, Scheduled, true},
+		{Draft, Published, true},
+		{Draft, Archived, false},
+		{Scheduled, Draft, true},
+		{Scheduled, Published, true},
+		{Published, Archived, true},
+		{Published, Draft, false},
+		{Published, Scheduled, false},
+		{Archived, Published, true},
+		{Archived, Draft, false},
+		{Published, Hidden, false},
+		{Hidden, Hidden, true},
+		{Hidden, Published, false},
+		{Draft, "deleted", false},
+		{"deleted", "deleted", false},
+	}
+
+	for _, tt := range tests {
+		err := Check(tt.from, tt.to)
+		if tt.ok {
+			assert.NoError(t, err, "%s -> %s", tt.from, tt.to)
+			continue
+		}
+		assert.True(t, errors.Is(err, ErrTransition), "%s -> %s: %v", tt.from, tt.to, err)
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, status := range []string{Draft, Scheduled, Published, Archived, Hidden} {
+		assert.True(t, Valid(status), status)
+	}
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("Published"))
+}
@@ -0,0 +1,48 @@
+// Package schedule reads the publish time of scheduled posts.
+package schedule
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalid is returned for publish times in an unknown format or an unknown timezone.
+var ErrInvalid = errors.New("invalid publish_at, expected RFC3339 or a local time like 2006-01-02T15:04")
+
+// localLayouts are the accepted formats of a publish time without an offset.
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// IsLocal reports whether value has no offset, so it needs the timezone of the author to be read.
+func IsLocal(value string) bool {
+	_, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	return err != nil
+}
+
+// Parse returns the publish time in UTC. A value without an offset is a wall clock time in timezone,
+// an IANA name like Asia/Tashkent, and an empty timezone is UTC.
+func Parse(value, timezone string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, ErrInvalid
+	}
+
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, ErrInvalid
+}
@@ -0,0 +1,45 @@
+package schedule_test
+
+import (
+	"post_service/pkg/schedule"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		value, timezone string
+		want            time.Time
+	}{
+		{"2025-03-14T09:30:00+05:00", "America/New_York", time.Date(2025, 3, 14, 4, 30, 0, 0, time.UTC)},
+		{"2025-03-14T09:30:00Z", "", time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)},
+		{"2025-03-14T09:30", "Asia/Tashkent", time.Date(2025, 3, 14, 4, 30, 0, 0, time.UTC)},
+		{"2025-03-14 09:30:15", "UTC", time.Date(2025, 3, 14, 9, 30, 15, 0, time.UTC)},
+		{"2025-07-01T09:30", "Europe/Berlin", time.Date(2025, 7, 1, 7, 30, 0, 0, time.UTC)},
+		{" 2025-03-14T09:30 ", "", time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)},
+	} {
+		got, err := schedule.Parse(tc.value, tc.timezone)
+		require.NoError(t, err, tc.value)
+		assert.Equal(t, tc.want, got, tc.value)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, tc := range []struct{ value, timezone string }{
+		{"", "UTC"},
+		{"tomorrow", "UTC"},
+		{"2025-03-14", "UTC"},
+		{"2025-03-14T09:30", "Mars/Olympus"},
+	} {
+		_, err := schedule.Parse(tc.value, tc.timezone)
+		assert.ErrorIs(t, err, schedule.ErrInvalid, tc.value)
+	}
+}
+
+func TestIsLocal(t *testing.T) {
+	assert.False(t, schedule.IsLocal("2025-03-14T09:30:00+05:00"))
+	assert.True(t, schedule.IsLocal("2025-03-14T09:30"))
+}
@@ -0,0 +1,146 @@
+// Package textdiff computes the differences between two versions of a text, word by word.
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Op tells how a chunk of text changed.
+type Op string
+
+const (
+	Equal  Op = "equal"
+	Insert Op = "insert"
+	Delete Op = "delete"
+)
+
+// Chunk is a piece of text that is in both versions, or only in the old or the new one.
+type Chunk struct {
+	Op   Op
+	Text string
+}
+
+// maxCells bounds the size of the table used to diff two texts, bigger texts are diffed line by line,
+// and replaced as a whole if that is still too big.
+const maxCells = 4_000_000
+
+// Diff returns the chunks that turn a into b. Joining the equal and deleted chunks gives a,
+// joining the equal and inserted chunks gives b.
+func Diff(a, b string) []Chunk {
+	x, y := words(a), words(b)
+	if len(x)*len(y) > maxCells {
+		x, y = lines(a), lines(b)
+	}
+	if len(x)*len(y) > maxCells {
+		return merge([]Chunk{{Op: Delete, Text: a}, {Op: Insert, Text: b}})
+	}
+
+	return merge(diff(x, y))
+}
+
+// diff walks the longest common subsequence of x and y.
+func diff(x, y []string) []Chunk {
+	var chunks []Chunk
+
+	// the common prefix and suffix do not need the table
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		chunks = append(chunks, Chunk{Op: Equal, Text: x[prefix]})
+		prefix++
+	}
+	x, y = x[prefix:], y[prefix:]
+
+	suffix := 0
+	for suffix < len(x) && suffix < len(y) && x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+	tail := x[len(x)-suffix:]
+	x, y = x[:len(x)-suffix], y[:len(y)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int32, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			chunks = append(chunks, Chunk{Op: Equal, Text: x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			chunks = append(chunks, Chunk{Op: Delete, Text: x[i]})
+			i++
+		default:
+			chunks = append(chunks, Chunk{Op: Insert, Text: y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		chunks = append(chunks, Chunk{Op: Delete, Text: x[i]})
+	}
+	for ; j < len(y); j++ {
+		chunks = append(chunks, Chunk{Op: Insert, Text: y[j]})
+	}
+
+	for _, t := range tail {
+		chunks = append(chunks, Chunk{Op: Equal, Text: t})
+	}
+
+	return chunks
+}
+
+// merge joins neighbouring chunks with the same op and drops empty ones.
+func merge(chunks []Chunk) []Chunk {
+	var out []Chunk
+	for _, c := range chunks {
+		if c.Text == "" {
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].Op == c.Op {
+			out[n-1].Text += c.Text
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// words splits s into runs of spaces and runs of other characters, so no text is lost.
+func words(s string) []string {
+	var (
+		tokens []string
+		start  int
+		space  bool
+	)
+	for i, r := range s {
+		if i > 0 && unicode.IsSpace(r) != space {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		space = unicode.IsSpace(r)
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.SplitAfter(s, "\n")
+}
@@ -0,0 +1,52 @@
+package textdiff_test
+
+import (
+	"post_service/pkg/textdiff"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	chunks := textdiff.Diff("the quick brown fox", "the slow brown fox jumps")
+
+	assert.Equal(t, []textdiff.Chunk{
+		{Op: textdiff.Equal, Text: "the "},
+		{Op: textdiff.Delete, Text: "quick"},
+		{Op: textdiff.Insert, Text: "slow"},
+		{Op: textdiff.Equal, Text: " brown fox"},
+		{Op: textdiff.Insert, Text: " jumps"},
+	}, chunks)
+}
+
+func TestDiff_Rebuilds(t *testing.T) {
+	for _, tc := range []struct{ a, b string }{
+		{"", "new text"},
+		{"old text", ""},
+		{"same", "same"},
+		{"line one\nline two\n", "line one\nline 2\nline three\n"},
+		{"ünïcode  spaces\ttabs", "ünïcode spaces\t\ttabs!"},
+	} {
+		var a, b strings.Builder
+		for _, c := range textdiff.Diff(tc.a, tc.b) {
+			if c.Op != textdiff.Insert {
+				a.WriteString(c.Text)
+			}
+			if c.Op != textdiff.Delete {
+				b.WriteString(c.Text)
+			}
+		}
+		assert.Equal(t, tc.a, a.String())
+		assert.Equal(t, tc.b, b.String())
+	}
+}
+
+func TestDiff_Large(t *testing.T) {
+	a := strings.Repeat("a ", 5000)
+	b := strings.Repeat("b ", 5000)
+
+	chunks := textdiff.Diff(a, b)
+
+	assert.Equal(t, []textdiff.Chunk{{Op: textdiff.Delete, Text: a}, {Op: textdiff.Insert, Text: b}}, chunks)
+}
@@ -0,0 +1,277 @@
+// Package unfurl finds the URLs of a text and fetches their link previews from the OpenGraph and
+// Twitter card metadata of the pages.
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// MaxURLs is the number of URLs of a text that get a preview.
+	MaxURLs = 5
+
+	DefaultTimeout  = 5 * time.Second
+	DefaultMaxBytes = 512 << 10
+
+	maxRedirects         = 3
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+)
+
+var (
+	// ErrForbiddenAddress is returned for URLs that resolve to an address that is not allowed,
+	// by default any address that is not public.
+	ErrForbiddenAddress = errors.New("the address of the URL is not public")
+	// ErrNoPreview is returned for pages without a title.
+	ErrNoPreview = errors.New("the page has no preview")
+
+	urlPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"']+`)
+)
+
+// Preview is the card shown for a link.
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// ExtractURLs returns the distinct http and https URLs of text in order, at most MaxURLs.
+// Punctuation that ends a sentence is not part of a URL.
+func ExtractURLs(text string) []string {
+	var (
+		urls []string
+		seen = map[string]bool{}
+	)
+
+	for _, match := range urlPattern.FindAllString(text, -1) {
+		match = strings.TrimRight(match, ".,;:!?)]}")
+
+		u, err := url.Parse(match)
+		if err != nil || u.Hostname() == "" || seen[match] {
+			continue
+		}
+
+		seen[match] = true
+		urls = append(urls, match)
+		if len(urls) == MaxURLs {
+			break
+		}
+	}
+
+	return urls
+}
+
+// PublicAddress reports whether ip is a public unicast address. Loopback, private, link local,
+// shared (100.64.0.0/10), multicast and unspecified addresses are not.
+func PublicAddress(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if ip4[0] == 0 || (ip4[0] == 100 && ip4[1]&0xc0 == 64) {
+			return false
+		}
+	}
+
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// Options configure a Fetcher, zero values are replaced by the defaults.
+type Options struct {
+	Timeout  time.Duration
+	MaxBytes int64
+	// AllowAddress decides which addresses can be connected to, PublicAddress by default.
+	AllowAddress func(ip net.IP) bool
+}
+
+// Fetcher fetches link previews. The address is checked when the connection is made, after the
+// name is resolved, so a name that resolves to a private address is rejected, also on redirects.
+type Fetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+func NewFetcher(opts Options) *Fetcher {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+	if opts.AllowAddress == nil {
+		opts.AllowAddress = PublicAddress
+	}
+
+	dialer := &net.Dialer{
+		Timeout: opts.Timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !opts.AllowAddress(ip) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+
+	return &Fetcher{
+		client: &http.Client{
+			Timeout: opts.Timeout,
+			// a proxy would make the connection instead of the dialer, so it is never used
+			Transport: &http.Transport{
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   opts.Timeout,
+				ResponseHeaderTimeout: opts.Timeout,
+				MaxIdleConns:          10,
+				IdleConnTimeout:       30 * time.Second,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+				}
+				return nil
+			},
+		},
+		maxBytes: opts.MaxBytes,
+	}
+}
+
+// Fetch returns the preview of the page at rawURL. Only the first MaxBytes of the page are read.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid URL %q", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "post_service link preview")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" {
+		return nil, fmt.Errorf("unexpected content type %q", mediaType)
+	}
+
+	preview := parse(io.LimitReader(resp.Body, f.maxBytes), resp.Request.URL)
+	if preview.Title == "" {
+		return nil, ErrNoPreview
+	}
+	preview.URL = rawURL
+
+	return preview, nil
+}
+
+// parse reads the metadata of the head of a page. OpenGraph tags win over Twitter card tags,
+// which win over the title and the description of the page.
+func parse(r io.Reader, base *url.URL) *Preview {
+	var (
+		meta    = map[string]string{}
+		title   string
+		inTitle bool
+		z       = html.NewTokenizer(r)
+	)
+
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				break loop
+			case "title":
+				inTitle = true
+			case "meta":
+				var key, content string
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "property", "name":
+						key = strings.ToLower(string(v))
+					case "content":
+						content = strings.TrimSpace(string(v))
+					}
+				}
+				if _, ok := meta[key]; !ok && key != "" && content != "" {
+					meta[key] = content
+				}
+			}
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(string(z.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "head":
+				break loop
+			case "title":
+				inTitle = false
+			}
+		}
+	}
+
+	preview := &Preview{
+		Title:       truncate(first(meta["og:title"], meta["twitter:title"], title), maxTitleLength),
+		Description: truncate(first(meta["og:description"], meta["twitter:description"], meta["description"]), maxDescriptionLength),
+		SiteName:    first(meta["og:site_name"], base.Hostname()),
+	}
+
+	if image := first(meta["og:image"], meta["twitter:image"]); image != "" {
+		if u, err := base.Parse(image); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			preview.ImageURL = u.String()
+		}
+	}
+
+	return preview
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncate cuts s to at most n characters.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
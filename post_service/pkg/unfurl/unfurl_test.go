@@ -0,0 +1,142 @@
+package unfurl_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"post_service/pkg/unfurl"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// allowAll lets the tests connect to the local server.
+var allowAll = unfurl.Options{AllowAddress: func(net.IP) bool { return true }}
+
+func page(head string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<!doctype html><html><head>" + head + "</head><body><h1>body</h1></body></html>"))
+	}
+}
+
+func TestExtractURLs(t *testing.T) {
+	assert.Equal(t, []string{
+		"https://example.com/a?b=1",
+		"http://example.org",
+	}, unfurl.ExtractURLs("see https://example.com/a?b=1. and (http://example.org), again https://example.com/a?b=1 or ftp://example.net"))
+
+	assert.Empty(t, unfurl.ExtractURLs("no links, just example.com and http://"))
+
+	many := strings.Repeat("https://example.com/x ", 3) + "https://a.io https://b.io https://c.io https://d.io https://e.io https://f.io"
+	assert.Len(t, unfurl.ExtractURLs(many), unfurl.MaxURLs)
+}
+
+func TestPublicAddress(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":      true,
+		"2606:4700::1111":    true,
+		"127.0.0.1":          false,
+		"10.1.2.3":           false,
+		"172.16.0.1":         false,
+		"192.168.1.1":        false,
+		"169.254.169.254":    false,
+		"100.64.0.1":         false,
+		"0.0.0.0":            false,
+		"224.0.0.1":          false,
+		"::1":                false,
+		"fd00::1":            false,
+		"fe80::1":            false,
+		"::ffff:192.168.1.1": false,
+	} {
+		assert.Equal(t, want, unfurl.PublicAddress(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(page(`
+		<title>Page title</title>
+		<meta name="description" content="page description">
+		<meta name="twitter:title" content="Twitter title">
+		<meta property="og:title" content=" OpenGraph title ">
+		<meta property="og:image" content="/cover.png">
+		<meta property="og:site_name" content="Example">`))
+	defer srv.Close()
+
+	preview, err := unfurl.NewFetcher(allowAll).Fetch(context.Background(), srv.URL+"/article")
+	require.NoError(t, err)
+	assert.Equal(t, &unfurl.Preview{
+		URL:         srv.URL + "/article",
+		Title:       "OpenGraph title",
+		Description: "page description",
+		ImageURL:    srv.URL + "/cover.png",
+		SiteName:    "Example",
+	}, preview)
+}
+
+func TestFetch_FallsBackToTitle(t *testing.T) {
+	srv := httptest.NewServer(page(`<title>Only a title</title><meta name="twitter:description" content="card">`))
+	defer srv.Close()
+
+	preview, err := unfurl.NewFetcher(allowAll).Fetch(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "Only a title", preview.Title)
+	assert.Equal(t, "card", preview.Description)
+	assert.Equal(t, "127.0.0.1", preview.SiteName)
+}
+
+func TestFetch_RejectsPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(page(`<title>internal</title>`))
+	defer srv.Close()
+
+	_, err := unfurl.NewFetcher(unfurl.Options{}).Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, unfurl.ErrForbiddenAddress)
+}
+
+func TestFetch_RejectsRedirectToPrivateAddress(t *testing.T) {
+	internal := httptest.NewServer(page(`<title>internal</title>`))
+	defer internal.Close()
+
+	public := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusFound))
+	defer public.Close()
+
+	// only the first connection, to the redirecting server, is allowed
+	calls := 0
+	fetcher := unfurl.NewFetcher(unfurl.Options{AllowAddress: func(net.IP) bool {
+		calls++
+		return calls == 1
+	}})
+	_, err := fetcher.Fetch(context.Background(), public.URL)
+	assert.ErrorIs(t, err, unfurl.ErrForbiddenAddress, "every connection is checked")
+	assert.Equal(t, 2, calls)
+}
+
+func TestFetch_Limits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><head>" + strings.Repeat("<!-- padding -->", 1000) + "<title>too late</title></head></html>"))
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"title": "no"}`))
+		case "/missing":
+			http.NotFound(w, r)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	fetcher := unfurl.NewFetcher(unfurl.Options{MaxBytes: 1024, AllowAddress: allowAll.AllowAddress})
+	for _, path := range []string{"/large", "/json", "/missing", "/loop"} {
+		_, err := fetcher.Fetch(context.Background(), srv.URL+path)
+		assert.Error(t, err, path)
+	}
+
+	_, err := fetcher.Fetch(context.Background(), "file:///etc/passwd")
+	assert.Error(t, err)
+}
@@ -2,9 +2,11 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
 	"post_service/storage"
 	"strings"
 	"time"
@@ -14,6 +16,25 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// attachmentVariants selects the variants of the media of the attachment aliased pa as a JSON array,
+// smallest first.
+const attachmentVariants = `COALESCE((
+			SELECT json_agg(jsonb_build_object(
+				'object_key', mv.object_key,
+				'mime_type', mv.mime_type,
+				'width', mv.width,
+				'height', mv.height,
+				'size', mv.size
+			) ORDER BY mv.width, mv.mime_type)
+			FROM media_variants mv
+			WHERE mv.media_key = pa.object_key
+		), '[]'::json)`
+
+// attachmentMedia joins the media of the attachment aliased pa as am, the photos are processed once
+// per media.
+const attachmentMedia = `post_attachment pa
+		LEFT JOIN media am ON am.key = pa.object_key`
+
 type PostAttachmentRepo struct {
 	db *pgxpool.Pool
 }
@@ -26,27 +47,43 @@ func NewAttachmentRepo(db *pgxpool.Pool) storage.PostAttachmentRepoI {
 
 func (s *PostAttachmentRepo) MultipleUpsert(ctx context.Context, req *us.AttachmentMultipleInsertRequest) (*us.AttachmentList, error) {
 
-	sql := `INSERT INTO post_attachment (id, post_id, filepath, content_type) VALUES`
+	sql := `INSERT INTO post_attachment (id, post_id, filepath, content_type, object_key) VALUES`
 	args := []interface{}{}
 	argIndex := 1
+	keys := []string{}
 
 	for _, attachment := range req.Attachments {
 		if attachment.Id == "" {
 			attachment.Id = uuid.NewString()
 		}
-		sql += fmt.Sprintf(" ($%d, $%d, $%d, $%d),", argIndex, argIndex+1, argIndex+2, argIndex+3)
-		args = append(args, attachment.Id, req.PostId, attachment.Filepath, attachment.ContentType)
-		argIndex += 4
+		sql += fmt.Sprintf(" ($%d, $%d, $%d, $%d, NULLIF($%d, '')),", argIndex, argIndex+1, argIndex+2, argIndex+3, argIndex+4)
+		args = append(args, attachment.Id, req.PostId, attachment.Filepath, attachment.ContentType, attachment.ObjectKey)
+		argIndex += 5
+		keys = append(keys, attachment.ObjectKey)
 	}
 
 	sql = strings.TrimSuffix(sql, ",")
 
-	_, err := s.db.Exec(ctx, sql, args...)
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, sql, args...)
 	if err != nil {
 		log.Println("error while inserting multiple post_attachments", err)
 		return nil, err
 	}
 
+	if err := referenceMedia(ctx, tx, keys); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
 	attachments, err := s.GetList(ctx, &us.GetListAttachmentRequest{
 		Search: req.PostId,
 		Limit:  100,
@@ -62,21 +99,36 @@ func (s *PostAttachmentRepo) MultipleUpsert(ctx context.Context, req *us.Attachm
 func (s *PostAttachmentRepo) Create(ctx context.Context, req *us.Attachment) (*us.Attachment, error) {
 	id := uuid.NewString()
 
-	_, err := s.db.Exec(ctx, `
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
 		INSERT INTO post_attachment (
 			id,
 			post_id,
 			filepath,
-			content_type
+			content_type,
+			object_key
 		) VALUES (
-			$1, $2, $3, $4
-		)`, id, req.PostId, req.Filepath, req.ContentType)
+			$1, $2, $3, $4, NULLIF($5, '')
+		)`, id, req.PostId, req.Filepath, req.ContentType, req.ObjectKey)
 
 	if err != nil {
 		log.Println("error while creating post_attachment in storage", err)
 		return nil, err
 	}
 
+	if err := referenceMedia(ctx, tx, []string{req.ObjectKey}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
 	attachment, err := s.GetSingle(ctx, &us.AttachmentSingleRequest{Id: id})
 	if err != nil {
 		log.Println("error while getting post_attachment by id after creating", err)
@@ -90,24 +142,36 @@ func (s *PostAttachmentRepo) GetSingle(ctx context.Context, req *us.AttachmentSi
 
 	var (
 		created_at, updated_at time.Time
+		variantsJSON           string
 	)
 
 	err := s.db.QueryRow(ctx, `
 	        SELECT 
-			id,
-			post_id,
-			filepath,
-			content_type,
-	        created_at,
-	        updated_at
-	        FROM post_attachment 
-	    WHERE id=$1`, req.Id).Scan(&resp.Id, &resp.PostId, &resp.Filepath, &resp.ContentType, &created_at, &updated_at)
+			pa.id,
+			pa.post_id,
+			pa.filepath,
+			pa.content_type,
+	        pa.created_at,
+	        pa.updated_at,
+	        COALESCE(pa.object_key, ''),
+			COALESCE(am.width, 0),
+			COALESCE(am.height, 0),
+			COALESCE(am.blurhash, ''),
+			COALESCE(am.stripped_key, ''),
+			`+attachmentVariants+`
+	        FROM `+attachmentMedia+`
+	    WHERE pa.id=$1`, req.Id).Scan(&resp.Id, &resp.PostId, &resp.Filepath, &resp.ContentType, &created_at, &updated_at, &resp.ObjectKey, &resp.Width, &resp.Height, &resp.Blurhash, &resp.StrippedKey, &variantsJSON)
 
 	if err != nil {
 		log.Println("error while getting attachment by id", err)
 		return nil, err
 	}
 
+	if err := json.Unmarshal([]byte(variantsJSON), &resp.Variants); err != nil {
+		log.Println("error while decoding attachment variants", err)
+		return nil, err
+	}
+
 	resp.CreatedAt = created_at.Format(time.RFC3339)
 	resp.UpdatedAt = updated_at.Format(time.RFC3339)
 
@@ -115,34 +179,47 @@ func (s *PostAttachmentRepo) GetSingle(ctx context.Context, req *us.AttachmentSi
 }
 
 func (s *PostAttachmentRepo) GetList(ctx context.Context, req *us.GetListAttachmentRequest) (*us.AttachmentList, error) {
-	resp := &us.AttachmentList{}
 	var (
-		created_at, updated_at time.Time
+		resp   = &us.AttachmentList{}
+		args   []interface{}
+		filter = " WHERE TRUE"
 	)
-	offset := (req.Page - 1) * req.Limit
 
-	var conditions []string
 	if req.Search != "" {
-		conditions = append(conditions, fmt.Sprintf(`post_id = '%v'`, req.Search))
+		args = append(args, req.Search)
+		filter += fmt.Sprintf(" AND pa.post_id = $%d", len(args))
 	}
 
-	var query string
-	if len(conditions) > 0 {
-		query = " WHERE " + strings.Join(conditions, " AND ")
+	if req.WithTotal {
+		err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM post_attachment pa`+filter, args...).Scan(&resp.Count)
+		if err != nil {
+			log.Println("error while counting attachments:", err)
+			return nil, err
+		}
 	}
 
-	query += fmt.Sprintf(" OFFSET %v LIMIT %v", offset, req.Limit)
+	// attachments are listed in the order they were uploaded
+	filter, args, err := page{cursor: req.Cursor, number: req.Page, limit: req.Limit, asc: true, alias: "pa."}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
 
 	rows, err := s.db.Query(ctx, `
 		SELECT
-			id,
-			post_id,
-			filepath,
-			content_type,
-			created_at,
-			updated_at
-		FROM post_attachment
-	`+query)
+			pa.id,
+			pa.post_id,
+			pa.filepath,
+			pa.content_type,
+			pa.created_at,
+			pa.updated_at,
+			COALESCE(pa.object_key, ''),
+			COALESCE(am.width, 0),
+			COALESCE(am.height, 0),
+			COALESCE(am.blurhash, ''),
+			COALESCE(am.stripped_key, ''),
+			`+attachmentVariants+`
+		FROM `+attachmentMedia+`
+	`+filter, args...)
 
 	if err != nil {
 		log.Println("error while getting all attachments:", err)
@@ -151,21 +228,36 @@ func (s *PostAttachmentRepo) GetList(ctx context.Context, req *us.GetListAttachm
 
 	defer rows.Close()
 
-	var count int64
-
+	var lastCreatedAt time.Time
 	for rows.Next() {
-		var attachment us.Attachment
-		count++
-		err = rows.Scan(&attachment.Id, &attachment.PostId, &attachment.Filepath, &attachment.ContentType, &created_at, &updated_at)
+		var (
+			attachment             us.Attachment
+			created_at, updated_at time.Time
+			variantsJSON           string
+		)
 
+		err = rows.Scan(&attachment.Id, &attachment.PostId, &attachment.Filepath, &attachment.ContentType, &created_at, &updated_at, &attachment.ObjectKey, &attachment.Width, &attachment.Height, &attachment.Blurhash, &attachment.StrippedKey, &variantsJSON)
 		if err != nil {
 			log.Println("error while scanning attachments:", err)
 			return nil, err
 		}
+
+		if err := json.Unmarshal([]byte(variantsJSON), &attachment.Variants); err != nil {
+			log.Println("error while decoding attachment variants:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
 		attachment.CreatedAt = created_at.Format(time.RFC3339)
 		attachment.UpdatedAt = updated_at.Format(time.RFC3339)
 
 		resp.Items = append(resp.Items, &attachment)
+		lastCreatedAt = created_at
 	}
 
 	if err = rows.Err(); err != nil {
@@ -173,13 +265,22 @@ func (s *PostAttachmentRepo) GetList(ctx context.Context, req *us.GetListAttachm
 		return nil, err
 	}
 
-	resp.Count = count
-
 	return resp, nil
 }
 
+// Delete deletes the attachments of the post req.Id and releases their media.
 func (s *PostAttachmentRepo) Delete(ctx context.Context, req *us.AttachmentSingleRequest) (*emptypb.Empty, error) {
-	_, err := s.db.Exec(ctx, `
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := releaseAttachments(ctx, tx, "pa.post_id = $1", req.Id); err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	_, err = tx.Exec(ctx, `
 		DELETE FROM post_attachment
 		WHERE post_id = $1
 	`, req.Id)
@@ -189,7 +290,7 @@ func (s *PostAttachmentRepo) Delete(ctx context.Context, req *us.AttachmentSingl
 		return &emptypb.Empty{}, err
 	}
 
-	return &emptypb.Empty{}, nil
+	return &emptypb.Empty{}, tx.Commit(ctx)
 }
 
 func (s *PostAttachmentRepo) GetDefaultTags(ctx context.Context, req *us.GetDefaultTagsRequest) (*us.GetDefaultTagsResponse, error) {
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"post_service/genproto/user_service"
+	"post_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type AuditEventRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditEventRepo(db *pgxpool.Pool) storage.AuditEventRepoI {
+	return &AuditEventRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.AuditEventRepoI.
+func (s *AuditEventRepo) Create(ctx context.Context, req *user_service.AuditEvent) error {
+	changes, err := json.Marshal(req.Changes)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(ctx, `
+		INSERT INTO audit_events (
+			id,
+			actor_id,
+			actor_role,
+			session_id,
+			client_ip,
+			service,
+			method,
+			resource,
+			target_id,
+			status,
+			changes
+		) VALUES (
+			$1, NULLIF($2, '')::uuid, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11
+		)`,
+		uuid.NewString(),
+		req.ActorId,
+		req.ActorRole,
+		req.SessionId,
+		req.ClientIp,
+		req.Service,
+		req.Method,
+		req.Resource,
+		req.TargetId,
+		req.Status,
+		changes,
+	)
+
+	if err != nil {
+		log.Println("error while creating audit event", err)
+		return err
+	}
+
+	return nil
+}
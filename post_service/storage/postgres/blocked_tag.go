@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type BlockedTagRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBlockedTagRepo(db *pgxpool.Pool) storage.BlockedTagRepoI {
+	return &BlockedTagRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.BlockedTagRepoI.
+// Blocking a blocked tag again updates the reason.
+func (s *BlockedTagRepo) Create(ctx context.Context, req *us.BlockedTag) (*us.BlockedTag, error) {
+	var (
+		resp       = &us.BlockedTag{}
+		created_at time.Time
+	)
+
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO blocked_tags (
+			tag,
+			reason,
+			blocked_by
+		) VALUES (
+			$1, $2, NULLIF($3, '')::uuid
+		)
+		ON CONFLICT (tag) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			blocked_by = EXCLUDED.blocked_by
+		RETURNING tag, reason, COALESCE(blocked_by::text, ''), created_at
+	`, req.Tag, req.Reason, req.BlockedBy).Scan(&resp.Tag, &resp.Reason, &resp.BlockedBy, &created_at)
+
+	if err != nil {
+		log.Println("error while blocking tag", err)
+		return nil, err
+	}
+
+	resp.CreatedAt = created_at.Format(time.RFC3339)
+
+	return resp, nil
+}
+
+// Delete implements storage.BlockedTagRepoI.
+func (s *BlockedTagRepo) Delete(ctx context.Context, req *us.TagSingleRequest) (*emptypb.Empty, error) {
+	_, err := s.db.Exec(ctx, `DELETE FROM blocked_tags WHERE tag = $1`, req.Tag)
+	if err != nil {
+		log.Println("error while unblocking tag", err)
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Blocked implements storage.BlockedTagRepoI.
+func (s *BlockedTagRepo) Blocked(ctx context.Context, tags []string) (map[string]bool, error) {
+	rows, err := s.db.Query(ctx, `SELECT tag FROM blocked_tags WHERE tag = ANY($1::text[])`, tags)
+	if err != nil {
+		log.Println("error while getting blocked tags:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocked := map[string]bool{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		blocked[tag] = true
+	}
+
+	return blocked, rows.Err()
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type BookmarkRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBookmarkRepo(db *pgxpool.Pool) storage.BookmarkRepoI {
+	return &BookmarkRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.BookmarkRepoI.
+// Saving a post again keeps the time it was first saved.
+func (s *BookmarkRepo) Create(ctx context.Context, req *us.BookmarkRequest) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if req.CollectionId != "" {
+		var ok bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM bookmark_collections WHERE id = $1 AND owner_id = $2)`,
+			req.CollectionId, req.UserId).Scan(&ok)
+		if err != nil {
+			log.Println("error while getting bookmark collection", err)
+			return err
+		}
+		if !ok {
+			return pgx.ErrNoRows
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO bookmarks (user_id, post_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, req.UserId, req.PostId)
+	if err != nil {
+		log.Println("error while creating bookmark", err)
+		return err
+	}
+
+	if req.CollectionId != "" {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO bookmark_collection_posts (collection_id, user_id, post_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`, req.CollectionId, req.UserId, req.PostId)
+		if err != nil {
+			log.Println("error while adding post to bookmark collection", err)
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete implements storage.BookmarkRepoI.
+// Without a collection the bookmark is deleted and the collection entries of the post with it.
+func (s *BookmarkRepo) Delete(ctx context.Context, req *us.BookmarkRequest) error {
+	var err error
+	if req.CollectionId != "" {
+		_, err = s.db.Exec(ctx, `
+			DELETE FROM bookmark_collection_posts
+			WHERE collection_id = $1 AND user_id = $2 AND post_id = $3`, req.CollectionId, req.UserId, req.PostId)
+	} else {
+		_, err = s.db.Exec(ctx, `DELETE FROM bookmarks WHERE user_id = $1 AND post_id = $2`, req.UserId, req.PostId)
+	}
+
+	if err != nil {
+		log.Println("error while deleting bookmark", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetList implements storage.BookmarkRepoI.
+// Posts are ordered by the time they were saved and the cursor points at that time, posts the viewer
+// can no longer read are left out.
+func (s *BookmarkRepo) GetList(ctx context.Context, req *us.GetListBookmarkRequest) (*us.PostList, error) {
+	var (
+		resp  = &us.PostList{}
+		args  = []interface{}{req.ViewerId}
+		saved = `SELECT post_id AS id, created_at FROM bookmarks WHERE user_id = $2`
+	)
+
+	if req.CollectionId != "" {
+		args = append(args, req.CollectionId)
+		saved = `SELECT post_id AS id, created_at FROM bookmark_collection_posts WHERE collection_id = $2`
+	} else {
+		args = append(args, req.UserId)
+	}
+
+	filter, args, err := page{alias: "b.", cursor: req.Cursor, limit: req.Limit}.apply(" WHERE "+postVisible("$1"), args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT`+postColumns("$1")+`,
+			b.created_at
+		FROM (`+saved+`) b
+		JOIN posts p ON p.id = b.id`+filter, args...)
+	if err != nil {
+		log.Println("error while getting bookmarks:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lastSavedAt time.Time
+	for rows.Next() {
+		var savedAt time.Time
+
+		post, _, err := scanPost(rows, &savedAt)
+		if err != nil {
+			log.Println("error while scanning bookmarks:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastSavedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
+		resp.Items = append(resp.Items, post)
+		lastSavedAt = savedAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// collectionColumns selects a bookmark collection aliased c.
+const collectionColumns = `
+		c.id,
+		c.owner_id,
+		c.name,
+		c.shared,
+		c.position,
+		(SELECT COUNT(*) FROM bookmark_collection_posts cp WHERE cp.collection_id = c.id),
+		c.created_at,
+		c.updated_at`
+
+func scanCollection(row pgx.Row) (*us.BookmarkCollection, error) {
+	var (
+		collection           = &us.BookmarkCollection{}
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&collection.Id, &collection.OwnerId, &collection.Name, &collection.Shared, &collection.Position, &collection.PostCount, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.CreatedAt = createdAt.Format(time.RFC3339)
+	collection.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return collection, nil
+}
+
+type BookmarkCollectionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBookmarkCollectionRepo(db *pgxpool.Pool) storage.BookmarkCollectionRepoI {
+	return &BookmarkCollectionRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.BookmarkCollectionRepoI.
+// A new collection is put last, the existing one is returned if the owner has one with the name.
+func (s *BookmarkCollectionRepo) Create(ctx context.Context, req *us.BookmarkCollection) (*us.BookmarkCollection, error) {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO bookmark_collections (
+			id,
+			owner_id,
+			name,
+			shared,
+			position
+		) VALUES (
+			$1, $2, $3, $4,
+			COALESCE((SELECT MAX(position) + 1 FROM bookmark_collections WHERE owner_id = $2), 0)
+		)
+		ON CONFLICT (owner_id, lower(name)) DO NOTHING`, uuid.NewString(), req.OwnerId, req.Name, req.Shared)
+	if err != nil {
+		log.Println("error while creating bookmark collection", err)
+		return nil, err
+	}
+
+	resp, err := scanCollection(s.db.QueryRow(ctx, `
+		SELECT`+collectionColumns+`
+		FROM bookmark_collections c
+		WHERE c.owner_id = $1 AND lower(c.name) = lower($2)`, req.OwnerId, req.Name))
+	if err != nil {
+		log.Println("error while getting bookmark collection after creating", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetSingle implements storage.BookmarkCollectionRepoI.
+func (s *BookmarkCollectionRepo) GetSingle(ctx context.Context, req *us.BookmarkCollectionSingleRequest) (*us.BookmarkCollection, error) {
+	resp, err := scanCollection(s.db.QueryRow(ctx, `
+		SELECT`+collectionColumns+`
+		FROM bookmark_collections c
+		WHERE c.id = $1`, req.Id))
+	if err != nil {
+		log.Println("error while getting bookmark collection", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetList implements storage.BookmarkCollectionRepoI.
+// Collections are ordered by position, other users than the owner only get the shared ones.
+func (s *BookmarkCollectionRepo) GetList(ctx context.Context, req *us.GetListBookmarkCollectionRequest) (*us.BookmarkCollectionList, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT`+collectionColumns+`
+		FROM bookmark_collections c
+		WHERE c.owner_id = $1 AND (c.shared OR c.owner_id = NULLIF($2, '')::uuid)
+		ORDER BY c.position, c.created_at, c.id`, req.OwnerId, req.ViewerId)
+	if err != nil {
+		log.Println("error while getting bookmark collections:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &us.BookmarkCollectionList{}
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			log.Println("error while scanning bookmark collections:", err)
+			return nil, err
+		}
+		resp.Items = append(resp.Items, collection)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+	resp.Count = int64(len(resp.Items))
+
+	return resp, nil
+}
+
+// Update implements storage.BookmarkCollectionRepoI.
+func (s *BookmarkCollectionRepo) Update(ctx context.Context, req *us.BookmarkCollection) (*us.BookmarkCollection, error) {
+	tag, err := s.db.Exec(ctx, `
+		UPDATE bookmark_collections SET
+			name = $3,
+			shared = $4,
+			updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2`, req.Id, req.OwnerId, req.Name, req.Shared)
+	if err != nil {
+		log.Println("error while updating bookmark collection", err)
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	return s.GetSingle(ctx, &us.BookmarkCollectionSingleRequest{Id: req.Id})
+}
+
+// Delete implements storage.BookmarkCollectionRepoI.
+// The posts of the collection stay bookmarked.
+func (s *BookmarkCollectionRepo) Delete(ctx context.Context, req *us.BookmarkCollectionSingleRequest) error {
+	tag, err := s.db.Exec(ctx, `DELETE FROM bookmark_collections WHERE id = $1 AND owner_id = $2`, req.Id, req.ViewerId)
+	if err != nil {
+		log.Println("error while deleting bookmark collection", err)
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Reorder implements storage.BookmarkCollectionRepoI.
+// Ids of collections the owner does not have are ignored.
+func (s *BookmarkCollectionRepo) Reorder(ctx context.Context, req *us.ReorderBookmarkCollectionsRequest) (*us.BookmarkCollectionList, error) {
+	_, err := s.db.Exec(ctx, `
+		WITH ordered AS (
+			SELECT
+				c.id,
+				ROW_NUMBER() OVER (ORDER BY array_position($2::uuid[], c.id) NULLS LAST, c.position, c.created_at, c.id) - 1 AS position
+			FROM bookmark_collections c
+			WHERE c.owner_id = $1
+		)
+		UPDATE bookmark_collections c SET
+			position = o.position,
+			updated_at = NOW()
+		FROM ordered o
+		WHERE c.id = o.id AND c.position <> o.position`, req.OwnerId, req.Ids)
+	if err != nil {
+		log.Println("error while reordering bookmark collections", err)
+		return nil, err
+	}
+
+	return s.GetList(ctx, &us.GetListBookmarkCollectionRequest{OwnerId: req.OwnerId, ViewerId: req.OwnerId})
+}
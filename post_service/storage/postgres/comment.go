@@ -0,0 +1,285 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type CommentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCommentRepo(db *pgxpool.Pool) storage.CommentRepoI {
+	return &CommentRepo{
+		db: db,
+	}
+}
+
+// commentColumns are selected with the viewer id as $1.
+// The content of deleted and removed comments is kept for moderation but never returned.
+var commentColumns = `
+	id,
+	post_id,
+	COALESCE(parent_id::text, ''),
+	author_id,
+	CASE WHEN status = 'active' THEN content ELSE '' END,
+	depth,
+	status,
+	reply_count,
+	reaction_counts,
+	` + viewerReaction("comment", "comments.id", "$1") + `,
+	edited_at,
+	created_at,
+	updated_at`
+
+func scanComment(row pgx.Row) (*us.Comment, time.Time, error) {
+	var (
+		comment                = &us.Comment{}
+		edited_at              sql.NullTime
+		created_at, updated_at time.Time
+	)
+
+	err := row.Scan(
+		&comment.Id,
+		&comment.PostId,
+		&comment.ParentId,
+		&comment.AuthorId,
+		&comment.Content,
+		&comment.Depth,
+		&comment.Status,
+		&comment.ReplyCount,
+		&comment.ReactionCounts,
+		&comment.ViewerReaction,
+		&edited_at,
+		&created_at,
+		&updated_at,
+	)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if edited_at.Valid {
+		comment.EditedAt = edited_at.Time.Format(time.RFC3339)
+	}
+	comment.CreatedAt = created_at.Format(time.RFC3339)
+	comment.UpdatedAt = updated_at.Format(time.RFC3339)
+
+	return comment, created_at, nil
+}
+
+// Create implements storage.CommentRepoI.
+// The reply count of the parent and the comment count of the post are updated in the same transaction.
+func (s *CommentRepo) Create(ctx context.Context, req *us.Comment) (*us.Comment, error) {
+	id := uuid.NewString()
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO comments (
+			id,
+			post_id,
+			parent_id,
+			author_id,
+			content,
+			depth
+		) VALUES (
+			$1, $2, NULLIF($3, '')::uuid, $4, $5, $6
+		)`, id, req.PostId, req.ParentId, req.AuthorId, req.Content, req.Depth)
+
+	if err != nil {
+		log.Println("error while creating comment", err)
+		return nil, err
+	}
+
+	if req.ParentId != "" {
+		_, err = tx.Exec(ctx, `UPDATE comments SET reply_count = reply_count + 1 WHERE id = $1`, req.ParentId)
+		if err != nil {
+			log.Println("error while updating reply count", err)
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE posts SET comment_count = comment_count + 1 WHERE id = $1`, req.PostId)
+	if err != nil {
+		log.Println("error while updating comment count", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.GetSingle(ctx, &us.CommentSingleRequest{Id: id})
+	if err != nil {
+		log.Println("error while getting comment after creating", err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetSingle implements storage.CommentRepoI.
+func (s *CommentRepo) GetSingle(ctx context.Context, req *us.CommentSingleRequest) (*us.Comment, error) {
+	comment, _, err := scanComment(s.db.QueryRow(ctx, `SELECT`+commentColumns+` FROM comments WHERE id = $2`, "", req.Id))
+	if err != nil {
+		log.Println("error while getting comment", err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetList implements storage.CommentRepoI.
+// Comments are listed oldest first with keyset pagination on (created_at, id).
+// Deleted and removed comments are kept as placeholders while they still have replies.
+func (s *CommentRepo) GetList(ctx context.Context, req *us.GetListCommentRequest) (*us.CommentList, error) {
+	var (
+		resp   = &us.CommentList{}
+		args   = []interface{}{req.ViewerId, req.PostId}
+		filter = ` WHERE post_id = $2 AND (status = 'active' OR reply_count > 0)`
+	)
+
+	if req.ParentId != "" {
+		args = append(args, req.ParentId)
+		filter += fmt.Sprintf(" AND parent_id = $%d", len(args))
+	} else {
+		filter += " AND parent_id IS NULL"
+	}
+
+	if req.Cursor != "" {
+		createdAt, id, err := cursor.Decode(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, createdAt, id)
+		filter += fmt.Sprintf(" AND (created_at, id) > ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	// one extra row tells whether there is a next page
+	filter += fmt.Sprintf(" ORDER BY created_at, id LIMIT %d", req.Limit+1)
+
+	rows, err := s.db.Query(ctx, `SELECT`+commentColumns+` FROM comments`+filter, args...)
+	if err != nil {
+		log.Println("error while getting comments:", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var lastCreatedAt time.Time
+	for rows.Next() {
+		comment, createdAt, err := scanComment(rows)
+		if err != nil {
+			log.Println("error while scanning comments:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
+		resp.Items = append(resp.Items, comment)
+		lastCreatedAt = createdAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Update implements storage.CommentRepoI.
+// Only the content of an active comment can be edited.
+func (s *CommentRepo) Update(ctx context.Context, req *us.Comment) (*us.Comment, error) {
+	tag, err := s.db.Exec(ctx, `
+		UPDATE comments SET
+			content = $1,
+			edited_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $2 AND status = 'active'`, req.Content, req.Id)
+
+	if err != nil {
+		log.Println("error while updating comment", err)
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	return s.GetSingle(ctx, &us.CommentSingleRequest{Id: req.Id})
+}
+
+// Delete implements storage.CommentRepoI.
+// Comments are soft deleted so the replies stay in place, moderators mark them as removed.
+func (s *CommentRepo) Delete(ctx context.Context, req *us.DeleteCommentRequest) (*emptypb.Empty, error) {
+	var (
+		status    = "deleted"
+		removedBy sql.NullString
+		postID    string
+		parentID  sql.NullString
+	)
+
+	if req.Moderator {
+		status = "removed"
+		removedBy = sql.NullString{String: req.RequestedBy, Valid: true}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE comments SET
+			status = $1,
+			removed_by = $2,
+			updated_at = NOW()
+		WHERE id = $3 AND status = 'active'
+		RETURNING post_id, parent_id`, status, removedBy, req.Id).Scan(&postID, &parentID)
+
+	if err != nil {
+		log.Println("error while deleting comment:", err)
+		return &emptypb.Empty{}, err
+	}
+
+	if parentID.Valid {
+		_, err = tx.Exec(ctx, `UPDATE comments SET reply_count = GREATEST(reply_count - 1, 0) WHERE id = $1`, parentID.String)
+		if err != nil {
+			log.Println("error while updating reply count", err)
+			return &emptypb.Empty{}, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE posts SET comment_count = GREATEST(comment_count - 1, 0) WHERE id = $1`, postID)
+	if err != nil {
+		log.Println("error while updating comment count", err)
+		return &emptypb.Empty{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
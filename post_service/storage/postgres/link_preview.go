@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type LinkPreviewRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLinkPreviewRepo(db *pgxpool.Pool) storage.LinkPreviewRepoI {
+	return &LinkPreviewRepo{
+		db: db,
+	}
+}
+
+// ReplacePostLinks implements storage.LinkPreviewRepoI.
+func (s *LinkPreviewRepo) ReplacePostLinks(ctx context.Context, postID string, urls []string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM post_links WHERE post_id = $1`, postID)
+	if err != nil {
+		log.Println("error while deleting post links", err)
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_links (post_id, url, position)
+		SELECT $1, l.url, l.position - 1
+		FROM unnest($2::text[]) WITH ORDINALITY AS l(url, position)
+		ON CONFLICT DO NOTHING`, postID, urls)
+	if err != nil {
+		log.Println("error while creating post links", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Stale implements storage.LinkPreviewRepoI.
+func (s *LinkPreviewRepo) Stale(ctx context.Context, urls []string, ttl, failedTTL time.Duration) ([]string, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT u.url
+		FROM unnest($1::text[]) AS u(url)
+		LEFT JOIN link_previews l ON l.url = u.url
+		WHERE l.url IS NULL
+			OR (l.status = 'ok' AND l.fetched_at < LOCALTIMESTAMP - $2::interval)
+			OR (l.status = 'failed' AND l.fetched_at < LOCALTIMESTAMP - $3::interval)`, urls, ttl, failedTTL)
+	if err != nil {
+		log.Println("error while getting stale link previews:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stale []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			log.Println("error while scanning stale link previews:", err)
+			return nil, err
+		}
+		stale = append(stale, url)
+	}
+
+	return stale, rows.Err()
+}
+
+// Save implements storage.LinkPreviewRepoI.
+func (s *LinkPreviewRepo) Save(ctx context.Context, url string, preview *us.LinkPreview) error {
+	status := "ok"
+	if preview == nil {
+		status, preview = "failed", &us.LinkPreview{}
+	}
+
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO link_previews (
+			url,
+			status,
+			title,
+			description,
+			image_url,
+			site_name
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		ON CONFLICT (url) DO UPDATE SET
+			status = EXCLUDED.status,
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			image_url = EXCLUDED.image_url,
+			site_name = EXCLUDED.site_name,
+			fetched_at = NOW()`, url, status, preview.Title, preview.Description, preview.ImageUrl, preview.SiteName)
+	if err != nil {
+		log.Println("error while saving link preview:", err)
+	}
+
+	return err
+}
+
+// GetByPostIDs implements storage.LinkPreviewRepoI.
+func (s *LinkPreviewRepo) GetByPostIDs(ctx context.Context, postIDs []string) (map[string][]*us.LinkPreview, error) {
+	previews := map[string][]*us.LinkPreview{}
+	if len(postIDs) == 0 {
+		return previews, nil
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT
+			pl.post_id,
+			l.url,
+			l.title,
+			l.description,
+			l.image_url,
+			l.site_name
+		FROM post_links pl
+		JOIN link_previews l ON l.url = pl.url AND l.status = 'ok'
+		WHERE pl.post_id = ANY($1)
+		ORDER BY pl.post_id, pl.position`, postIDs)
+	if err != nil {
+		log.Println("error while getting link previews:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID  string
+			preview = &us.LinkPreview{}
+		)
+
+		err := rows.Scan(&postID, &preview.Url, &preview.Title, &preview.Description, &preview.ImageUrl, &preview.SiteName)
+		if err != nil {
+			log.Println("error while scanning link previews:", err)
+			return nil, err
+		}
+		previews[postID] = append(previews[postID], preview)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return previews, nil
+}
@@ -0,0 +1,390 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type MediaRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMediaRepo(db *pgxpool.Pool) storage.MediaRepoI {
+	return &MediaRepo{
+		db: db,
+	}
+}
+
+// mediaColumns selects a media row aliased m.
+const mediaColumns = `
+		m.key,
+		COALESCE(m.sha256, ''),
+		m.mime_type,
+		m.type,
+		m.size,
+		m.ref_count,
+		m.created_at`
+
+func scanMedia(row pgx.Row) (*us.Media, error) {
+	var (
+		media     = &us.Media{}
+		createdAt time.Time
+	)
+
+	if err := row.Scan(&media.Key, &media.Sha256, &media.MimeType, &media.Type, &media.Size, &media.RefCount, &createdAt); err != nil {
+		return nil, err
+	}
+	media.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return media, nil
+}
+
+// Create implements storage.MediaRepoI.
+// The media row is shared by the uploads of the same content, it gains a reference for each
+// owner that uploads it.
+func (s *MediaRepo) Create(ctx context.Context, req *us.Media) (*us.Media, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO media (
+			key,
+			sha256,
+			mime_type,
+			type,
+			size
+		) VALUES (
+			$1, NULLIF($2, ''), $3, $4, $5
+		)
+		ON CONFLICT (key) DO NOTHING`, req.Key, req.Sha256, req.MimeType, req.Type, req.Size)
+	if err != nil {
+		log.Println("error while creating media", err)
+		return nil, err
+	}
+
+	// the blobs of a released file are still stored until the gateway deletes them, uploading the
+	// content again keeps them
+	_, err = tx.Exec(ctx, `
+		DELETE FROM released_blobs
+		WHERE key = $1 OR key LIKE 'variants/' || $1 || '/%'`, req.Key)
+	if err != nil {
+		log.Println("error while keeping released blob", err)
+		return nil, err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO media_uploads (key, owner_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, req.Key, req.OwnerId)
+	if err != nil {
+		log.Println("error while creating media upload", err)
+		return nil, err
+	}
+
+	// uploading the same content again does not add a reference
+	var increment int
+	if tag.RowsAffected() > 0 {
+		increment = 1
+	}
+
+	media, err := scanMedia(tx.QueryRow(ctx, `
+		UPDATE media m SET ref_count = m.ref_count + $2
+		WHERE m.key = $1
+		RETURNING`+mediaColumns, req.Key, increment))
+	if err != nil {
+		log.Println("error while counting media references", err)
+		return nil, err
+	}
+	media.OwnerId = req.OwnerId
+	media.Created = increment > 0
+
+	return media, tx.Commit(ctx)
+}
+
+// GetByKeys implements storage.MediaRepoI.
+func (s *MediaRepo) GetByKeys(ctx context.Context, keys []string) ([]*us.Media, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT`+mediaColumns+`
+		FROM media m
+		WHERE m.key = ANY($1::text[])`, keys)
+	if err != nil {
+		log.Println("error while getting media", err)
+		return nil, err
+	}
+
+	return collectMedia(rows, "")
+}
+
+// GetUploaded implements storage.MediaRepoI.
+func (s *MediaRepo) GetUploaded(ctx context.Context, ownerID string, keys []string) ([]*us.Media, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT`+mediaColumns+`
+		FROM media m
+		JOIN media_uploads mu ON mu.key = m.key
+		WHERE mu.owner_id = $1 AND m.key = ANY($2::text[])`, ownerID, keys)
+	if err != nil {
+		log.Println("error while getting uploaded media", err)
+		return nil, err
+	}
+
+	return collectMedia(rows, ownerID)
+}
+
+func collectMedia(rows pgx.Rows, ownerID string) ([]*us.Media, error) {
+	defer rows.Close()
+
+	var resp []*us.Media
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			log.Println("error while scanning media", err)
+			return nil, err
+		}
+		media.OwnerId = ownerID
+		resp = append(resp, media)
+	}
+
+	return resp, rows.Err()
+}
+
+// Delete implements storage.MediaRepoI.
+// The media row goes with its last reference, the attachments that use it hold references too.
+func (s *MediaRepo) Delete(ctx context.Context, req *us.MediaDeleteRequest) (*us.Media, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM media_uploads WHERE key = $1 AND owner_id = $2`, req.Key, req.OwnerId)
+	if err != nil {
+		log.Println("error while deleting media upload", err)
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	media, err := scanMedia(tx.QueryRow(ctx, `
+		UPDATE media m SET ref_count = m.ref_count - 1
+		WHERE m.key = $1
+		RETURNING`+mediaColumns, req.Key))
+	if err != nil {
+		log.Println("error while counting media references", err)
+		return nil, err
+	}
+	media.OwnerId = req.OwnerId
+
+	if err := deleteReleasedMedia(ctx, tx, []string{req.Key}); err != nil {
+		return nil, err
+	}
+
+	return media, tx.Commit(ctx)
+}
+
+// ListReleased implements storage.MediaRepoI.
+func (s *MediaRepo) ListReleased(ctx context.Context, limit int64) ([]string, error) {
+	rows, err := s.db.Query(ctx, `SELECT key FROM released_blobs ORDER BY created_at LIMIT $1`, limit)
+	if err != nil {
+		log.Println("error while listing released blobs", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			log.Println("error while scanning released blobs", err)
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// ForgetReleased implements storage.MediaRepoI.
+func (s *MediaRepo) ForgetReleased(ctx context.Context, keys []string) error {
+	_, err := s.db.Exec(ctx, `DELETE FROM released_blobs WHERE key = ANY($1::text[])`, keys)
+	if err != nil {
+		log.Println("error while forgetting released blobs", err)
+	}
+
+	return err
+}
+
+// ClaimUnprocessed implements storage.MediaRepoI.
+// Photos locked by another replica are skipped, so a photo is processed by one replica at a time.
+func (s *MediaRepo) ClaimUnprocessed(ctx context.Context, req *us.ClaimUnprocessedRequest) (*us.MediaJobList, error) {
+	rows, err := s.db.Query(ctx, `
+		WITH due AS (
+			SELECT key
+			FROM media
+			WHERE processed_at IS NULL AND type = 'photo'
+				AND (processing_started_at IS NULL OR processing_started_at <= LOCALTIMESTAMP - make_interval(secs => $2))
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE media m SET
+			processing_started_at = LOCALTIMESTAMP
+		FROM due
+		WHERE m.key = due.key
+		RETURNING m.key, m.mime_type`, req.Limit, req.LeaseSeconds)
+	if err != nil {
+		log.Println("error while claiming unprocessed media", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &us.MediaJobList{}
+	for rows.Next() {
+		job := &us.MediaJob{}
+		if err := rows.Scan(&job.Key, &job.MimeType); err != nil {
+			log.Println("error while scanning unprocessed media", err)
+			return nil, err
+		}
+		resp.Items = append(resp.Items, job)
+	}
+
+	return resp, rows.Err()
+}
+
+// SaveProcessed implements storage.MediaRepoI.
+// The variants replace the ones of an earlier run, the original stays as it was uploaded.
+func (s *MediaRepo) SaveProcessed(ctx context.Context, req *us.ProcessedMedia) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE media SET
+			stripped_key = $2,
+			width = $3,
+			height = $4,
+			blurhash = $5,
+			processed_at = LOCALTIMESTAMP,
+			processing_error = NULL
+		WHERE key = $1`, req.Key, req.StrippedKey, req.Width, req.Height, req.Blurhash)
+	if err != nil {
+		log.Println("error while saving processed media", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM media_variants WHERE media_key = $1`, req.Key); err != nil {
+		log.Println("error while deleting media variants", err)
+		return err
+	}
+
+	for _, variant := range req.Variants {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO media_variants (
+				id,
+				media_key,
+				object_key,
+				mime_type,
+				width,
+				height,
+				size
+			) VALUES (
+				$1, $2, $3, $4, $5, $6, $7
+			)`, uuid.NewString(), req.Key, variant.ObjectKey, variant.MimeType, variant.Width, variant.Height, variant.Size)
+		if err != nil {
+			log.Println("error while inserting media variant", err)
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FailProcessing implements storage.MediaRepoI.
+func (s *MediaRepo) FailProcessing(ctx context.Context, req *us.FailProcessingRequest) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE media SET
+			processed_at = LOCALTIMESTAMP,
+			processing_error = $2
+		WHERE key = $1`, req.Key, req.Reason)
+	if err != nil {
+		log.Println("error while marking media processing failed", err)
+	}
+
+	return err
+}
+
+// referenceMedia adds a reference to the media stored under each of keys, once per time the key
+// is given. Attachments without an object key pass an empty key, which refers to nothing.
+func referenceMedia(ctx context.Context, tx pgx.Tx, keys []string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE media m SET ref_count = m.ref_count + used.n
+		FROM (SELECT key, COUNT(*) AS n FROM unnest($1::text[]) AS key GROUP BY key) used
+		WHERE m.key = used.key`, keys)
+	if err != nil {
+		log.Println("error while counting media references", err)
+	}
+
+	return err
+}
+
+// releaseAttachments drops the references the attachments matched by filter, a condition on
+// post_attachment pa, hold on their media, before the attachments are deleted.
+func releaseAttachments(ctx context.Context, tx pgx.Tx, filter string, args ...interface{}) error {
+	var keys []string
+	err := tx.QueryRow(ctx, `
+		WITH released AS (
+			UPDATE media m SET ref_count = m.ref_count - used.n
+			FROM (
+				SELECT pa.object_key, COUNT(*) AS n
+				FROM post_attachment pa
+				WHERE pa.object_key IS NOT NULL AND `+filter+`
+				GROUP BY pa.object_key
+			) used
+			WHERE m.key = used.object_key
+			RETURNING m.key
+		)
+		SELECT COALESCE(array_agg(key), '{}') FROM released`, args...).Scan(&keys)
+	if err != nil {
+		log.Println("error while releasing attachment media", err)
+		return err
+	}
+
+	return deleteReleasedMedia(ctx, tx, keys)
+}
+
+// deleteReleasedMedia deletes the media stored under keys that are left without references. Their
+// blobs, stripped copies and variants are queued in released_blobs for the gateway to delete.
+func deleteReleasedMedia(ctx context.Context, tx pgx.Tx, keys []string) error {
+	_, err := tx.Exec(ctx, `
+		WITH released AS (
+			DELETE FROM media m
+			WHERE m.key = ANY($1::text[]) AND m.ref_count <= 0
+			RETURNING m.key, m.stripped_key
+		)
+		INSERT INTO released_blobs (key)
+		SELECT key FROM released
+		UNION
+		SELECT stripped_key FROM released WHERE stripped_key IS NOT NULL
+		UNION
+		SELECT mv.object_key FROM media_variants mv JOIN released ON released.key = mv.media_key
+		ON CONFLICT DO NOTHING`, keys)
+	if err != nil {
+		log.Println("error while deleting released media", err)
+	}
+
+	return err
+}
@@ -0,0 +1,98 @@
+package postgres_test
+
+import (
+	"context"
+	us "post_service/genproto/post_service"
+	"post_service/storage/postgres"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaReleasedWithAttachments(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	owner := testUser(t, db)
+
+	media := postgres.NewMediaRepo(db)
+	key := "sha256/" + uuid.NewString()
+	_, err := media.Create(ctx, &us.Media{
+		Key:      key,
+		OwnerId:  owner,
+		MimeType: "image/png",
+		Type:     "photo",
+		Size:     10,
+	})
+	require.NoError(t, err)
+	variant, stripped := "variants/"+key+"/320.webp", "variants/"+key+"/original.png"
+	t.Cleanup(func() {
+		_ = media.ForgetReleased(ctx, []string{key, variant, stripped})
+	})
+
+	post, err := postgres.NewPostRepo(db).Create(ctx, &us.Post{
+		OwnerId:    owner,
+		Content:    "photo",
+		Status:     "published",
+		Visibility: "public",
+	})
+	require.NoError(t, err)
+
+	attachments := postgres.NewAttachmentRepo(db)
+	for i := 0; i < 2; i++ {
+		_, err = attachments.Create(ctx, &us.Attachment{
+			PostId:      post.Id,
+			Filepath:    key,
+			ContentType: "photo",
+			ObjectKey:   key,
+		})
+		require.NoError(t, err)
+	}
+
+	// the photo is processed once for both attachments
+	jobs, err := media.ClaimUnprocessed(ctx, &us.ClaimUnprocessedRequest{Limit: 1000, LeaseSeconds: 60})
+	require.NoError(t, err)
+	var claimed int
+	for _, job := range jobs.Items {
+		if job.Key == key {
+			claimed++
+		}
+	}
+	assert.Equal(t, 1, claimed)
+
+	require.NoError(t, media.SaveProcessed(ctx, &us.ProcessedMedia{
+		Key:         key,
+		StrippedKey: stripped,
+		Width:       1,
+		Height:      1,
+		Variants:    []*us.AttachmentVariant{{ObjectKey: variant, MimeType: "image/webp", Width: 1, Height: 1, Size: 1}},
+	}))
+
+	list, err := attachments.GetList(ctx, &us.GetListAttachmentRequest{Search: post.Id, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 2)
+	for _, attachment := range list.Items {
+		assert.Equal(t, key, attachment.ObjectKey)
+		assert.Equal(t, stripped, attachment.StrippedKey)
+		assert.Len(t, attachment.Variants, 1)
+	}
+
+	// the attachments keep the file after its upload is deleted
+	deleted, err := media.Delete(ctx, &us.MediaDeleteRequest{Key: key, OwnerId: owner})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, deleted.RefCount)
+
+	_, err = attachments.Delete(ctx, &us.AttachmentSingleRequest{Id: post.Id})
+	require.NoError(t, err)
+
+	left, err := media.GetByKeys(ctx, []string{key})
+	require.NoError(t, err)
+	assert.Empty(t, left)
+
+	released, err := media.ListReleased(ctx, 1000)
+	require.NoError(t, err)
+	assert.Contains(t, released, key)
+	assert.Contains(t, released, variant)
+	assert.Contains(t, released, stripped)
+}
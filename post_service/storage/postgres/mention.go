@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type MentionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewMentionRepo(db *pgxpool.Pool) storage.MentionRepoI {
+	return &MentionRepo{
+		db: db,
+	}
+}
+
+// Replace implements storage.MentionRepoI.
+func (s *MentionRepo) Replace(ctx context.Context, postID string, mentions []*us.Mention) error {
+	var (
+		userIDs   = []string{}
+		usernames = []string{}
+		starts    = []int32{}
+		lengths   = []int32{}
+	)
+	for _, m := range mentions {
+		userIDs = append(userIDs, m.UserId)
+		usernames = append(usernames, m.Username)
+		starts = append(starts, m.Offset)
+		lengths = append(lengths, m.Length)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM post_mentions WHERE post_id = $1`, postID)
+	if err != nil {
+		log.Println("error while deleting post mentions", err)
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_mentions (post_id, user_id, username, start, length)
+		SELECT $1, m.user_id, m.username, m.start, m.length
+		FROM unnest($2::uuid[], $3::text[], $4::int[], $5::int[]) AS m(user_id, username, start, length)
+		ON CONFLICT DO NOTHING`, postID, userIDs, usernames, starts, lengths)
+	if err != nil {
+		log.Println("error while creating post mentions", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetPosts implements storage.MentionRepoI.
+func (s *MentionRepo) GetPosts(ctx context.Context, req *us.GetMentionPostsRequest) (*us.PostList, error) {
+	var (
+		resp = &us.PostList{}
+		args = []interface{}{req.ViewerId, req.UserId}
+	)
+
+	filter := ` WHERE p.status = 'published' AND EXISTS (
+		SELECT 1 FROM post_mentions pm WHERE pm.post_id = p.id AND pm.user_id = $2
+	)` + " AND " + postVisible("$1")
+
+	filter, args, err := page{alias: "p.", cursor: req.Cursor, limit: req.Limit}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT`+postColumns("$1")+` FROM posts p`+filter, args...)
+	if err != nil {
+		log.Println("error while getting mention posts:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lastCreatedAt time.Time
+	for rows.Next() {
+		post, createdAt, err := scanPost(rows)
+		if err != nil {
+			log.Println("error while scanning mention posts:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
+		resp.Items = append(resp.Items, post)
+		lastCreatedAt = createdAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
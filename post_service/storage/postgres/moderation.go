@@ -0,0 +1,250 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// moderationColumns selects a moderation action aliased m.
+const moderationColumns = `
+		m.id,
+		m.target_type,
+		m.target_id,
+		m.action,
+		COALESCE(m.user_id::text, ''),
+		COALESCE(m.moderator_id::text, ''),
+		m.note,
+		m.report_count,
+		m.created_at,
+		COALESCE(m.job_id::text, '')`
+
+func scanModerationAction(row pgx.Row) (*us.ModerationAction, time.Time, error) {
+	var (
+		action    = &us.ModerationAction{}
+		createdAt time.Time
+	)
+
+	err := row.Scan(&action.Id, &action.TargetType, &action.TargetId, &action.Action, &action.UserId, &action.ModeratorId, &action.Note, &action.ReportCount, &createdAt, &action.JobId)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	action.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return action, createdAt, nil
+}
+
+type ModerationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewModerationRepo(db *pgxpool.Pool) storage.ModerationRepoI {
+	return &ModerationRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.ModerationRepoI.
+func (s *ModerationRepo) Create(ctx context.Context, req *us.ModerationAction) (*us.ModerationAction, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	id := uuid.NewString()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO moderation_actions (
+			id,
+			target_type,
+			target_id,
+			action,
+			user_id,
+			moderator_id,
+			note
+		) VALUES (
+			$1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, '')::uuid, $7
+		)`, id, req.TargetType, req.TargetId, req.Action, req.UserId, req.ModeratorId, req.Note)
+	if err != nil {
+		log.Println("error while creating moderation action:", err)
+		return nil, err
+	}
+
+	switch req.Action {
+	case "hide_post":
+		var repostOf sql.NullString
+		err = tx.QueryRow(ctx, `
+			UPDATE posts SET
+				status = 'hidden',
+				updated_at = NOW()
+			WHERE id = $1 AND status = 'published'
+			RETURNING repost_of`, req.TargetId).Scan(&repostOf)
+		if err == pgx.ErrNoRows {
+			return nil, storage.ErrPostNotPublished
+		}
+		if err != nil {
+			log.Println("error while hiding post:", err)
+			return nil, err
+		}
+
+		// a hidden quote no longer counts as a repost of its original
+		if repostOf.Valid {
+			if err := recountReposts(ctx, tx, repostOf.String); err != nil {
+				return nil, err
+			}
+		}
+	case "delete_post":
+		if err := deletePost(ctx, tx, req.TargetId); err != nil {
+			return nil, err
+		}
+	}
+
+	reportStatus := "resolved"
+	if req.Action == "dismiss" {
+		reportStatus = "dismissed"
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE reports SET
+			status = $3,
+			action_id = $4,
+			resolved_at = NOW(),
+			updated_at = NOW()
+		WHERE target_type = $1 AND target_id = $2 AND status = 'open'`, req.TargetType, req.TargetId, reportStatus, id)
+	if err != nil {
+		log.Println("error while resolving reports:", err)
+		return nil, err
+	}
+
+	resp, _, err := scanModerationAction(tx.QueryRow(ctx, `
+		UPDATE moderation_actions m SET
+			report_count = $2
+		WHERE m.id = $1
+		RETURNING`+moderationColumns, id, tag.RowsAffected()))
+	if err != nil {
+		log.Println("error while counting resolved reports:", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetJob implements storage.ModerationRepoI.
+func (s *ModerationRepo) SetJob(ctx context.Context, id, jobID string) error {
+	_, err := s.db.Exec(ctx, `UPDATE moderation_actions SET job_id = $2 WHERE id = $1`, id, jobID)
+	if err != nil {
+		log.Println("error while setting moderation job:", err)
+	}
+
+	return err
+}
+
+// Delete implements storage.ModerationRepoI.
+// The reports the action resolved are opened again, unless their reporter reported the target
+// again since.
+func (s *ModerationRepo) Delete(ctx context.Context, id string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE reports r SET
+			status = 'open',
+			action_id = NULL,
+			resolved_at = NULL,
+			updated_at = NOW()
+		WHERE r.action_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM reports o
+			WHERE o.status = 'open' AND o.reporter_id = r.reporter_id
+				AND o.target_type = r.target_type AND o.target_id = r.target_id
+		)`, id)
+	if err != nil {
+		log.Println("error while reopening reports:", err)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM moderation_actions WHERE id = $1`, id); err != nil {
+		log.Println("error while deleting moderation action:", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetList implements storage.ModerationRepoI.
+func (s *ModerationRepo) GetList(ctx context.Context, req *us.GetListModerationActionRequest) (*us.ModerationActionList, error) {
+	var (
+		resp   = &us.ModerationActionList{}
+		args   []interface{}
+		filter = " WHERE TRUE"
+	)
+
+	if req.TargetType != "" {
+		args = append(args, req.TargetType)
+		filter += fmt.Sprintf(" AND m.target_type = $%d", len(args))
+	}
+
+	if req.TargetId != "" {
+		args = append(args, req.TargetId)
+		filter += fmt.Sprintf(" AND m.target_id = $%d", len(args))
+	}
+
+	if req.UserId != "" {
+		args = append(args, req.UserId)
+		filter += fmt.Sprintf(" AND m.user_id = $%d", len(args))
+	}
+
+	filter, args, err := page{alias: "m.", cursor: req.Cursor, limit: req.Limit}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT`+moderationColumns+` FROM moderation_actions m`+filter, args...)
+	if err != nil {
+		log.Println("error while getting moderation actions:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lastCreatedAt time.Time
+	for rows.Next() {
+		action, createdAt, err := scanModerationAction(rows)
+		if err != nil {
+			log.Println("error while scanning moderation actions:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
+		resp.Items = append(resp.Items, action)
+		lastCreatedAt = createdAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,60 @@
+package postgres_test
+
+import (
+	"context"
+	us "post_service/genproto/post_service"
+	"post_service/storage/postgres"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationDeleteReopensReports(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	reporter, user, moderator := testUser(t, db), testUser(t, db), testUser(t, db)
+
+	reports := postgres.NewReportRepo(db)
+	_, err := reports.Create(ctx, &us.Report{
+		TargetType: "user",
+		TargetId:   user,
+		ReporterId: reporter,
+		Reason:     "spam",
+	})
+	require.NoError(t, err)
+
+	repo := postgres.NewModerationRepo(db)
+	action, err := repo.Create(ctx, &us.ModerationAction{
+		TargetType:  "user",
+		TargetId:    user,
+		Action:      "block_user",
+		UserId:      user,
+		ModeratorId: moderator,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, action.ReportCount)
+	t.Cleanup(func() {
+		_, _ = db.Exec(ctx, `DELETE FROM moderation_actions WHERE id = $1`, action.Id)
+	})
+
+	jobID := uuid.NewString()
+	require.NoError(t, repo.SetJob(ctx, action.Id, jobID))
+
+	actions, err := repo.GetList(ctx, &us.GetListModerationActionRequest{UserId: user, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, actions.Items, 1)
+	assert.Equal(t, jobID, actions.Items[0].JobId)
+
+	require.NoError(t, repo.Delete(ctx, action.Id))
+
+	open, err := reports.GetList(ctx, &us.GetListReportRequest{TargetType: "user", TargetId: user, Status: "open", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, open.Items, 1)
+	assert.Empty(t, open.Items[0].ActionId)
+
+	actions, err = repo.GetList(ctx, &us.GetListModerationActionRequest{UserId: user, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, actions.Items)
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"fmt"
+	"post_service/pkg/cursor"
+)
+
+// page describes a keyset paginated list ordered by created_at and id.
+type page struct {
+	// column prefix of the paginated table, e.g. "p."
+	alias  string
+	cursor string
+	// offset page, only used by clients that do not send a cursor yet
+	number uint64
+	limit  uint64
+	asc    bool
+}
+
+// apply appends the cursor condition, the order and the limit to filter, which must already
+// contain a WHERE clause. One extra row is fetched to tell whether there is a next page.
+func (p page) apply(filter string, args []interface{}) (string, []interface{}, error) {
+	order, cmp := "DESC", "<"
+	if p.asc {
+		order, cmp = "ASC", ">"
+	}
+
+	if p.cursor != "" {
+		createdAt, id, err := cursor.Decode(p.cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, createdAt, id)
+		filter += fmt.Sprintf(" AND (%[1]screated_at, %[1]sid) %[2]s ($%[3]d, $%[4]d)", p.alias, cmp, len(args)-1, len(args))
+	}
+
+	filter += fmt.Sprintf(" ORDER BY %[1]screated_at %[2]s, %[1]sid %[2]s LIMIT %[3]d", p.alias, order, p.limit+1)
+
+	if p.cursor == "" && p.number > 1 {
+		filter += fmt.Sprintf(" OFFSET %d", (p.number-1)*p.limit)
+	}
+
+	return filter, args, nil
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// createPoll stores the poll of a new post, options keep their order.
+func createPoll(ctx context.Context, tx pgx.Tx, postID string, poll *us.Poll) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO polls (
+			post_id,
+			multiple,
+			closes_at
+		) VALUES (
+			$1, $2, $3
+		)`, postID, poll.Multiple, poll.ClosesAt)
+	if err != nil {
+		return err
+	}
+
+	for i, option := range poll.Options {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO poll_options (
+				id,
+				post_id,
+				position,
+				text
+			) VALUES (
+				$1, $2, $3, $4
+			)`, uuid.NewString(), postID, i, option.Text)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type PollRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPollRepo(db *pgxpool.Pool) storage.PollRepoI {
+	return &PollRepo{
+		db: db,
+	}
+}
+
+// GetByPostIDs implements storage.PollRepoI.
+func (s *PollRepo) GetByPostIDs(ctx context.Context, postIDs []string, viewerID string) (map[string]*us.Poll, error) {
+	polls := map[string]*us.Poll{}
+	if len(postIDs) == 0 {
+		return polls, nil
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT
+			p.post_id,
+			p.multiple,
+			p.closes_at,
+			p.closes_at <= `+utcNow+`,
+			p.voter_count,
+			o.id,
+			o.text,
+			o.vote_count,
+			v.user_id IS NOT NULL
+		FROM polls p
+		JOIN poll_options o ON o.post_id = p.post_id
+		LEFT JOIN poll_votes v ON v.option_id = o.id AND v.user_id = NULLIF($2::text, '')::uuid
+		WHERE p.post_id = ANY($1)
+		ORDER BY p.post_id, o.position`, postIDs, viewerID)
+	if err != nil {
+		log.Println("error while getting polls:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID   string
+			poll     = &us.Poll{}
+			option   = &us.PollOption{}
+			closesAt time.Time
+			chosen   bool
+		)
+
+		err := rows.Scan(&postID, &poll.Multiple, &closesAt, &poll.Closed, &poll.VoterCount, &option.Id, &option.Text, &option.VoteCount, &chosen)
+		if err != nil {
+			log.Println("error while scanning polls:", err)
+			return nil, err
+		}
+
+		if existing, ok := polls[postID]; ok {
+			poll = existing
+		} else {
+			poll.ClosesAt = closesAt.Format(time.RFC3339)
+			polls[postID] = poll
+		}
+
+		poll.Options = append(poll.Options, option)
+		if chosen {
+			poll.ViewerChoices = append(poll.ViewerChoices, option.Id)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return polls, nil
+}
+
+// Vote implements storage.PollRepoI.
+// The counters are incremented in place, so concurrent votes do not overwrite each other.
+func (s *PollRepo) Vote(ctx context.Context, req *us.PollVoteRequest) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// a second vote of the user violates the primary key of poll_voters
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO poll_voters (post_id, user_id)
+		SELECT post_id, $2 FROM polls
+		WHERE post_id = $1 AND closes_at > `+utcNow, req.PostId, req.UserId)
+	if err != nil {
+		log.Println("error while adding poll voter:", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrPollClosed
+	}
+
+	tag, err = tx.Exec(ctx, `
+		INSERT INTO poll_votes (post_id, option_id, user_id)
+		SELECT post_id, id, $3 FROM poll_options
+		WHERE post_id = $1 AND id = ANY($2)`, req.PostId, req.OptionIds, req.UserId)
+	if err != nil {
+		log.Println("error while adding poll votes:", err)
+		return err
+	}
+	if tag.RowsAffected() != int64(len(req.OptionIds)) {
+		return pgx.ErrNoRows
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE poll_options SET vote_count = vote_count + 1 WHERE post_id = $1 AND id = ANY($2)`, req.PostId, req.OptionIds)
+	if err != nil {
+		log.Println("error while counting poll votes:", err)
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE polls SET voter_count = voter_count + 1 WHERE post_id = $1`, req.PostId)
+	if err != nil {
+		log.Println("error while counting poll voters:", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,44 @@
+package postgres_test
+
+import (
+	"context"
+	us "post_service/genproto/post_service"
+	"post_service/storage/postgres"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollClosesAt(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	owner := testUser(t, db)
+
+	post, err := postgres.NewPostRepo(db).Create(ctx, &us.Post{
+		OwnerId:    owner,
+		Content:    "poll",
+		Status:     "published",
+		Visibility: "public",
+		Poll: &us.Poll{
+			Options:  []*us.PollOption{{Text: "a"}, {Text: "b"}},
+			ClosesAt: time.Now().UTC().Add(time.Minute).Format(time.RFC3339),
+		},
+	})
+	require.NoError(t, err)
+
+	repo := postgres.NewPollRepo(db)
+	polls, err := repo.GetByPostIDs(ctx, []string{post.Id}, owner)
+	require.NoError(t, err)
+	require.Contains(t, polls, post.Id)
+
+	// a session ahead of UTC must not close the poll early
+	poll := polls[post.Id]
+	assert.False(t, poll.Closed)
+	assert.NoError(t, repo.Vote(ctx, &us.PollVoteRequest{
+		PostId:    post.Id,
+		UserId:    owner,
+		OptionIds: []string{poll.Options[0].Id},
+	}))
+}
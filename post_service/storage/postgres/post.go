@@ -2,18 +2,149 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
 	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/pkg/hashtag"
 	"post_service/storage"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// postAttachments selects the attachments of the post aliased p as a JSON array.
+const postAttachments = `COALESCE((
+			SELECT json_agg(jsonb_build_object(
+				'id', pa.id,
+				'filepath', pa.filepath,
+				'content_type', pa.content_type,
+				'created_at', pa.created_at,
+				'updated_at', pa.updated_at,
+				'object_key', pa.object_key,
+				'width', am.width,
+				'height', am.height,
+				'blurhash', am.blurhash,
+				'stripped_key', am.stripped_key,
+				'variants', ` + attachmentVariants + `
+			) ORDER BY pa.created_at, pa.id)
+			FROM ` + attachmentMedia + `
+			WHERE pa.post_id = p.id
+		), '[]'::json)`
+
+// postMentions selects the mentions of the post aliased p as a JSON array.
+const postMentions = `COALESCE((
+			SELECT json_agg(jsonb_build_object(
+				'user_id', pm.user_id,
+				'username', pm.username,
+				'offset', pm.start,
+				'length', pm.length
+			) ORDER BY pm.start)
+			FROM post_mentions pm
+			WHERE pm.post_id = p.id
+		), '[]'::json)`
+
+// utcNow is the current time as a UTC timestamp. publish_at and closes_at hold UTC times written by
+// the services, NOW() would be converted to the TimeZone of the session when it is compared with them.
+const utcNow = `(NOW() AT TIME ZONE 'UTC')`
+
+// repostCount counts the published reposts and quotes of the post aliased o.
+const repostCount = `SELECT COUNT(*) FROM posts r WHERE r.repost_of = o.id AND r.status = 'published'`
+
+// postVisible is the condition under which the viewer can read the post aliased p, viewer is the
+// placeholder of the viewer id. Unpublished and private posts are only visible to their owner,
+// followers-only posts also to the followers of the owner.
+func postVisible(viewer string) string {
+	return fmt.Sprintf(`(p.owner_id = NULLIF(%[1]s, '')::uuid OR p.status = 'published' AND (
+			p.visibility = 'public' OR p.visibility = 'followers' AND EXISTS (
+				SELECT 1 FROM follows f
+				WHERE f.followee_id = p.owner_id AND f.follower_id = NULLIF(%[1]s, '')::uuid
+			)
+		))`, viewer)
+}
+
+// postBookmarked tells whether the viewer bookmarked the post aliased p.
+func postBookmarked(viewer string) string {
+	return `EXISTS (SELECT 1 FROM bookmarks bm WHERE bm.post_id = p.id AND bm.user_id = NULLIF(` + viewer + `, '')::uuid)`
+}
+
+// postColumns selects a post aliased p, viewer is the placeholder of the viewer id.
+func postColumns(viewer string) string {
+	return `
+		p.id,
+		p.owner_id,
+		p.tags,
+		p.content,
+		p.status,
+		p.comment_count,
+		p.reaction_counts,
+		` + viewerReaction("post", "p.id", viewer) + `,
+		p.created_at,
+		p.updated_at,
+		p.publish_at,
+		p.edited_at,
+		p.published_at,
+		p.archived_at,
+		p.type,
+		p.repost_of,
+		p.repost_count,
+		p.visibility,
+		` + postBookmarked(viewer) + `,
+		` + postAttachments + `,
+		` + postMentions
+}
+
+// scanPost scans a row selected with postColumns, extra receives the columns selected after them.
+func scanPost(row pgx.Row, extra ...interface{}) (*us.Post, time.Time, error) {
+	var (
+		post                 = &us.Post{}
+		createdAt, updatedAt time.Time
+		publishAt, editedAt  sql.NullTime
+		publishedAt          sql.NullTime
+		archivedAt           sql.NullTime
+		repostOf             sql.NullString
+		attachmentsJSON      string
+		mentionsJSON         string
+	)
+
+	dest := []interface{}{&post.Id, &post.OwnerId, &post.Tags, &post.Content, &post.Status, &post.CommentCount, &post.ReactionCounts, &post.ViewerReaction, &createdAt, &updatedAt, &publishAt, &editedAt, &publishedAt, &archivedAt, &post.Type, &repostOf, &post.RepostCount, &post.Visibility, &post.Bookmarked, &attachmentsJSON, &mentionsJSON}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if err := json.Unmarshal([]byte(attachmentsJSON), &post.Attachments); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if err := json.Unmarshal([]byte(mentionsJSON), &post.Mentions); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	post.CreatedAt = createdAt.Format(time.RFC3339)
+	post.UpdatedAt = updatedAt.Format(time.RFC3339)
+	if publishAt.Valid {
+		post.PublishAt = publishAt.Time.Format(time.RFC3339)
+	}
+	if editedAt.Valid {
+		post.EditedAt = editedAt.Time.Format(time.RFC3339)
+	}
+	if publishedAt.Valid {
+		post.PublishedAt = publishedAt.Time.Format(time.RFC3339)
+	}
+	if archivedAt.Valid {
+		post.ArchivedAt = archivedAt.Time.Format(time.RFC3339)
+	}
+	post.RepostOf = repostOf.String
+
+	return post, createdAt, nil
+}
+
 type PostRepo struct {
 	db *pgxpool.Pool
 }
@@ -28,22 +159,47 @@ func NewPostRepo(db *pgxpool.Pool) storage.PostRepoI {
 func (s *PostRepo) Create(ctx context.Context, req *us.Post) (*us.Post, error) {
 	id := uuid.NewString()
 
-	_, err := s.db.Exec(ctx, `
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
 		INSERT INTO posts (
 			id,
 			owner_id,
 			tags,
 			content,
-			status
+			status,
+			publish_at,
+			visibility,
+			published_at
 		) VALUES (
-			$1, $2, $3, $4, $5
-		)`, id, req.OwnerId, req.Tags, req.Content, req.Status)
+			$1, $2, $3, $4, $5, NULLIF($6::text, '')::timestamp, $7, CASE WHEN $5 = 'published' THEN NOW() END
+		)`, id, req.OwnerId, req.Tags, req.Content, req.Status, req.PublishAt, req.Visibility)
 
 	if err != nil {
 		log.Println("error while creating post in storage", err)
 		return nil, err
 	}
 
+	if err := replacePostTags(ctx, tx, id, req.Tags); err != nil {
+		log.Println("error while creating post tags", err)
+		return nil, err
+	}
+
+	if req.Poll != nil {
+		if err := createPoll(ctx, tx, id, req.Poll); err != nil {
+			log.Println("error while creating post poll", err)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
 	post, err := s.GetSingle(ctx, &us.PostSingleRequest{Id: id})
 	if err != nil {
 		log.Println("error while getting post by id after creating", err)
@@ -54,10 +210,25 @@ func (s *PostRepo) Create(ctx context.Context, req *us.Post) (*us.Post, error) {
 
 // GetByID implements storage.PostRepoI.
 func (s *PostRepo) GetSingle(ctx context.Context, req *us.PostSingleRequest) (*us.Post, error) {
+	return s.getSingle(ctx, req, "")
+}
+
+// GetVisible implements storage.PostRepoI.
+func (s *PostRepo) GetVisible(ctx context.Context, req *us.PostSingleRequest) (*us.Post, error) {
+	return s.getSingle(ctx, req, " AND "+postVisible("$2"))
+}
+
+// getSingle returns the post with the id of the request if it also matches filter.
+func (s *PostRepo) getSingle(ctx context.Context, req *us.PostSingleRequest, filter string) (*us.Post, error) {
 	resp := &us.Post{}
 
 	var (
 		created_at, updated_at time.Time
+		publish_at, edited_at  sql.NullTime
+		published_at           sql.NullTime
+		archived_at            sql.NullTime
+		repost_of              sql.NullString
+		mentions               string
 	)
 
 	tags := []byte{}
@@ -68,10 +239,23 @@ func (s *PostRepo) GetSingle(ctx context.Context, req *us.PostSingleRequest) (*u
 			tags,
 			content,
 			status,
+			comment_count,
+			reaction_counts,
+			`+viewerReaction("post", "p.id", "$2")+`,
 	        created_at,
-	        updated_at
-	        FROM posts 
-	    WHERE id=$1`, req.Id).Scan(&resp.Id, &resp.OwnerId, &tags, &resp.Content, &resp.Status, &created_at, &updated_at)
+	        updated_at,
+	        publish_at,
+	        edited_at,
+	        published_at,
+	        archived_at,
+	        type,
+	        repost_of,
+	        repost_count,
+	        visibility,
+	        `+postBookmarked("$2")+`,
+	        `+postMentions+`
+	        FROM posts p
+	    WHERE id=$1`+filter, req.Id, req.ViewerId).Scan(&resp.Id, &resp.OwnerId, &tags, &resp.Content, &resp.Status, &resp.CommentCount, &resp.ReactionCounts, &resp.ViewerReaction, &created_at, &updated_at, &publish_at, &edited_at, &published_at, &archived_at, &resp.Type, &repost_of, &resp.RepostCount, &resp.Visibility, &resp.Bookmarked, &mentions)
 
 	if err != nil {
 		log.Println("error while getting post by id", err)
@@ -83,84 +267,193 @@ func (s *PostRepo) GetSingle(ctx context.Context, req *us.PostSingleRequest) (*u
 		return &us.Post{}, err
 	}
 
+	err = json.Unmarshal([]byte(mentions), &resp.Mentions)
+	if err != nil {
+		return &us.Post{}, err
+	}
+
 	resp.CreatedAt = created_at.Format(time.RFC3339)
 	resp.UpdatedAt = updated_at.Format(time.RFC3339)
+	if publish_at.Valid {
+		resp.PublishAt = publish_at.Time.Format(time.RFC3339)
+	}
+	if edited_at.Valid {
+		resp.EditedAt = edited_at.Time.Format(time.RFC3339)
+	}
+	if published_at.Valid {
+		resp.PublishedAt = published_at.Time.Format(time.RFC3339)
+	}
+	if archived_at.Valid {
+		resp.ArchivedAt = archived_at.Time.Format(time.RFC3339)
+	}
+	resp.RepostOf = repost_of.String
 
 	return resp, nil
 }
 
 // GetList implements storage.PostRepoI.
 func (s *PostRepo) GetList(ctx context.Context, req *us.GetListPostRequest) (*us.PostList, error) {
-	resp := &us.PostList{}
-
 	var (
-		createdAt, updatedAt time.Time
+		resp   = &us.PostList{}
+		args   = []interface{}{req.ViewerId}
+		filter = " WHERE " + postVisible("$1")
 	)
 
-	offset := (req.Page - 1) * req.Limit
-
-	baseQuery := `
-    SELECT 
-        p.id, 
-        p.owner_id,
-		p.tags, 
-        p.content, 
-        p.status, 
-        p.created_at, 
-        p.updated_at,
-        COALESCE(json_agg(DISTINCT pa_json), '[]'::json) AS attachments
-    FROM posts p
-    LEFT JOIN (
-        SELECT pa.post_id, jsonb_build_object(
-            'id', pa.id,
-            'filepath', pa.filepath,
-            'content_type', pa.content_type,
-            'created_at', pa.created_at,
-            'updated_at', pa.updated_at
-        ) AS pa_json
-        FROM post_attachment pa
-    ) pa ON pa.post_id = p.id
-    `
-
-	whereClause := ""
 	if req.Search != "" {
-		whereClause = fmt.Sprintf(" WHERE p.owner_id = '%s'", req.Search)
+		args = append(args, req.Search)
+		filter += fmt.Sprintf(" AND p.owner_id = $%d", len(args))
 	}
 
-	tailClause := fmt.Sprintf(`
-    %s
-    GROUP BY p.id
-    ORDER BY p.created_at DESC
-    OFFSET %v LIMIT %v`, whereClause, offset, req.Limit)
+	if req.WithTotal {
+		err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM posts p`+filter, args...).Scan(&resp.Count)
+		if err != nil {
+			log.Println("error while counting posts:", err)
+			return nil, err
+		}
+	}
 
-	query := baseQuery + tailClause
+	filter, args, err := page{alias: "p.", cursor: req.Cursor, number: req.Page, limit: req.Limit}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := s.db.Query(ctx, query)
+	rows, err := s.db.Query(ctx, `SELECT`+postColumns("$1")+` FROM posts p`+filter, args...)
 	if err != nil {
 		log.Println("Error while getting posts:", err)
 		return nil, err
 	}
 	defer rows.Close()
 
+	var lastCreatedAt time.Time
 	for rows.Next() {
-		var post us.Post
-		var attachmentsJSON string
-
-		err = rows.Scan(&post.Id, &post.OwnerId, &post.Tags, &post.Content, &post.Status, &createdAt, &updatedAt, &attachmentsJSON)
+		post, createdAt, err := scanPost(rows)
 		if err != nil {
 			log.Println("error while scanning posts:", err)
 			return nil, err
 		}
 
-		post.CreatedAt = createdAt.Format(time.RFC3339)
-		post.UpdatedAt = updatedAt.Format(time.RFC3339)
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
+		resp.Items = append(resp.Items, post)
+		lastCreatedAt = createdAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// searchHeadline are the ts_headline options of the search snippets.
+const searchHeadline = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=" ... "`
+
+// htmlEscape wraps the text expression so the snippets can be rendered as HTML. The parser of ts_headline
+// reads an entity as a single token, so escaping the content first leaves <mark> the only markup.
+func htmlEscape(expr string) string {
+	return `replace(replace(replace(replace(replace(` + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// Search implements storage.PostRepoI.
+// Hits are ordered by rank and hits of the same rank newest first. Without a query every hit
+// has the same rank and the snippet is the beginning of the content. Reposts have no content of
+// their own and are never hits.
+func (s *PostRepo) Search(ctx context.Context, req *us.SearchPostRequest) (*us.PostSearchResult, error) {
+	var (
+		resp    = &us.PostSearchResult{}
+		args    = []interface{}{req.Status, req.ViewerId}
+		filter  = " WHERE p.status = $1 AND p.type <> 'repost' AND " + postVisible("$2")
+		rank    = "0::real"
+		snippet = htmlEscape("left(p.content, 200)")
+	)
+
+	if req.Query != "" {
+		args = append(args, req.Query)
+		query := fmt.Sprintf("websearch_to_tsquery('english', $%d)", len(args))
+
+		filter += " AND p.search_vector @@ " + query
+		rank = "ts_rank_cd(p.search_vector, " + query + ")"
+		snippet = "ts_headline('english', " + htmlEscape("p.content") + ", " + query + ", '" + searchHeadline + "')"
+	}
+
+	if req.OwnerId != "" {
+		args = append(args, req.OwnerId)
+		filter += fmt.Sprintf(" AND p.owner_id = $%d", len(args))
+	}
+
+	if req.Tag != "" {
+		args = append(args, hashtag.Normalize(req.Tag))
+		filter += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM post_tags pt WHERE pt.post_id = p.id AND pt.tag = $%d)", len(args))
+	}
+
+	// the dates are sent as text, so both YYYY-MM-DD and RFC3339 are parsed by postgres
+	if req.CreatedFrom != "" {
+		args = append(args, req.CreatedFrom)
+		filter += fmt.Sprintf(" AND p.created_at >= $%d::text::timestamp", len(args))
+	}
+
+	if req.CreatedTo != "" {
+		args = append(args, req.CreatedTo)
+		filter += fmt.Sprintf(" AND p.created_at < $%d::text::timestamp", len(args))
+	}
+
+	after := " WHERE TRUE"
+	if req.Cursor != "" {
+		lastRank, createdAt, id, err := cursor.DecodeRanked(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, lastRank, createdAt, id)
+		after += fmt.Sprintf(" AND (h.rank, h.created_at, h.id) < ($%d::real, $%d, $%d)", len(args)-2, len(args)-1, len(args))
+	}
 
-		if err := json.Unmarshal([]byte(attachmentsJSON), &post.Attachments); err != nil {
-			log.Println("error unmarshalling attachments:", err)
+	// the snippet is only built for the rows of the page
+	rows, err := s.db.Query(ctx, `
+		SELECT`+postColumns("$2")+`,
+			h.rank,
+			`+snippet+`
+		FROM (
+			SELECT p.id, p.created_at, `+rank+` AS rank
+			FROM posts p`+filter+`
+		) h
+		JOIN posts p ON p.id = h.id`+after+fmt.Sprintf(`
+		ORDER BY h.rank DESC, h.created_at DESC, h.id DESC
+		LIMIT %d`, req.Limit+1), args...)
+	if err != nil {
+		log.Println("error while searching posts:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var last struct {
+		rank      float32
+		createdAt time.Time
+	}
+	for rows.Next() {
+		hit := &us.PostSearchHit{}
+
+		post, createdAt, err := scanPost(rows, &hit.Rank, &hit.Snippet)
+		if err != nil {
+			log.Println("error while scanning search hits:", err)
 			return nil, err
 		}
 
-		resp.Items = append(resp.Items, &post)
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.EncodeRanked(last.rank, last.createdAt, resp.Items[len(resp.Items)-1].Post.Id)
+			break
+		}
+
+		hit.Post = post
+		resp.Items = append(resp.Items, hit)
+		last.rank, last.createdAt = hit.Rank, createdAt
 	}
 
 	if err = rows.Err(); err != nil {
@@ -168,25 +461,67 @@ func (s *PostRepo) GetList(ctx context.Context, req *us.GetListPostRequest) (*us
 		return nil, err
 	}
 
-	resp.Count = int64(len(resp.Items))
 	return resp, nil
 }
 
 // Update implements storage.PostRepoI.
+// The tags are only replaced when the request has tags and the visibility when it is set. The previous
+// version is kept as a revision when the content or the status changes. A post of another owner is
+// left unchanged and pgx.ErrNoRows is returned.
 func (s *PostRepo) Update(ctx context.Context, req *us.Post) (*us.Post, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
 
-	_, err := s.db.Exec(ctx, `
+	if err := editPost(ctx, tx, req.Id, req.Content, req.Status, req.EditorId); err != nil {
+		log.Println("error while updating post in storage", err)
+		return nil, err
+	}
+
+	tag, err := tx.Exec(ctx, `
         UPDATE posts SET
-		    content=$1,
-		    status=$2,
+		    publish_at=NULLIF($2::text, '')::timestamp,
+		    visibility=COALESCE(NULLIF($3::text, '')::post_visibility, visibility),
             updated_at = NOW()
-        WHERE id = $3`, req.Content, req.Status, req.Id)
+        WHERE id = $1 AND owner_id = $4`, req.Id, req.PublishAt, req.Visibility, req.OwnerId)
 
 	if err != nil {
 		log.Println("error while updating post in storage", err)
 		return nil, err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	if len(req.Tags) > 0 {
+		_, err = tx.Exec(ctx, `UPDATE posts SET tags = $1 WHERE id = $2`, req.Tags, req.Id)
+		if err != nil {
+			log.Println("error while updating post tags in storage", err)
+			return nil, err
+		}
+
+		if err := replacePostTags(ctx, tx, req.Id, req.Tags); err != nil {
+			log.Println("error while replacing post tags", err)
+			return nil, err
+		}
+	}
+
+	// a quote that is published or unpublished changes the count of the quoted post
+	_, err = tx.Exec(ctx, `
+		UPDATE posts o SET repost_count = (`+repostCount+`)
+		WHERE o.id = (SELECT repost_of FROM posts WHERE id = $1)`, req.Id)
+	if err != nil {
+		log.Println("error while counting reposts", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
 	post, err := s.GetSingle(ctx, &us.PostSingleRequest{Id: req.Id})
 	if err != nil {
 		log.Println("error while getting updated post by id", err)
@@ -197,16 +532,361 @@ func (s *PostRepo) Update(ctx context.Context, req *us.Post) (*us.Post, error) {
 }
 
 // Delete implements storage.PostRepoI.
+// Reactions have no foreign key to their target, so they are removed together with the post.
+// Reposts of the post are deleted with it, quotes stay without the quoted post.
 func (s *PostRepo) Delete(ctx context.Context, req *us.PostSingleRequest) (*emptypb.Empty, error) {
-	_, err := s.db.Exec(ctx, `
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return &emptypb.Empty{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := deletePost(ctx, tx, req.Id); err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return &emptypb.Empty{}, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// deletePost deletes a post with its plain reposts and the reactions to them and to their comments.
+// The media of their attachments are released.
+// Deleting a post that does not exist changes nothing.
+func deletePost(ctx context.Context, tx pgx.Tx, id string) error {
+	var repostOf sql.NullString
+	err := tx.QueryRow(ctx, `SELECT repost_of FROM posts WHERE id = $1`, id).Scan(&repostOf)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		log.Println("error while getting post to delete:", err)
+		return err
+	}
+
+	// the attachments are deleted with their posts
+	err = releaseAttachments(ctx, tx, `pa.post_id IN (
+			SELECT id FROM posts
+			WHERE id = $1 OR (repost_of = $1 AND type = 'repost')
+		)`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		WITH doomed AS (
+			SELECT id FROM posts
+			WHERE id = $1 OR (repost_of = $1 AND type = 'repost')
+		), deleted_reactions AS (
+			DELETE FROM reactions
+			WHERE (target_type = 'post' AND target_id IN (SELECT id FROM doomed))
+			   OR (target_type = 'comment' AND target_id IN (SELECT id FROM comments WHERE post_id IN (SELECT id FROM doomed)))
+		)
 		DELETE FROM posts
-		WHERE id = $1
-	`, req.Id)
+		WHERE id IN (SELECT id FROM doomed)
+	`, id)
 
 	if err != nil {
 		log.Println("error while deleting post:", err)
+		return err
+	}
+
+	if repostOf.Valid {
+		return recountReposts(ctx, tx, repostOf.String)
+	}
+
+	return nil
+}
+
+// recountReposts updates the repost count of a post.
+func recountReposts(ctx context.Context, tx pgx.Tx, id string) error {
+	_, err := tx.Exec(ctx, `UPDATE posts o SET repost_count = (`+repostCount+`) WHERE o.id = $1`, id)
+	if err != nil {
+		log.Println("error while counting reposts:", err)
+	}
+	return err
+}
+
+// Repost implements storage.PostRepoI.
+// The repost is published right away. Reposting the same post again returns the existing repost.
+func (s *PostRepo) Repost(ctx context.Context, req *us.RepostRequest) (*us.Post, error) {
+	postType := "repost"
+	if req.Content != "" {
+		postType = "quote"
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO posts (
+			id,
+			owner_id,
+			content,
+			status,
+			type,
+			repost_of,
+			published_at
+		) VALUES (
+			$1, $2, $3, 'published', $4, $5, NOW()
+		)
+		ON CONFLICT (owner_id, repost_of) WHERE type = 'repost' DO NOTHING
+		RETURNING id`, uuid.NewString(), req.OwnerId, req.Content, postType, req.PostId).Scan(&id)
+
+	if err == pgx.ErrNoRows {
+		err = tx.QueryRow(ctx, `
+			SELECT id FROM posts
+			WHERE owner_id = $1 AND repost_of = $2 AND type = 'repost'`, req.OwnerId, req.PostId).Scan(&id)
+	}
+	if err != nil {
+		log.Println("error while reposting post", err)
+		return nil, err
+	}
+
+	if err := recountReposts(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSingle(ctx, &us.PostSingleRequest{Id: id, ViewerId: req.OwnerId})
+}
+
+// DeleteRepost implements storage.PostRepoI.
+func (s *PostRepo) DeleteRepost(ctx context.Context, req *us.RepostRequest) (*emptypb.Empty, error) {
+	var id string
+	err := s.db.QueryRow(ctx, `
+		SELECT id FROM posts
+		WHERE owner_id = $1 AND repost_of = $2 AND type = 'repost'`, req.OwnerId, req.PostId).Scan(&id)
+
+	if err != nil {
+		log.Println("error while getting repost:", err)
 		return &emptypb.Empty{}, err
 	}
 
-	return &emptypb.Empty{}, nil
+	return s.Delete(ctx, &us.PostSingleRequest{Id: id})
+}
+
+// GetByIDs implements storage.PostRepoI.
+func (s *PostRepo) GetByIDs(ctx context.Context, ids []string, viewerID string) ([]*us.Post, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT`+postColumns("$2")+`
+		FROM posts p
+		WHERE p.id = ANY($1::uuid[]) AND p.status = 'published' AND `+postVisible("$2"), ids, viewerID)
+	if err != nil {
+		log.Println("error while getting posts by ids:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*us.Post
+	for rows.Next() {
+		post, _, err := scanPost(rows)
+		if err != nil {
+			log.Println("error while scanning posts:", err)
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// Reschedule implements storage.PostRepoI.
+// Only drafts and scheduled posts are changed, pgx.ErrNoRows is returned for the others.
+func (s *PostRepo) Reschedule(ctx context.Context, req *us.ReschedulePostRequest) (*us.Post, error) {
+	tag, err := s.db.Exec(ctx, `
+		UPDATE posts SET
+			status = 'scheduled',
+			publish_at = $2::text::timestamp,
+			updated_at = NOW()
+		WHERE id = $1 AND status IN ('draft', 'scheduled')`, req.Id, req.PublishAt)
+
+	if err != nil {
+		log.Println("error while rescheduling post", err)
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	return s.GetSingle(ctx, &us.PostSingleRequest{Id: req.Id})
+}
+
+// CancelSchedule implements storage.PostRepoI.
+// The post goes back to draft, pgx.ErrNoRows is returned if it is not scheduled.
+func (s *PostRepo) CancelSchedule(ctx context.Context, req *us.PostSingleRequest) (*us.Post, error) {
+	tag, err := s.db.Exec(ctx, `
+		UPDATE posts SET
+			status = 'draft',
+			publish_at = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'scheduled'`, req.Id)
+
+	if err != nil {
+		log.Println("error while cancelling post schedule", err)
+		return nil, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	return s.GetSingle(ctx, &us.PostSingleRequest{Id: req.Id})
+}
+
+// PublishDue implements storage.PostRepoI.
+// Posts locked by another replica are skipped, so every due post is published once. A published post
+// is dated at its publish time, so it shows up in the feeds next to the posts of that time.
+func (s *PostRepo) PublishDue(ctx context.Context, limit int) ([]*us.Post, error) {
+	rows, err := s.db.Query(ctx, `
+		WITH due AS (
+			SELECT id
+			FROM posts
+			WHERE status = 'scheduled' AND publish_at <= `+utcNow+`
+			ORDER BY publish_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), published AS (
+			UPDATE posts p SET
+				status = 'published',
+				created_at = p.publish_at,
+				published_at = COALESCE(p.published_at, p.publish_at),
+				publish_at = NULL,
+				updated_at = NOW()
+			FROM due
+			WHERE p.id = due.id
+			RETURNING p.id, p.owner_id, p.status, p.repost_of
+		), counted AS (
+			-- scheduled quotes were not counted before
+			UPDATE posts o SET repost_count = o.repost_count + q.n
+			FROM (
+				SELECT repost_of, COUNT(*) AS n FROM published
+				WHERE repost_of IS NOT NULL
+				GROUP BY repost_of
+			) q
+			WHERE o.id = q.repost_of
+		)
+		SELECT id, owner_id, status FROM published`, limit)
+	if err != nil {
+		log.Println("error while publishing due posts:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*us.Post
+	for rows.Next() {
+		post := &us.Post{}
+		if err := rows.Scan(&post.Id, &post.OwnerId, &post.Status); err != nil {
+			log.Println("error while scanning published posts:", err)
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+
+	return posts, rows.Err()
+}
+
+// GetTimelineEntries implements storage.PostRepoI.
+func (s *PostRepo) GetTimelineEntries(ctx context.Context, req *storage.TimelineEntryFilter) ([]storage.TimelineEntry, error) {
+	query := `
+		SELECT id, created_at
+		FROM posts
+		WHERE status = 'published' AND (id = ANY($1::uuid[]) OR owner_id = ANY($2::uuid[]))
+		ORDER BY created_at DESC, id DESC`
+
+	args := []interface{}{req.PostIDs, req.OwnerIDs}
+	if req.Limit > 0 {
+		query += ` LIMIT $3`
+		args = append(args, req.Limit)
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		log.Println("error while getting timeline entries:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []storage.TimelineEntry
+	for rows.Next() {
+		var entry storage.TimelineEntry
+		if err := rows.Scan(&entry.PostID, &entry.CreatedAt); err != nil {
+			log.Println("error while scanning timeline entries:", err)
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetFeed implements storage.PostRepoI.
+func (s *PostRepo) GetFeed(ctx context.Context, req *storage.FeedQuery) ([]storage.FeedItem, error) {
+	var (
+		args   = []interface{}{req.ViewerID, req.Owners}
+		filter = ` WHERE p.status = 'published' AND p.owner_id = ANY($2::uuid[]) AND ` + postVisible("$1")
+		source []string
+	)
+
+	if req.Before != nil {
+		args = append(args, req.Before.CreatedAt, req.Before.PostID)
+		filter += fmt.Sprintf(" AND (p.created_at, p.id) < ($%d, $%d::uuid)", len(args)-1, len(args))
+	}
+
+	if req.From != nil {
+		args = append(args, req.From.CreatedAt, req.From.PostID)
+		filter += fmt.Sprintf(" AND (p.created_at, p.id) >= ($%d, $%d::uuid)", len(args)-1, len(args))
+	}
+
+	if !req.PullAll {
+		args = append(args, req.PostIDs)
+		source = append(source, fmt.Sprintf("p.id = ANY($%d::uuid[])", len(args)))
+
+		args = append(args, req.Pulled)
+		source = append(source, fmt.Sprintf("p.owner_id = ANY($%d::uuid[])", len(args)))
+
+		if req.PullBelow != nil {
+			args = append(args, req.PullBelow.CreatedAt, req.PullBelow.PostID)
+			source = append(source, fmt.Sprintf("(p.created_at, p.id) < ($%d, $%d::uuid)", len(args)-1, len(args)))
+		}
+
+		filter += " AND (" + strings.Join(source, " OR ") + ")"
+	}
+
+	filter += fmt.Sprintf(" ORDER BY p.created_at DESC, p.id DESC LIMIT %d", req.Limit)
+
+	rows, err := s.db.Query(ctx, `SELECT`+postColumns("$1")+` FROM posts p`+filter, args...)
+	if err != nil {
+		log.Println("error while getting feed:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []storage.FeedItem
+	for rows.Next() {
+		post, createdAt, err := scanPost(rows)
+		if err != nil {
+			log.Println("error while scanning feed:", err)
+			return nil, err
+		}
+
+		items = append(items, storage.FeedItem{Post: post, CreatedAt: createdAt})
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return items, nil
 }
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type PostStatsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostStatsRepo(db *pgxpool.Pool) storage.PostStatsRepoI {
+	return &PostStatsRepo{
+		db: db,
+	}
+}
+
+// Add implements storage.PostStatsRepoI.
+func (s *PostStatsRepo) Add(ctx context.Context, counts []storage.ViewCounts) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	var (
+		ids                         = make([]string, 0, len(counts))
+		views, impressions, viewers = make([]int64, 0, len(counts)), make([]int64, 0, len(counts)), make([]int64, 0, len(counts))
+	)
+	for _, c := range counts {
+		ids = append(ids, c.PostID)
+		views = append(views, c.Views)
+		impressions = append(impressions, c.Impressions)
+		viewers = append(viewers, c.UniqueViewers)
+	}
+
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO post_stats (
+			post_id,
+			view_count,
+			impression_count,
+			unique_viewers
+		)
+		SELECT c.post_id, c.views, c.impressions, c.viewers
+		FROM unnest($1::uuid[], $2::bigint[], $3::bigint[], $4::bigint[]) AS c(post_id, views, impressions, viewers)
+		JOIN posts p ON p.id = c.post_id
+		ON CONFLICT (post_id) DO UPDATE SET
+			view_count = post_stats.view_count + EXCLUDED.view_count,
+			impression_count = post_stats.impression_count + EXCLUDED.impression_count,
+			unique_viewers = GREATEST(post_stats.unique_viewers, EXCLUDED.unique_viewers),
+			updated_at = NOW()`, ids, views, impressions, viewers)
+	if err != nil {
+		log.Println("error while adding post stats:", err)
+	}
+
+	return err
+}
+
+// GetSingle implements storage.PostStatsRepoI.
+func (s *PostStatsRepo) GetSingle(ctx context.Context, postID string) (*us.PostStats, error) {
+	var (
+		resp      = &us.PostStats{PostId: postID}
+		updatedAt time.Time
+	)
+
+	err := s.db.QueryRow(ctx, `
+		SELECT
+			view_count,
+			impression_count,
+			unique_viewers,
+			updated_at
+		FROM post_stats
+		WHERE post_id = $1`, postID).Scan(&resp.ViewCount, &resp.ImpressionCount, &resp.UniqueViewers, &updatedAt)
+	if err == pgx.ErrNoRows {
+		return resp, nil
+	}
+	if err != nil {
+		log.Println("error while getting post stats:", err)
+		return nil, err
+	}
+
+	resp.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+package postgres_test
+
+import (
+	"context"
+	us "post_service/genproto/post_service"
+	"post_service/storage/postgres"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishDue(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	repo := postgres.NewPostRepo(db)
+
+	post, err := repo.Create(ctx, &us.Post{
+		OwnerId:    testUser(t, db),
+		Content:    "scheduled",
+		Status:     "scheduled",
+		Visibility: "public",
+		PublishAt:  time.Now().UTC().Add(time.Minute).Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	published := func() bool {
+		posts, err := repo.PublishDue(ctx, 1000)
+		require.NoError(t, err)
+		for _, p := range posts {
+			if p.Id == post.Id {
+				return true
+			}
+		}
+		return false
+	}
+
+	// a session ahead of UTC must not publish the post early
+	assert.False(t, published())
+
+	_, err = db.Exec(ctx, `UPDATE posts SET publish_at = publish_at - interval '2 minutes' WHERE id = $1`, post.Id)
+	require.NoError(t, err)
+	assert.True(t, published())
+}
+
+func TestSearchSnippetEscaped(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	repo := postgres.NewPostRepo(db)
+	owner := testUser(t, db)
+
+	_, err := repo.Create(ctx, &us.Post{
+		OwnerId:    owner,
+		Content:    `<script>alert("xss")</script> snippets & marks`,
+		Status:     "published",
+		Visibility: "public",
+	})
+	require.NoError(t, err)
+
+	for _, query := range []string{"", "snippets"} {
+		resp, err := repo.Search(ctx, &us.SearchPostRequest{Query: query, OwnerId: owner, Status: "published", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 1)
+
+		snippet := strings.ReplaceAll(strings.ReplaceAll(resp.Items[0].Snippet, "<mark>", ""), "</mark>", "")
+		assert.NotContains(t, snippet, "<")
+		assert.Contains(t, snippet, "&lt;script&gt;")
+	}
+}
+
+func TestUpdateOtherOwner(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	repo := postgres.NewPostRepo(db)
+
+	post, err := repo.Create(ctx, &us.Post{
+		OwnerId:    testUser(t, db),
+		Content:    "mine",
+		Status:     "published",
+		Visibility: "public",
+	})
+	require.NoError(t, err)
+
+	other := testUser(t, db)
+	_, err = repo.Update(ctx, &us.Post{Id: post.Id, OwnerId: other, EditorId: other, Content: "theirs", Status: "published"})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	got, err := repo.GetSingle(ctx, &us.PostSingleRequest{Id: post.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "mine", got.Content)
+}
@@ -14,7 +14,22 @@ import (
 type Store struct {
 	db         *pgxpool.Pool
 	post       storage.PostRepoI
+	revision   storage.PostRevisionRepoI
 	attachment storage.PostAttachmentRepoI
+	comment    storage.CommentRepoI
+	reaction   storage.ReactionRepoI
+	tag        storage.TagRepoI
+	mention    storage.MentionRepoI
+	bookmark   storage.BookmarkRepoI
+	collection storage.BookmarkCollectionRepoI
+	report     storage.ReportRepoI
+	moderation storage.ModerationRepoI
+	postStats  storage.PostStatsRepoI
+	poll       storage.PollRepoI
+	preview    storage.LinkPreviewRepoI
+	media      storage.MediaRepoI
+	blockedTag storage.BlockedTagRepoI
+	audit      storage.AuditEventRepoI
 }
 
 func NewPostgres(ctx context.Context, cfg config.Config) (storage.StorageI, error) {
@@ -64,6 +79,15 @@ func (s *Store) Post() storage.PostRepoI {
 	return s.post
 }
 
+// PostRevision implements storage.StorageI.
+func (s *Store) PostRevision() storage.PostRevisionRepoI {
+	if s.revision == nil {
+		s.revision = NewPostRevisionRepo(s.db)
+	}
+
+	return s.revision
+}
+
 // Session implements storage.StorageI.
 func (s *Store) PostAttachment() storage.PostAttachmentRepoI {
 	if s.attachment == nil {
@@ -72,3 +96,129 @@ func (s *Store) PostAttachment() storage.PostAttachmentRepoI {
 
 	return s.attachment
 }
+
+// Comment implements storage.StorageI.
+func (s *Store) Comment() storage.CommentRepoI {
+	if s.comment == nil {
+		s.comment = NewCommentRepo(s.db)
+	}
+
+	return s.comment
+}
+
+// Reaction implements storage.StorageI.
+func (s *Store) Reaction() storage.ReactionRepoI {
+	if s.reaction == nil {
+		s.reaction = NewReactionRepo(s.db)
+	}
+
+	return s.reaction
+}
+
+// Tag implements storage.StorageI.
+func (s *Store) Tag() storage.TagRepoI {
+	if s.tag == nil {
+		s.tag = NewTagRepo(s.db)
+	}
+
+	return s.tag
+}
+
+// Mention implements storage.StorageI.
+func (s *Store) Mention() storage.MentionRepoI {
+	if s.mention == nil {
+		s.mention = NewMentionRepo(s.db)
+	}
+
+	return s.mention
+}
+
+// Bookmark implements storage.StorageI.
+func (s *Store) Bookmark() storage.BookmarkRepoI {
+	if s.bookmark == nil {
+		s.bookmark = NewBookmarkRepo(s.db)
+	}
+
+	return s.bookmark
+}
+
+// BookmarkCollection implements storage.StorageI.
+func (s *Store) BookmarkCollection() storage.BookmarkCollectionRepoI {
+	if s.collection == nil {
+		s.collection = NewBookmarkCollectionRepo(s.db)
+	}
+
+	return s.collection
+}
+
+// Report implements storage.StorageI.
+func (s *Store) Report() storage.ReportRepoI {
+	if s.report == nil {
+		s.report = NewReportRepo(s.db)
+	}
+
+	return s.report
+}
+
+// Moderation implements storage.StorageI.
+func (s *Store) Moderation() storage.ModerationRepoI {
+	if s.moderation == nil {
+		s.moderation = NewModerationRepo(s.db)
+	}
+
+	return s.moderation
+}
+
+// PostStats implements storage.StorageI.
+func (s *Store) PostStats() storage.PostStatsRepoI {
+	if s.postStats == nil {
+		s.postStats = NewPostStatsRepo(s.db)
+	}
+
+	return s.postStats
+}
+
+// Poll implements storage.StorageI.
+func (s *Store) Poll() storage.PollRepoI {
+	if s.poll == nil {
+		s.poll = NewPollRepo(s.db)
+	}
+
+	return s.poll
+}
+
+// LinkPreview implements storage.StorageI.
+func (s *Store) LinkPreview() storage.LinkPreviewRepoI {
+	if s.preview == nil {
+		s.preview = NewLinkPreviewRepo(s.db)
+	}
+
+	return s.preview
+}
+
+// Media implements storage.StorageI.
+func (s *Store) Media() storage.MediaRepoI {
+	if s.media == nil {
+		s.media = NewMediaRepo(s.db)
+	}
+
+	return s.media
+}
+
+// BlockedTag implements storage.StorageI.
+func (s *Store) BlockedTag() storage.BlockedTagRepoI {
+	if s.blockedTag == nil {
+		s.blockedTag = NewBlockedTagRepo(s.db)
+	}
+
+	return s.blockedTag
+}
+
+// AuditEvent implements storage.StorageI.
+func (s *Store) AuditEvent() storage.AuditEventRepoI {
+	if s.audit == nil {
+		s.audit = NewAuditEventRepo(s.db)
+	}
+
+	return s.audit
+}
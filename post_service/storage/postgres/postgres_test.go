@@ -0,0 +1,45 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// testDB connects to the migrated database in TEST_POSTGRES_URL, the test is skipped when it is not
+// set. The session TimeZone is not UTC, so the comparisons with times stored in UTC are checked.
+func testDB(t *testing.T) *pgxpool.Pool {
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+
+	config, err := pgxpool.ParseConfig(url)
+	require.NoError(t, err)
+	config.ConnConfig.RuntimeParams["timezone"] = "Asia/Tashkent"
+
+	db, err := pgxpool.ConnectConfig(context.Background(), config)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	return db
+}
+
+// testUser creates a user that is deleted with everything it owns when the test ends.
+func testUser(t *testing.T, db *pgxpool.Pool) string {
+	id := uuid.NewString()
+	_, err := db.Exec(context.Background(), `
+		INSERT INTO users (id, user_type, user_role, full_name, user_name, email, password)
+		VALUES ($1, 'user', 'user', 'Test User', $2, $3, 'x')`, id, "test_"+id[:8], id+"@example.com")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = db.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, id)
+	})
+
+	return id
+}
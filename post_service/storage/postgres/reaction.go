@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ReactionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReactionRepo(db *pgxpool.Pool) storage.ReactionRepoI {
+	return &ReactionRepo{
+		db: db,
+	}
+}
+
+// reactionTables maps a target type to the table that holds its counters.
+var reactionTables = map[string]string{
+	"post":    "posts",
+	"comment": "comments",
+}
+
+// Upsert implements storage.ReactionRepoI.
+// The target row is locked first, so concurrent reactions to the same target are applied one by one
+// and the counters always match the reactions table.
+func (s *ReactionRepo) Upsert(ctx context.Context, req *us.Reaction) (*us.ReactionSummary, error) {
+	table, ok := reactionTables[req.TargetType]
+	if !ok {
+		return nil, fmt.Errorf("unknown reaction target %q", req.TargetType)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockReactionTarget(ctx, tx, table, req.TargetId); err != nil {
+		return nil, err
+	}
+
+	var current string
+	err = tx.QueryRow(ctx, `
+		SELECT type
+		FROM reactions
+		WHERE target_type = $1 AND target_id = $2 AND user_id = $3`,
+		req.TargetType, req.TargetId, req.UserId).Scan(&current)
+
+	switch {
+	case err == pgx.ErrNoRows:
+		_, err = tx.Exec(ctx, `
+			INSERT INTO reactions (
+				target_type,
+				target_id,
+				user_id,
+				type
+			) VALUES (
+				$1, $2, $3, $4
+			)`, req.TargetType, req.TargetId, req.UserId, req.Type)
+		if err == nil {
+			err = addReactionCount(ctx, tx, table, req.TargetId, req.Type, 1)
+		}
+	case err != nil:
+	case current != req.Type:
+		_, err = tx.Exec(ctx, `
+			UPDATE reactions SET
+				type = $1,
+				updated_at = NOW()
+			WHERE target_type = $2 AND target_id = $3 AND user_id = $4`,
+			req.Type, req.TargetType, req.TargetId, req.UserId)
+		if err == nil {
+			err = addReactionCount(ctx, tx, table, req.TargetId, current, -1)
+		}
+		if err == nil {
+			err = addReactionCount(ctx, tx, table, req.TargetId, req.Type, 1)
+		}
+	}
+
+	if err != nil {
+		log.Println("error while upserting reaction", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.summary(ctx, table, req.TargetType, req.TargetId, req.UserId)
+}
+
+// Delete implements storage.ReactionRepoI.
+// Deleting a reaction that does not exist is not an error.
+func (s *ReactionRepo) Delete(ctx context.Context, req *us.ReactionSingleRequest) (*us.ReactionSummary, error) {
+	table, ok := reactionTables[req.TargetType]
+	if !ok {
+		return nil, fmt.Errorf("unknown reaction target %q", req.TargetType)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockReactionTarget(ctx, tx, table, req.TargetId); err != nil {
+		return nil, err
+	}
+
+	var current string
+	err = tx.QueryRow(ctx, `
+		DELETE FROM reactions
+		WHERE target_type = $1 AND target_id = $2 AND user_id = $3
+		RETURNING type`, req.TargetType, req.TargetId, req.UserId).Scan(&current)
+
+	switch {
+	case err == pgx.ErrNoRows:
+		err = nil
+	case err == nil:
+		err = addReactionCount(ctx, tx, table, req.TargetId, current, -1)
+	}
+
+	if err != nil {
+		log.Println("error while deleting reaction", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.summary(ctx, table, req.TargetType, req.TargetId, req.UserId)
+}
+
+// GetList implements storage.ReactionRepoI.
+func (s *ReactionRepo) GetList(ctx context.Context, req *us.GetListReactionRequest) (*us.ReactionList, error) {
+	var (
+		resp   = &us.ReactionList{}
+		args   = []interface{}{req.TargetType, req.TargetId}
+		filter = ` WHERE target_type = $1 AND target_id = $2`
+	)
+
+	if req.Type != "" {
+		args = append(args, req.Type)
+		filter += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+
+	if req.Cursor != "" {
+		createdAt, userID, err := cursor.Decode(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, createdAt, userID)
+		filter += fmt.Sprintf(" AND (created_at, user_id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	// one extra row tells whether there is a next page
+	filter += fmt.Sprintf(" ORDER BY created_at DESC, user_id DESC LIMIT %d", req.Limit+1)
+
+	rows, err := s.db.Query(ctx, `
+		SELECT
+			target_type,
+			target_id,
+			user_id,
+			type,
+			created_at
+		FROM reactions`+filter, args...)
+	if err != nil {
+		log.Println("error while getting reactions:", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var lastCreatedAt time.Time
+	for rows.Next() {
+		var (
+			reaction   us.Reaction
+			created_at time.Time
+		)
+
+		err = rows.Scan(&reaction.TargetType, &reaction.TargetId, &reaction.UserId, &reaction.Type, &created_at)
+		if err != nil {
+			log.Println("error while scanning reactions:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].UserId)
+			break
+		}
+
+		reaction.CreatedAt = created_at.Format(time.RFC3339)
+		resp.Items = append(resp.Items, &reaction)
+		lastCreatedAt = created_at
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *ReactionRepo) summary(ctx context.Context, table, targetType, targetID, userID string) (*us.ReactionSummary, error) {
+	var (
+		resp = &us.ReactionSummary{
+			TargetType: targetType,
+			TargetId:   targetID,
+		}
+		counts []byte
+	)
+
+	err := s.db.QueryRow(ctx, fmt.Sprintf(`
+		SELECT
+			t.reaction_counts,
+			COALESCE(r.type::text, '')
+		FROM %s t
+		LEFT JOIN reactions r ON r.target_type = $1 AND r.target_id = t.id AND r.user_id = $3
+		WHERE t.id = $2`, table), targetType, targetID, userID).Scan(&counts, &resp.ViewerReaction)
+	if err != nil {
+		log.Println("error while getting reaction summary", err)
+		return nil, err
+	}
+
+	if err := json.Unmarshal(counts, &resp.Counts); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// lockReactionTarget locks the post or comment, pgx.ErrNoRows is returned if it does not exist.
+func lockReactionTarget(ctx context.Context, tx pgx.Tx, table, id string) error {
+	var locked string
+	return tx.QueryRow(ctx, fmt.Sprintf(`SELECT id FROM %s WHERE id = $1 FOR UPDATE`, table), id).Scan(&locked)
+}
+
+// addReactionCount adds delta to the counter of the reaction type, counters that drop to zero are removed.
+func addReactionCount(ctx context.Context, tx pgx.Tx, table, id, reactionType string, delta int) error {
+	_, err := tx.Exec(ctx, fmt.Sprintf(`
+		UPDATE %s SET reaction_counts = CASE
+			WHEN COALESCE((reaction_counts->>$2::text)::int, 0) + $3::int > 0
+			THEN jsonb_set(reaction_counts, ARRAY[$2::text], to_jsonb(COALESCE((reaction_counts->>$2::text)::int, 0) + $3::int))
+			ELSE reaction_counts - $2::text
+		END
+		WHERE id = $1`, table), id, reactionType, delta)
+	return err
+}
+
+// viewerReaction returns a column expression with the reaction of the viewer to the target,
+// an empty string when the viewer did not react or is not known.
+func viewerReaction(targetType, targetID, viewerParam string) string {
+	return fmt.Sprintf(`COALESCE((
+		SELECT r.type::text FROM reactions r
+		WHERE r.target_type = '%s' AND r.target_id = %s AND r.user_id = NULLIF(%s, '')::uuid
+	), '')`, targetType, targetID, viewerParam)
+}
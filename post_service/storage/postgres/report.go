@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// reportColumns selects a report aliased r.
+const reportColumns = `
+		r.id,
+		r.target_type,
+		r.target_id,
+		r.reporter_id,
+		r.reason,
+		r.details,
+		r.status,
+		COALESCE(r.action_id::text, ''),
+		r.created_at,
+		r.updated_at,
+		r.resolved_at`
+
+func scanReport(row pgx.Row) (*us.Report, time.Time, error) {
+	var (
+		report               = &us.Report{}
+		createdAt, updatedAt time.Time
+		resolvedAt           sql.NullTime
+	)
+
+	err := row.Scan(&report.Id, &report.TargetType, &report.TargetId, &report.ReporterId, &report.Reason, &report.Details, &report.Status, &report.ActionId, &createdAt, &updatedAt, &resolvedAt)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	report.CreatedAt = createdAt.Format(time.RFC3339)
+	report.UpdatedAt = updatedAt.Format(time.RFC3339)
+	if resolvedAt.Valid {
+		report.ResolvedAt = resolvedAt.Time.Format(time.RFC3339)
+	}
+
+	return report, createdAt, nil
+}
+
+type ReportRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepo(db *pgxpool.Pool) storage.ReportRepoI {
+	return &ReportRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.ReportRepoI.
+// The open report of the reporter for the target is updated if there is one.
+func (s *ReportRepo) Create(ctx context.Context, req *us.Report) (*us.Report, error) {
+	resp, _, err := scanReport(s.db.QueryRow(ctx, `
+		INSERT INTO reports AS r (
+			id,
+			target_type,
+			target_id,
+			reporter_id,
+			reason,
+			details
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		ON CONFLICT (reporter_id, target_type, target_id) WHERE status = 'open' DO UPDATE SET
+			reason = EXCLUDED.reason,
+			details = EXCLUDED.details,
+			updated_at = NOW()
+		RETURNING`+reportColumns, uuid.NewString(), req.TargetType, req.TargetId, req.ReporterId, req.Reason, req.Details))
+	if err != nil {
+		log.Println("error while creating report", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetList implements storage.ReportRepoI.
+func (s *ReportRepo) GetList(ctx context.Context, req *us.GetListReportRequest) (*us.ReportList, error) {
+	var (
+		resp   = &us.ReportList{}
+		args   []interface{}
+		filter = " WHERE TRUE"
+	)
+
+	if req.TargetType != "" {
+		args = append(args, req.TargetType)
+		filter += fmt.Sprintf(" AND r.target_type = $%d", len(args))
+	}
+
+	if req.TargetId != "" {
+		args = append(args, req.TargetId)
+		filter += fmt.Sprintf(" AND r.target_id = $%d", len(args))
+	}
+
+	if req.Status != "" {
+		args = append(args, req.Status)
+		filter += fmt.Sprintf(" AND r.status = $%d", len(args))
+	}
+
+	filter, args, err := page{alias: "r.", cursor: req.Cursor, limit: req.Limit}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT`+reportColumns+` FROM reports r`+filter, args...)
+	if err != nil {
+		log.Println("error while getting reports:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lastCreatedAt time.Time
+	for rows.Next() {
+		report, createdAt, err := scanReport(rows)
+		if err != nil {
+			log.Println("error while scanning reports:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
+		resp.Items = append(resp.Items, report)
+		lastCreatedAt = createdAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetQueue implements storage.ReportRepoI.
+// Targets are ordered by their oldest open report and the cursor points at it.
+func (s *ReportRepo) GetQueue(ctx context.Context, req *us.GetModerationQueueRequest) (*us.ModerationQueue, error) {
+	var (
+		resp   = &us.ModerationQueue{}
+		args   []interface{}
+		filter = " WHERE r.status = 'open'"
+		having string
+	)
+
+	if req.TargetType != "" {
+		args = append(args, req.TargetType)
+		filter += fmt.Sprintf(" AND r.target_type = $%d", len(args))
+	}
+
+	if req.Reason != "" {
+		args = append(args, req.Reason)
+		having = fmt.Sprintf(" HAVING bool_or(r.reason = $%d)", len(args))
+	}
+
+	page, args, err := page{alias: "q.", cursor: req.Cursor, limit: req.Limit, asc: true}.apply(" WHERE TRUE", args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT
+			q.target_type,
+			q.id,
+			q.report_count,
+			q.reasons,
+			q.created_at,
+			q.last_reported_at
+		FROM (
+			SELECT
+				r.target_type,
+				r.target_id AS id,
+				COUNT(*) AS report_count,
+				array_agg(DISTINCT r.reason::text) AS reasons,
+				MIN(r.created_at) AS created_at,
+				MAX(r.created_at) AS last_reported_at
+			FROM reports r`+filter+`
+			GROUP BY r.target_type, r.target_id`+having+`
+		) q`+page, args...)
+	if err != nil {
+		log.Println("error while getting moderation queue:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lastReportedAt time.Time
+	for rows.Next() {
+		var (
+			item            = &us.ModerationQueueItem{}
+			firstAt, lastAt time.Time
+		)
+
+		err := rows.Scan(&item.TargetType, &item.TargetId, &item.ReportCount, &item.Reasons, &firstAt, &lastAt)
+		if err != nil {
+			log.Println("error while scanning moderation queue:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastReportedAt, resp.Items[len(resp.Items)-1].TargetId)
+			break
+		}
+
+		item.FirstReportedAt = firstAt.Format(time.RFC3339)
+		item.LastReportedAt = lastAt.Format(time.RFC3339)
+		resp.Items = append(resp.Items, item)
+		lastReportedAt = firstAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/poststatus"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// editPost writes the content and the status of a post and keeps the previous version as a revision.
+// Nothing is kept when neither changes, and edited_at only moves when the content changes.
+// storage.ErrPostHidden is returned for a post hidden by a moderator, and an error wrapping
+// poststatus.ErrTransition when the post can not be given the status.
+func editPost(ctx context.Context, tx pgx.Tx, id, content, status, editorID string) error {
+	// concurrent edits of a post would otherwise get the same revision number
+	var current string
+	err := tx.QueryRow(ctx, `SELECT status FROM posts WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if err != nil {
+		return err
+	}
+	if current == poststatus.Hidden {
+		return storage.ErrPostHidden
+	}
+	if err := poststatus.Check(current, status); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_revisions (
+			post_id,
+			revision,
+			content,
+			status,
+			tags,
+			editor_id,
+			created_at
+		)
+		SELECT
+			p.id,
+			COALESCE((SELECT MAX(r.revision) FROM post_revisions r WHERE r.post_id = p.id), 0) + 1,
+			p.content,
+			p.status,
+			p.tags,
+			COALESCE(p.edited_by, p.owner_id),
+			p.updated_at
+		FROM posts p
+		WHERE p.id = $1 AND (p.content <> $2 OR p.status <> $3)`, id, content, status)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE posts SET
+			edited_at = CASE WHEN content <> $2 THEN NOW() ELSE edited_at END,
+			edited_by = CASE WHEN content <> $2 OR status <> $3 THEN NULLIF($4::text, '')::uuid ELSE edited_by END,
+			published_at = CASE WHEN $3 = 'published' THEN COALESCE(published_at, NOW()) ELSE published_at END,
+			archived_at = CASE WHEN $3 <> 'archived' THEN NULL WHEN status = 'archived' THEN archived_at ELSE NOW() END,
+			content = $2,
+			status = $3,
+			updated_at = NOW()
+		WHERE id = $1`, id, content, status, editorID)
+
+	return err
+}
+
+type PostRevisionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostRevisionRepo(db *pgxpool.Pool) storage.PostRevisionRepoI {
+	return &PostRevisionRepo{
+		db: db,
+	}
+}
+
+const postRevisionColumns = `
+		post_id,
+		revision,
+		content,
+		status,
+		tags,
+		editor_id,
+		created_at`
+
+func scanPostRevision(row pgx.Row) (*us.PostRevision, error) {
+	var (
+		rev       = &us.PostRevision{}
+		editorID  sql.NullString
+		createdAt time.Time
+	)
+
+	if err := row.Scan(&rev.PostId, &rev.Revision, &rev.Content, &rev.Status, &rev.Tags, &editorID, &createdAt); err != nil {
+		return nil, err
+	}
+
+	rev.EditorId = editorID.String
+	rev.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return rev, nil
+}
+
+// GetSingle implements storage.PostRevisionRepoI.
+func (s *PostRevisionRepo) GetSingle(ctx context.Context, req *us.PostRevisionSingleRequest) (*us.PostRevision, error) {
+	rev, err := scanPostRevision(s.db.QueryRow(ctx, `
+		SELECT`+postRevisionColumns+`
+		FROM post_revisions
+		WHERE post_id = $1 AND revision = $2`, req.PostId, req.Revision))
+
+	if err != nil {
+		log.Println("error while getting post revision", err)
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// GetList implements storage.PostRevisionRepoI.
+func (s *PostRevisionRepo) GetList(ctx context.Context, req *us.GetListPostRevisionRequest) (*us.PostRevisionList, error) {
+	resp := &us.PostRevisionList{}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT`+postRevisionColumns+`
+		FROM post_revisions
+		WHERE post_id = $1
+		ORDER BY revision DESC`, req.PostId)
+	if err != nil {
+		log.Println("error while getting post revisions:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rev, err := scanPostRevision(rows)
+		if err != nil {
+			log.Println("error while scanning post revisions:", err)
+			return nil, err
+		}
+		resp.Items = append(resp.Items, rev)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	resp.Count = int64(len(resp.Items))
+
+	return resp, nil
+}
+
+// Restore implements storage.PostRevisionRepoI.
+// The content and the tags of the revision become the current version, the status is kept.
+func (s *PostRevisionRepo) Restore(ctx context.Context, req *us.RestorePostRevisionRequest) (*us.Post, error) {
+	rev, err := s.GetSingle(ctx, &us.PostRevisionSingleRequest{PostId: req.PostId, Revision: req.Revision})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var status string
+	err = tx.QueryRow(ctx, `SELECT status FROM posts WHERE id = $1 FOR UPDATE`, req.PostId).Scan(&status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := editPost(ctx, tx, req.PostId, rev.Content, status, req.EditorId); err != nil {
+		log.Println("error while restoring post revision", err)
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE posts SET tags = $1 WHERE id = $2`, rev.Tags, req.PostId)
+	if err != nil {
+		log.Println("error while restoring post tags", err)
+		return nil, err
+	}
+
+	if err := replacePostTags(ctx, tx, req.PostId, rev.Tags); err != nil {
+		log.Println("error while replacing post tags", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return NewPostRepo(s.db).GetSingle(ctx, &us.PostSingleRequest{Id: req.PostId})
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	us "post_service/genproto/post_service"
+	"post_service/pkg/cursor"
+	"post_service/pkg/hashtag"
+	"post_service/storage"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// tagSources maps the keys of posts.tags to the source of the tags stored under them.
+// The camel case keys were written by older versions of UpdatePost.
+var tagSources = map[string]string{
+	"gemini_tags":      "ai",
+	"geminiTags":       "ai",
+	"content_hashtags": "hashtag",
+	"getTagBody":       "hashtag",
+	"manual_tags":      "manual",
+}
+
+type TagRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTagRepo(db *pgxpool.Pool) storage.TagRepoI {
+	return &TagRepo{
+		db: db,
+	}
+}
+
+// replacePostTags makes the rows of the post in post_tags match tags.
+// Rows that are kept keep their created_at.
+func replacePostTags(ctx context.Context, tx pgx.Tx, postID string, tags map[string]*us.StringList) error {
+	var (
+		names   = []string{}
+		sources = []string{}
+		seen    = map[[2]string]bool{}
+	)
+
+	for key, list := range tags {
+		source, ok := tagSources[key]
+		if !ok {
+			continue
+		}
+
+		for _, value := range list.GetValues() {
+			name := hashtag.Normalize(value)
+			if name == "" || seen[[2]string{name, source}] {
+				continue
+			}
+
+			seen[[2]string{name, source}] = true
+			names = append(names, name)
+			sources = append(sources, source)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `
+		DELETE FROM post_tags
+		WHERE post_id = $1 AND (tag, source::text) NOT IN (SELECT * FROM unnest($2::text[], $3::text[]))
+	`, postID, names, sources)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_tags (post_id, tag, source)
+		SELECT $1, t.tag, t.source::tag_source
+		FROM unnest($2::text[], $3::text[]) AS t(tag, source)
+		ON CONFLICT DO NOTHING
+	`, postID, names, sources)
+
+	return err
+}
+
+// tagCategory selects the category of the default tag matching the normalized tag.
+func tagCategory(tag string) string {
+	return `COALESCE((
+		SELECT category FROM default_tags
+		WHERE lower(ltrim(name, '#')) = ` + tag + `
+		ORDER BY id
+		LIMIT 1
+	), '')`
+}
+
+// GetSingle implements storage.TagRepoI.
+// The post count only counts public posts, like the trends.
+func (s *TagRepo) GetSingle(ctx context.Context, req *us.TagSingleRequest) (*us.Tag, error) {
+	resp := &us.Tag{Tag: req.Tag}
+
+	err := s.db.QueryRow(ctx, `
+		SELECT
+			(
+				SELECT COUNT(DISTINCT pt.post_id)
+				FROM post_tags pt
+				JOIN posts p ON p.id = pt.post_id
+				WHERE pt.tag = $1 AND p.status = 'published' AND p.visibility = 'public'
+			),
+			`+tagCategory("$1")+`
+	`, req.Tag).Scan(&resp.PostCount, &resp.Category)
+
+	if err != nil {
+		log.Println("error while getting tag", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetPosts implements storage.TagRepoI.
+func (s *TagRepo) GetPosts(ctx context.Context, req *us.GetTagPostsRequest) (*us.PostList, error) {
+	var (
+		resp   = &us.PostList{}
+		args   = []interface{}{req.ViewerId, req.Tag}
+		source = ""
+	)
+
+	if req.Source != "" {
+		args = append(args, req.Source)
+		source = fmt.Sprintf(" AND pt.source = $%d", len(args))
+	}
+
+	filter := ` WHERE p.status = 'published' AND EXISTS (
+		SELECT 1 FROM post_tags pt WHERE pt.post_id = p.id AND pt.tag = $2` + source + `
+	)` + " AND " + postVisible("$1")
+
+	filter, args, err := page{alias: "p.", cursor: req.Cursor, limit: req.Limit}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT`+postColumns("$1")+` FROM posts p`+filter, args...)
+	if err != nil {
+		log.Println("error while getting tag posts:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lastCreatedAt time.Time
+	for rows.Next() {
+		post, createdAt, err := scanPost(rows)
+		if err != nil {
+			log.Println("error while scanning tag posts:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].Id)
+			break
+		}
+
+		resp.Items = append(resp.Items, post)
+		lastCreatedAt = createdAt
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetTrending implements storage.TagRepoI.
+// Events are public published posts, active comments and reactions to them. An event counts once
+// for every tag of its post, however many sources the tag has. Followers-only and private posts are
+// left out, the trends are the same for every viewer.
+func (s *TagRepo) GetTrending(ctx context.Context, req *storage.TrendingQuery) ([]storage.TrendingTag, error) {
+	rows, err := s.db.Query(ctx, `
+		WITH events AS (
+			SELECT p.id AS post_id, p.created_at AS at, $3::float8 AS weight
+			FROM posts p
+			WHERE p.created_at >= LOCALTIMESTAMP - $1::interval
+			UNION ALL
+			SELECT c.post_id, c.created_at, $4::float8
+			FROM comments c
+			WHERE c.status = 'active' AND c.created_at >= LOCALTIMESTAMP - $1::interval
+			UNION ALL
+			SELECT r.target_id, r.created_at, $5::float8
+			FROM reactions r
+			WHERE r.target_type = 'post' AND r.created_at >= LOCALTIMESTAMP - $1::interval
+		), scores AS (
+			SELECT t.tag, SUM(e.weight * power(0.5, extract(epoch FROM LOCALTIMESTAMP - e.at) / $2)) AS score
+			FROM events e
+			JOIN posts p ON p.id = e.post_id AND p.status = 'published' AND p.visibility = 'public'
+			JOIN (SELECT DISTINCT post_id, tag FROM post_tags) t ON t.post_id = e.post_id
+			WHERE NOT EXISTS (SELECT 1 FROM blocked_tags b WHERE b.tag = t.tag)
+			GROUP BY t.tag
+			ORDER BY score DESC
+			LIMIT $6
+		)
+		SELECT tag, `+tagCategory("scores.tag")+`, score
+		FROM scores
+		ORDER BY score DESC, tag
+	`, req.Window, req.HalfLife.Seconds(), req.PostWeight, req.CommentWeight, req.ReactionWeight, req.Limit)
+	if err != nil {
+		log.Println("error while getting trending tags:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []storage.TrendingTag
+	for rows.Next() {
+		var tag storage.TrendingTag
+		if err := rows.Scan(&tag.Tag, &tag.Category, &tag.Score); err != nil {
+			log.Println("error while scanning trending tags:", err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
@@ -0,0 +1,34 @@
+package postgres_test
+
+import (
+	"context"
+	us "post_service/genproto/post_service"
+	"post_service/storage/postgres"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagCountsPublicPosts(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(t)
+	owner := testUser(t, db)
+	tag := "test" + uuid.NewString()[:8]
+
+	for _, visibility := range []string{"public", "followers", "private"} {
+		_, err := postgres.NewPostRepo(db).Create(ctx, &us.Post{
+			OwnerId:    owner,
+			Content:    visibility,
+			Status:     "published",
+			Visibility: visibility,
+			Tags:       map[string]*us.StringList{"manual_tags": {Values: []string{tag}}},
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := postgres.NewTagRepo(db).GetSingle(ctx, &us.TagSingleRequest{Tag: tag})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.PostCount)
+}
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"post_service/config"
+	"post_service/storage"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type Store struct {
+	rdb      *redis.Client
+	timeline storage.TimelineRepoI
+	trending storage.TrendingRepoI
+	view     storage.ViewRepoI
+}
+
+func NewRedis(ctx context.Context, cfg config.Config) (storage.CacheI, error) {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.RedisHost, cfg.RedisPort),
+		Password: cfg.RedisPassword,
+	})
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		rdb: rdb,
+	}, nil
+}
+
+func (s *Store) Close() {
+	s.rdb.Close()
+}
+
+// Timeline implements storage.CacheI.
+func (s *Store) Timeline() storage.TimelineRepoI {
+	if s.timeline == nil {
+		s.timeline = NewTimelineRepo(s.rdb)
+	}
+
+	return s.timeline
+}
+
+// Trending implements storage.CacheI.
+func (s *Store) Trending() storage.TrendingRepoI {
+	if s.trending == nil {
+		s.trending = NewTrendingRepo(s.rdb)
+	}
+
+	return s.trending
+}
+
+// View implements storage.CacheI.
+func (s *Store) View() storage.ViewRepoI {
+	if s.view == nil {
+		s.view = NewViewRepo(s.rdb)
+	}
+
+	return s.view
+}
@@ -0,0 +1,138 @@
+package redis
+
+import (
+	"context"
+	"post_service/storage"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// TimelineSize is the number of newest entries kept per timeline, older posts are read from postgres.
+	TimelineSize = 800
+	// timelineTTL drops the timelines of inactive users, they are rebuilt on the next read.
+	timelineTTL = 7 * 24 * time.Hour
+)
+
+// pushScript adds the entry only to timelines that exist. Adding it to a missing timeline would
+// create a timeline with a single post, which would hide the older posts from the reader.
+var pushScript = redis.NewScript(`
+for _, key in ipairs(KEYS) do
+	if redis.call('EXISTS', key) == 1 then
+		redis.call('ZADD', key, ARGV[1], ARGV[2])
+		redis.call('ZREMRANGEBYRANK', key, 0, -(tonumber(ARGV[3]) + 1))
+	end
+end
+return 0
+`)
+
+type TimelineRepo struct {
+	rdb *redis.Client
+}
+
+func NewTimelineRepo(rdb *redis.Client) storage.TimelineRepoI {
+	return &TimelineRepo{
+		rdb: rdb,
+	}
+}
+
+func timelineKey(userID string) string {
+	return "timeline:" + userID
+}
+
+// score orders entries by creation time, entries created in the same microsecond are ordered by post id,
+// which is how redis orders members with equal scores.
+func score(t time.Time) float64 {
+	return float64(t.UnixMicro())
+}
+
+// Exists implements storage.TimelineRepoI.
+func (s *TimelineRepo) Exists(ctx context.Context, userID string) (bool, error) {
+	n, err := s.rdb.Exists(ctx, timelineKey(userID)).Result()
+	return n == 1, err
+}
+
+// Replace implements storage.TimelineRepoI.
+func (s *TimelineRepo) Replace(ctx context.Context, userID string, entries []storage.TimelineEntry) error {
+	key := timelineKey(userID)
+
+	members := make([]redis.Z, 0, len(entries))
+	for _, e := range entries {
+		members = append(members, redis.Z{Score: score(e.CreatedAt), Member: e.PostID})
+	}
+
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(members) > 0 {
+			pipe.ZAdd(ctx, key, members...)
+			pipe.ZRemRangeByRank(ctx, key, 0, -(TimelineSize + 1))
+			pipe.Expire(ctx, key, timelineTTL)
+		}
+		return nil
+	})
+	return err
+}
+
+// Push implements storage.TimelineRepoI.
+func (s *TimelineRepo) Push(ctx context.Context, userIDs []string, entry storage.TimelineEntry) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		keys = append(keys, timelineKey(id))
+	}
+
+	return pushScript.Run(ctx, s.rdb, keys, score(entry.CreatedAt), entry.PostID, TimelineSize).Err()
+}
+
+// GetBefore implements storage.TimelineRepoI.
+func (s *TimelineRepo) GetBefore(ctx context.Context, userID string, before *storage.TimelineEntry, limit int) ([]storage.TimelineEntry, error) {
+	key := timelineKey(userID)
+	upper := "+inf"
+
+	var ties []redis.Z
+	if before != nil {
+		upper = "(" + strconv.FormatFloat(score(before.CreatedAt), 'f', -1, 64)
+
+		// entries created in the same microsecond as before, only the ones with a smaller id come after it
+		sameScore := strconv.FormatFloat(score(before.CreatedAt), 'f', -1, 64)
+		all, err := s.rdb.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: sameScore, Max: sameScore}).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, z := range all {
+			if z.Member.(string) < before.PostID {
+				ties = append(ties, z)
+			}
+		}
+	}
+
+	older, err := s.rdb.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Min: "-inf", Max: upper, Count: int64(limit)}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	s.rdb.Expire(ctx, key, timelineTTL)
+
+	entries := make([]storage.TimelineEntry, 0, limit)
+	for _, z := range append(ties, older...) {
+		if len(entries) == limit {
+			break
+		}
+		entries = append(entries, storage.TimelineEntry{
+			PostID:    z.Member.(string),
+			CreatedAt: time.UnixMicro(int64(z.Score)).UTC(),
+		})
+	}
+
+	return entries, nil
+}
+
+// Delete implements storage.TimelineRepoI.
+func (s *TimelineRepo) Delete(ctx context.Context, userID string) error {
+	return s.rdb.Del(ctx, timelineKey(userID)).Err()
+}
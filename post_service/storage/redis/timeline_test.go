@@ -0,0 +1,99 @@
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"post_service/storage"
+	postredis "post_service/storage/redis"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTimelineRepo(t *testing.T) storage.TimelineRepoI {
+	mr := miniredis.RunT(t)
+	return postredis.NewTimelineRepo(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+}
+
+func entry(id string, minute int) storage.TimelineEntry {
+	return storage.TimelineEntry{
+		PostID:    id,
+		CreatedAt: time.Date(2025, 1, 1, 12, minute, 0, 123456000, time.UTC),
+	}
+}
+
+func ids(entries []storage.TimelineEntry) []string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.PostID)
+	}
+	return out
+}
+
+func TestTimeline_PushSkipsMissingTimelines(t *testing.T) {
+	ctx := context.Background()
+	repo := newTimelineRepo(t)
+
+	require.NoError(t, repo.Replace(ctx, "alice", []storage.TimelineEntry{entry("a1", 1)}))
+	require.NoError(t, repo.Push(ctx, []string{"alice", "bob"}, entry("p2", 2)))
+
+	got, err := repo.GetBefore(ctx, "alice", nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p2", "a1"}, ids(got))
+
+	exists, err := repo.Exists(ctx, "bob")
+	require.NoError(t, err)
+	assert.False(t, exists, "a push must not create a partial timeline")
+}
+
+func TestTimeline_GetBeforePaginates(t *testing.T) {
+	ctx := context.Background()
+	repo := newTimelineRepo(t)
+
+	same := entry("", 5).CreatedAt
+	require.NoError(t, repo.Replace(ctx, "alice", []storage.TimelineEntry{
+		entry("p1", 1),
+		{PostID: "p5a", CreatedAt: same},
+		{PostID: "p5b", CreatedAt: same},
+		{PostID: "p5c", CreatedAt: same},
+		entry("p9", 9),
+	}))
+
+	first, err := repo.GetBefore(ctx, "alice", nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p9", "p5c"}, ids(first))
+	assert.True(t, first[1].CreatedAt.Equal(same), "the timestamp must survive the round trip")
+
+	second, err := repo.GetBefore(ctx, "alice", &first[1], 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p5b", "p5a"}, ids(second))
+
+	third, err := repo.GetBefore(ctx, "alice", &second[1], 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1"}, ids(third))
+}
+
+func TestTimeline_IsTrimmed(t *testing.T) {
+	ctx := context.Background()
+	repo := newTimelineRepo(t)
+
+	var entries []storage.TimelineEntry
+	for i := 0; i < postredis.TimelineSize+5; i++ {
+		entries = append(entries, storage.TimelineEntry{
+			PostID:    fmt.Sprintf("p%04d", i),
+			CreatedAt: time.Date(2025, 1, 1, 0, 0, i, 0, time.UTC),
+		})
+	}
+	require.NoError(t, repo.Replace(ctx, "alice", entries[:postredis.TimelineSize]))
+	require.NoError(t, repo.Push(ctx, []string{"alice"}, entries[len(entries)-1]))
+
+	got, err := repo.GetBefore(ctx, "alice", nil, postredis.TimelineSize+10)
+	require.NoError(t, err)
+	require.Len(t, got, postredis.TimelineSize)
+	assert.Equal(t, entries[len(entries)-1].PostID, got[0].PostID)
+	assert.Equal(t, "p0001", got[len(got)-1].PostID, "the oldest entry is dropped")
+}
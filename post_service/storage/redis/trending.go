@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"post_service/storage"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type TrendingRepo struct {
+	rdb *redis.Client
+}
+
+func NewTrendingRepo(rdb *redis.Client) storage.TrendingRepoI {
+	return &TrendingRepo{
+		rdb: rdb,
+	}
+}
+
+func trendingKey(window, category string) string {
+	key := "trending:" + window
+	if category != "" {
+		key += ":" + strings.ToLower(category)
+	}
+	return key
+}
+
+// Replace implements storage.TrendingRepoI.
+func (s *TrendingRepo) Replace(ctx context.Context, window, category string, tags []storage.TrendingTag, ttl time.Duration) error {
+	key := trendingKey(window, category)
+
+	members := make([]redis.Z, 0, len(tags))
+	for _, t := range tags {
+		members = append(members, redis.Z{Score: t.Score, Member: t.Tag})
+	}
+
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(members) > 0 {
+			pipe.ZAdd(ctx, key, members...)
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// Get implements storage.TrendingRepoI.
+func (s *TrendingRepo) Get(ctx context.Context, window, category string, limit int) ([]storage.TrendingTag, error) {
+	members, err := s.rdb.ZRevRangeWithScores(ctx, trendingKey(window, category), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]storage.TrendingTag, 0, len(members))
+	for _, m := range members {
+		tags = append(tags, storage.TrendingTag{
+			Tag:      m.Member.(string),
+			Category: category,
+			Score:    m.Score,
+		})
+	}
+
+	return tags, nil
+}
+
+// Lock implements storage.TrendingRepoI.
+func (s *TrendingRepo) Lock(ctx context.Context, ttl time.Duration) (bool, error) {
+	return s.rdb.SetNX(ctx, "trending:lock", 1, ttl).Result()
+}
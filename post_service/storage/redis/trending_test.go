@@ -0,0 +1,77 @@
+package redis_test
+
+import (
+	"context"
+	"post_service/storage"
+	postredis "post_service/storage/redis"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTrendingRepo(t *testing.T) (storage.TrendingRepoI, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	return postredis.NewTrendingRepo(redis.NewClient(&redis.Options{Addr: mr.Addr()})), mr
+}
+
+func TestTrending_ReplaceAndGet(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTrendingRepo(t)
+
+	require.NoError(t, repo.Replace(ctx, "24h", "Sport", []storage.TrendingTag{
+		{Tag: "football", Score: 3.5},
+		{Tag: "tennis", Score: 1.25},
+		{Tag: "chess", Score: 7},
+	}, time.Minute))
+
+	tags, err := repo.Get(ctx, "24h", "sport", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.TrendingTag{
+		{Tag: "chess", Category: "sport", Score: 7},
+		{Tag: "football", Category: "sport", Score: 3.5},
+	}, tags, "highest score first, the category is case insensitive")
+
+	mr.FastForward(time.Minute)
+	tags, err = repo.Get(ctx, "24h", "sport", 2)
+	require.NoError(t, err)
+	assert.Empty(t, tags, "the set expires")
+}
+
+func TestTrending_ReplaceDropsOldTags(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTrendingRepo(t)
+
+	require.NoError(t, repo.Replace(ctx, "1h", "", []storage.TrendingTag{{Tag: "old", Score: 10}}, time.Minute))
+	require.NoError(t, repo.Replace(ctx, "1h", "", []storage.TrendingTag{{Tag: "new", Score: 1}}, time.Minute))
+
+	tags, err := repo.Get(ctx, "1h", "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.TrendingTag{{Tag: "new", Score: 1}}, tags)
+
+	require.NoError(t, repo.Replace(ctx, "1h", "", nil, time.Minute))
+	tags, err = repo.Get(ctx, "1h", "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestTrending_Lock(t *testing.T) {
+	ctx := context.Background()
+	repo, mr := newTrendingRepo(t)
+
+	ok, err := repo.Lock(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = repo.Lock(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "the lock is held until it expires")
+
+	mr.FastForward(time.Minute)
+	ok, err = repo.Lock(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,125 @@
+package redis
+
+import (
+	"context"
+	"post_service/storage"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// viewsPendingKey is the set of posts with views that were not popped yet.
+	viewsPendingKey = "views:pending"
+	// viewersTTL drops the unique viewers of posts nobody looked at for a while. The estimate flushed
+	// last stays in postgres, it is only replaced by a larger one.
+	viewersTTL = 30 * 24 * time.Hour
+)
+
+func viewsKey(postID string) string {
+	return "views:" + postID
+}
+
+func viewersKey(postID string) string {
+	return "views:viewers:" + postID
+}
+
+type ViewRepo struct {
+	rdb *redis.Client
+}
+
+func NewViewRepo(rdb *redis.Client) storage.ViewRepoI {
+	return &ViewRepo{
+		rdb: rdb,
+	}
+}
+
+// AddViews implements storage.ViewRepoI.
+func (s *ViewRepo) AddViews(ctx context.Context, viewerID string, postIDs ...string) error {
+	return s.add(ctx, "views", viewerID, postIDs)
+}
+
+// AddImpressions implements storage.ViewRepoI.
+func (s *ViewRepo) AddImpressions(ctx context.Context, viewerID string, postIDs ...string) error {
+	return s.add(ctx, "impressions", viewerID, postIDs)
+}
+
+func (s *ViewRepo) add(ctx context.Context, field, viewerID string, postIDs []string) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range postIDs {
+			pipe.HIncrBy(ctx, viewsKey(id), field, 1)
+			if viewerID != "" {
+				pipe.PFAdd(ctx, viewersKey(id), viewerID)
+				pipe.Expire(ctx, viewersKey(id), viewersTTL)
+			}
+			pipe.SAdd(ctx, viewsPendingKey, id)
+		}
+		return nil
+	})
+	return err
+}
+
+// Pop implements storage.ViewRepoI.
+// A post is removed from the pending set before its counts are read, views added in between mark
+// it pending again, so no view is lost. Replicas popping at the same time get different posts.
+func (s *ViewRepo) Pop(ctx context.Context, limit int) ([]storage.ViewCounts, error) {
+	ids, err := s.rdb.SPopN(ctx, viewsPendingKey, int64(limit)).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var (
+		counts  = make([]*redis.MapStringStringCmd, len(ids))
+		viewers = make([]*redis.IntCmd, len(ids))
+	)
+
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			counts[i] = pipe.HGetAll(ctx, viewsKey(id))
+			pipe.Del(ctx, viewsKey(id))
+			viewers[i] = pipe.PFCount(ctx, viewersKey(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]storage.ViewCounts, 0, len(ids))
+	for i, id := range ids {
+		c := counts[i].Val()
+		views, _ := strconv.ParseInt(c["views"], 10, 64)
+		impressions, _ := strconv.ParseInt(c["impressions"], 10, 64)
+
+		resp = append(resp, storage.ViewCounts{
+			PostID:        id,
+			Views:         views,
+			Impressions:   impressions,
+			UniqueViewers: viewers[i].Val(),
+		})
+	}
+
+	return resp, nil
+}
+
+// Restore implements storage.ViewRepoI.
+func (s *ViewRepo) Restore(ctx context.Context, counts []storage.ViewCounts) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, c := range counts {
+			pipe.HIncrBy(ctx, viewsKey(c.PostID), "views", c.Views)
+			pipe.HIncrBy(ctx, viewsKey(c.PostID), "impressions", c.Impressions)
+			pipe.SAdd(ctx, viewsPendingKey, c.PostID)
+		}
+		return nil
+	})
+	return err
+}
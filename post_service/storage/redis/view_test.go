@@ -0,0 +1,83 @@
+package redis_test
+
+import (
+	"context"
+	"post_service/storage"
+	postredis "post_service/storage/redis"
+	"sort"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newViewRepo(t *testing.T) storage.ViewRepoI {
+	mr := miniredis.RunT(t)
+	return postredis.NewViewRepo(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+}
+
+func sortCounts(counts []storage.ViewCounts) []storage.ViewCounts {
+	sort.Slice(counts, func(i, j int) bool { return counts[i].PostID < counts[j].PostID })
+	return counts
+}
+
+func TestView_AddAndPop(t *testing.T) {
+	ctx := context.Background()
+	repo := newViewRepo(t)
+
+	require.NoError(t, repo.AddViews(ctx, "alice", "p1"))
+	require.NoError(t, repo.AddViews(ctx, "alice", "p1"))
+	require.NoError(t, repo.AddViews(ctx, "bob", "p1"))
+	require.NoError(t, repo.AddImpressions(ctx, "alice", "p1", "p2"))
+	require.NoError(t, repo.AddViews(ctx, "", "p2"))
+
+	counts, err := repo.Pop(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.ViewCounts{
+		{PostID: "p1", Views: 3, Impressions: 1, UniqueViewers: 2},
+		{PostID: "p2", Views: 1, Impressions: 1, UniqueViewers: 1},
+	}, sortCounts(counts), "anonymous views are not unique viewers")
+
+	counts, err = repo.Pop(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, counts, "popped counts are removed")
+
+	require.NoError(t, repo.AddViews(ctx, "alice", "p1"))
+	counts, err = repo.Pop(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.ViewCounts{{PostID: "p1", Views: 1, UniqueViewers: 2}}, counts, "unique viewers are kept across pops")
+}
+
+func TestView_PopLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := newViewRepo(t)
+
+	require.NoError(t, repo.AddViews(ctx, "alice", "p1", "p2", "p3"))
+
+	first, err := repo.Pop(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, first, 2)
+
+	rest, err := repo.Pop(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, rest, 1)
+	assert.NotContains(t, first, rest[0])
+}
+
+func TestView_Restore(t *testing.T) {
+	ctx := context.Background()
+	repo := newViewRepo(t)
+
+	require.NoError(t, repo.AddViews(ctx, "alice", "p1"))
+	counts, err := repo.Pop(ctx, 10)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddImpressions(ctx, "bob", "p1"))
+	require.NoError(t, repo.Restore(ctx, counts))
+
+	counts, err = repo.Pop(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.ViewCounts{{PostID: "p1", Views: 1, Impressions: 1, UniqueViewers: 2}}, counts)
+}
@@ -2,15 +2,114 @@ package storage
 
 import (
 	"context"
+	"errors"
 	us "post_service/genproto/post_service"
+	"post_service/genproto/user_service"
+	"time"
 
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// ErrPostHidden is returned when a post hidden by a moderator is edited.
+var ErrPostHidden = errors.New("the post was hidden by a moderator")
+
+// ErrPostNotPublished is returned when a moderator hides a post that is not published, drafts,
+// scheduled and archived posts are not visible to others.
+var ErrPostNotPublished = errors.New("only published posts can be hidden")
+
+// ErrPollClosed is returned for votes in a poll that is closed.
+var ErrPollClosed = errors.New("the poll is closed")
+
 type StorageI interface {
 	CloseDB()
 	PostAttachment() PostAttachmentRepoI
 	Post() PostRepoI
+	PostRevision() PostRevisionRepoI
+	Comment() CommentRepoI
+	Reaction() ReactionRepoI
+	Tag() TagRepoI
+	Mention() MentionRepoI
+	Bookmark() BookmarkRepoI
+	BookmarkCollection() BookmarkCollectionRepoI
+	Report() ReportRepoI
+	Moderation() ModerationRepoI
+	PostStats() PostStatsRepoI
+	Poll() PollRepoI
+	LinkPreview() LinkPreviewRepoI
+	Media() MediaRepoI
+	BlockedTag() BlockedTagRepoI
+	AuditEvent() AuditEventRepoI
+}
+
+// CacheI holds the data kept in Redis.
+type CacheI interface {
+	Close()
+	Timeline() TimelineRepoI
+	Trending() TrendingRepoI
+	View() ViewRepoI
+}
+
+// TimelineEntry is a post in a home timeline. Timelines are ordered by created_at and id, newest first,
+// the same way the feed query orders posts.
+type TimelineEntry struct {
+	PostID    string
+	CreatedAt time.Time
+}
+
+// TimelineEntryFilter selects published posts that are written by one of OwnerIDs or are in PostIDs.
+type TimelineEntryFilter struct {
+	PostIDs  []string
+	OwnerIDs []string
+	Limit    int
+}
+
+// FeedQuery selects published posts written by Owners, newest first.
+// A post is returned only if it is in PostIDs, written by one of Pulled, or older than PullBelow.
+// PullAll returns every post of Owners.
+type FeedQuery struct {
+	ViewerID  string
+	Owners    []string
+	PostIDs   []string
+	Pulled    []string
+	PullBelow *TimelineEntry
+	PullAll   bool
+	// Before is an exclusive upper bound and From an inclusive lower bound of the page
+	Before *TimelineEntry
+	From   *TimelineEntry
+	Limit  int
+}
+
+// FeedItem is a post of the feed with the exact creation time needed for the cursor.
+type FeedItem struct {
+	Post      *us.Post
+	CreatedAt time.Time
+}
+
+// TrendingQuery scores the tags by the events of the last Window. The weight of an event
+// halves every HalfLife, so newer events count more.
+type TrendingQuery struct {
+	Window         time.Duration
+	HalfLife       time.Duration
+	PostWeight     float64
+	CommentWeight  float64
+	ReactionWeight float64
+	Limit          int
+}
+
+// TrendingTag is a tag with its score in a trending window.
+type TrendingTag struct {
+	Tag      string
+	Category string
+	Score    float64
+}
+
+// ViewCounts are the views of a post counted since the last flush. UniqueViewers is the estimated
+// number of different viewers of all time.
+type ViewCounts struct {
+	PostID        string
+	Views         int64
+	Impressions   int64
+	UniqueViewers int64
 }
 
 type (
@@ -25,12 +124,211 @@ type (
 		GetDefaultTags(ctx context.Context, req *us.GetDefaultTagsRequest) (*us.GetDefaultTagsResponse, error)
 	}
 
+	// MediaRepoI keeps the files uploaded through the gateway. A file is stored once however many
+	// times it is uploaded, it is counted as referenced once per owner that uploaded it and once
+	// per attachment that uses it.
+	MediaRepoI interface {
+		// Create adds the upload of req.OwnerId, registering the file when it is new.
+		Create(ctx context.Context, req *us.Media) (*us.Media, error)
+		// GetByKeys returns the media stored under the keys, keys that are not found are left out.
+		GetByKeys(ctx context.Context, keys []string) ([]*us.Media, error)
+		// GetUploaded is GetByKeys for the media that ownerID uploaded.
+		GetUploaded(ctx context.Context, ownerID string, keys []string) ([]*us.Media, error)
+		// Delete removes the upload of req.OwnerId, pgx.ErrNoRows is returned when there is none.
+		// The file is removed with its last reference.
+		Delete(ctx context.Context, req *us.MediaDeleteRequest) (*us.Media, error)
+		// ListReleased returns up to limit keys of the blobs left without references, oldest first.
+		ListReleased(ctx context.Context, limit int64) ([]string, error)
+		// ForgetReleased drops the keys of the blobs the gateway deleted.
+		ForgetReleased(ctx context.Context, keys []string) error
+		// ClaimUnprocessed leases at most req.Limit uploaded photos that are not processed yet. A photo
+		// is claimed again when it is not processed within the lease, e.g. because the worker stopped.
+		ClaimUnprocessed(ctx context.Context, req *us.ClaimUnprocessedRequest) (*us.MediaJobList, error)
+		// SaveProcessed stores the stripped copy, dimensions and variants of a photo and marks it processed.
+		SaveProcessed(ctx context.Context, req *us.ProcessedMedia) error
+		// FailProcessing marks a photo that cannot be processed, so it is not tried again.
+		FailProcessing(ctx context.Context, req *us.FailProcessingRequest) error
+	}
+
 	// PostRepoI -.
 	PostRepoI interface {
 		Create(ctx context.Context, req *us.Post) (*us.Post, error)
 		GetSingle(ctx context.Context, req *us.PostSingleRequest) (*us.Post, error)
+		// GetVisible is GetSingle for a post the viewer of the request can read, pgx.ErrNoRows is
+		// returned for other posts as if they did not exist.
+		GetVisible(ctx context.Context, req *us.PostSingleRequest) (*us.Post, error)
 		GetList(ctx context.Context, req *us.GetListPostRequest) (*us.PostList, error)
 		Update(ctx context.Context, req *us.Post) (*us.Post, error)
 		Delete(ctx context.Context, req *us.PostSingleRequest) (*emptypb.Empty, error)
+		Search(ctx context.Context, req *us.SearchPostRequest) (*us.PostSearchResult, error)
+		Repost(ctx context.Context, req *us.RepostRequest) (*us.Post, error)
+		DeleteRepost(ctx context.Context, req *us.RepostRequest) (*emptypb.Empty, error)
+		// GetByIDs returns the published posts among ids the viewer can read, in no particular order.
+		GetByIDs(ctx context.Context, ids []string, viewerID string) ([]*us.Post, error)
+		Reschedule(ctx context.Context, req *us.ReschedulePostRequest) (*us.Post, error)
+		CancelSchedule(ctx context.Context, req *us.PostSingleRequest) (*us.Post, error)
+		// PublishDue publishes at most limit scheduled posts that are due, only id, owner_id and status are set.
+		PublishDue(ctx context.Context, limit int) ([]*us.Post, error)
+		GetTimelineEntries(ctx context.Context, req *TimelineEntryFilter) ([]TimelineEntry, error)
+		GetFeed(ctx context.Context, req *FeedQuery) ([]FeedItem, error)
+	}
+
+	// CommentRepoI -.
+	CommentRepoI interface {
+		Create(ctx context.Context, req *us.Comment) (*us.Comment, error)
+		GetSingle(ctx context.Context, req *us.CommentSingleRequest) (*us.Comment, error)
+		GetList(ctx context.Context, req *us.GetListCommentRequest) (*us.CommentList, error)
+		Update(ctx context.Context, req *us.Comment) (*us.Comment, error)
+		Delete(ctx context.Context, req *us.DeleteCommentRequest) (*emptypb.Empty, error)
+	}
+
+	// ReactionRepoI -.
+	ReactionRepoI interface {
+		Upsert(ctx context.Context, req *us.Reaction) (*us.ReactionSummary, error)
+		Delete(ctx context.Context, req *us.ReactionSingleRequest) (*us.ReactionSummary, error)
+		GetList(ctx context.Context, req *us.GetListReactionRequest) (*us.ReactionList, error)
+	}
+
+	// TimelineRepoI stores the home timelines of users with a bounded number of entries each.
+	TimelineRepoI interface {
+		Exists(ctx context.Context, userID string) (bool, error)
+		// Replace stores entries as the timeline of the user.
+		Replace(ctx context.Context, userID string, entries []TimelineEntry) error
+		// Push adds the entry to the timelines of userIDs that exist, missing timelines are built on read.
+		Push(ctx context.Context, userIDs []string, entry TimelineEntry) error
+		// GetBefore returns up to limit entries older than before, or the newest entries when before is nil.
+		GetBefore(ctx context.Context, userID string, before *TimelineEntry, limit int) ([]TimelineEntry, error)
+		Delete(ctx context.Context, userID string) error
+	}
+
+	// TrendingRepoI keeps one sorted set of trending tags per window and category.
+	TrendingRepoI interface {
+		// Replace stores tags as the trending tags of the window and category, an empty category is every tag.
+		// The tags expire after ttl unless they are replaced again.
+		Replace(ctx context.Context, window, category string, tags []TrendingTag, ttl time.Duration) error
+		// Get returns up to limit tags with the highest scores.
+		Get(ctx context.Context, window, category string, limit int) ([]TrendingTag, error)
+		// Lock returns true if no other instance holds the refresh lock, the lock expires after ttl.
+		Lock(ctx context.Context, ttl time.Duration) (bool, error)
+	}
+
+	// ViewRepoI counts the views of posts until they are flushed to postgres.
+	ViewRepoI interface {
+		// AddViews counts one view of each post, and the viewer as a unique viewer if it is set.
+		AddViews(ctx context.Context, viewerID string, postIDs ...string) error
+		// AddImpressions counts one impression of each post, e.g. when it is shown in a feed.
+		AddImpressions(ctx context.Context, viewerID string, postIDs ...string) error
+		// Pop removes and returns the counts of at most limit posts that were viewed since they were popped last.
+		Pop(ctx context.Context, limit int) ([]ViewCounts, error)
+		// Restore adds back counts that were popped but could not be flushed.
+		Restore(ctx context.Context, counts []ViewCounts) error
+	}
+
+	// TagRepoI -.
+	TagRepoI interface {
+		GetSingle(ctx context.Context, req *us.TagSingleRequest) (*us.Tag, error)
+		GetPosts(ctx context.Context, req *us.GetTagPostsRequest) (*us.PostList, error)
+		// GetTrending returns the tags with the highest scores, blocked tags are left out.
+		GetTrending(ctx context.Context, req *TrendingQuery) ([]TrendingTag, error)
+	}
+
+	// PostRevisionRepoI -.
+	PostRevisionRepoI interface {
+		GetSingle(ctx context.Context, req *us.PostRevisionSingleRequest) (*us.PostRevision, error)
+		GetList(ctx context.Context, req *us.GetListPostRevisionRequest) (*us.PostRevisionList, error)
+		Restore(ctx context.Context, req *us.RestorePostRevisionRequest) (*us.Post, error)
+	}
+
+	// MentionRepoI -.
+	MentionRepoI interface {
+		// Replace sets the mentions of a post, the previous ones are removed.
+		Replace(ctx context.Context, postID string, mentions []*us.Mention) error
+		GetPosts(ctx context.Context, req *us.GetMentionPostsRequest) (*us.PostList, error)
+	}
+
+	// BookmarkRepoI -.
+	BookmarkRepoI interface {
+		// Create bookmarks a post and adds it to the collection of the request if it is set,
+		// pgx.ErrNoRows is returned if the user does not own the collection.
+		Create(ctx context.Context, req *us.BookmarkRequest) error
+		Delete(ctx context.Context, req *us.BookmarkRequest) error
+		GetList(ctx context.Context, req *us.GetListBookmarkRequest) (*us.PostList, error)
+	}
+
+	// BookmarkCollectionRepoI -.
+	BookmarkCollectionRepoI interface {
+		Create(ctx context.Context, req *us.BookmarkCollection) (*us.BookmarkCollection, error)
+		GetSingle(ctx context.Context, req *us.BookmarkCollectionSingleRequest) (*us.BookmarkCollection, error)
+		GetList(ctx context.Context, req *us.GetListBookmarkCollectionRequest) (*us.BookmarkCollectionList, error)
+		// Update and Delete only change collections of the owner, or of the viewer for Delete,
+		// pgx.ErrNoRows is returned for other collections.
+		Update(ctx context.Context, req *us.BookmarkCollection) (*us.BookmarkCollection, error)
+		Delete(ctx context.Context, req *us.BookmarkCollectionSingleRequest) error
+		Reorder(ctx context.Context, req *us.ReorderBookmarkCollectionsRequest) (*us.BookmarkCollectionList, error)
+	}
+
+	// ReportRepoI -.
+	ReportRepoI interface {
+		// Create keeps one open report per reporter and target, reporting the target again updates it.
+		Create(ctx context.Context, req *us.Report) (*us.Report, error)
+		GetList(ctx context.Context, req *us.GetListReportRequest) (*us.ReportList, error)
+		// GetQueue groups the open reports by target, the post of an item is not set.
+		GetQueue(ctx context.Context, req *us.GetModerationQueueRequest) (*us.ModerationQueue, error)
+	}
+
+	// ModerationRepoI -.
+	ModerationRepoI interface {
+		// Create records the action, applies it to the post for hide_post and delete_post and
+		// closes the open reports of the target.
+		Create(ctx context.Context, req *us.ModerationAction) (*us.ModerationAction, error)
+		// SetJob records the bulk job of user_service that applies the action.
+		SetJob(ctx context.Context, id, jobID string) error
+		// Delete removes an action that could not be applied and opens the reports it closed again.
+		// Only block_user is removed this way, it does not change the post.
+		Delete(ctx context.Context, id string) error
+		GetList(ctx context.Context, req *us.GetListModerationActionRequest) (*us.ModerationActionList, error)
+	}
+
+	// PollRepoI -.
+	PollRepoI interface {
+		// GetByPostIDs returns the polls of the posts keyed by post id, with the choices of the viewer.
+		GetByPostIDs(ctx context.Context, postIDs []string, viewerID string) (map[string]*us.Poll, error)
+		// Vote records the choices of a user. A second vote of the user fails with a unique violation,
+		// ErrPollClosed is returned once the poll is closed and pgx.ErrNoRows for unknown options.
+		Vote(ctx context.Context, req *us.PollVoteRequest) error
+	}
+
+	// LinkPreviewRepoI caches the previews of URLs and keeps the URLs of every post.
+	LinkPreviewRepoI interface {
+		// ReplacePostLinks sets the URLs of a post in order, the previous ones are removed.
+		ReplacePostLinks(ctx context.Context, postID string, urls []string) error
+		// Stale returns the urls without a preview, or with one fetched more than ttl ago, failedTTL
+		// for previews that could not be fetched.
+		Stale(ctx context.Context, urls []string, ttl, failedTTL time.Duration) ([]string, error)
+		// Save stores the preview of url, a nil preview marks the url as failed.
+		Save(ctx context.Context, url string, preview *us.LinkPreview) error
+		// GetByPostIDs returns the previews of the posts in order keyed by post id, failed ones are left out.
+		GetByPostIDs(ctx context.Context, postIDs []string) (map[string][]*us.LinkPreview, error)
+	}
+
+	// PostStatsRepoI -.
+	PostStatsRepoI interface {
+		// Add adds the views to the stats of the posts, counts of deleted posts are dropped.
+		Add(ctx context.Context, counts []ViewCounts) error
+		// GetSingle returns the stats of a post, zero counts if it was never viewed.
+		GetSingle(ctx context.Context, postID string) (*us.PostStats, error)
+	}
+
+	// BlockedTagRepoI -.
+	BlockedTagRepoI interface {
+		Create(ctx context.Context, req *us.BlockedTag) (*us.BlockedTag, error)
+		Delete(ctx context.Context, req *us.TagSingleRequest) (*emptypb.Empty, error)
+		// Blocked returns which of tags are blocked.
+		Blocked(ctx context.Context, tags []string) (map[string]bool, error)
+	}
+
+	// AuditEventRepoI writes audit events, the audit_events table is owned and served by user_service.
+	AuditEventRepoI interface {
+		Create(ctx context.Context, req *user_service.AuditEvent) error
 	}
 )
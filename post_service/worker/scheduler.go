@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"post_service/config"
+	"post_service/genproto/post_service"
+	"post_service/storage"
+	"time"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+const (
+	defaultPublishInterval = 30 * time.Second
+	// publishBatch is the number of due posts published per query.
+	publishBatch = 100
+)
+
+// Publisher spreads a post the scheduler published, e.g. to the timelines of the followers.
+type Publisher interface {
+	FanOut(post *post_service.Post)
+}
+
+// Scheduler publishes scheduled posts once their publish time has come. Every replica runs it,
+// the due posts are split between them by row locks.
+type Scheduler struct {
+	cfg       config.Config
+	log       logger.LoggerI
+	strg      storage.StorageI
+	publisher Publisher
+}
+
+func NewScheduler(cfg config.Config, log logger.LoggerI, strg storage.StorageI, publisher Publisher) *Scheduler {
+	return &Scheduler{
+		cfg:       cfg,
+		log:       log,
+		strg:      strg,
+		publisher: publisher,
+	}
+}
+
+// Run publishes the due posts every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	interval := s.cfg.PublishInterval
+	if interval <= 0 {
+		interval = defaultPublishInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.publishDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) publishDue(ctx context.Context) {
+	for {
+		posts, err := s.strg.Post().PublishDue(ctx, publishBatch)
+		if err != nil {
+			s.log.Error("---PublishScheduledPosts--->>>", logger.Error(err))
+			return
+		}
+
+		for _, post := range posts {
+			s.log.Info("---PublishScheduledPosts--->>>", logger.String("post_id", post.Id))
+			s.publisher.FanOut(post)
+		}
+
+		if len(posts) < publishBatch {
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Package worker runs the background jobs of post_service.
+package worker
+
+import (
+	"context"
+	"post_service/config"
+	"post_service/storage"
+	"time"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+// TrendingWindows are the windows trending tags are computed for, keyed by their name in the API.
+var TrendingWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+const (
+	// TrendingSize is the number of tags kept per window and category.
+	TrendingSize = 50
+	// trendingCandidates are the highest scored tags of a window, the tags of each category are picked from them.
+	trendingCandidates = 2000
+
+	defaultTrendingInterval = 5 * time.Minute
+
+	// a post with the tag counts more than the engagement it gets
+	postWeight     = 1
+	commentWeight  = 0.5
+	reactionWeight = 0.25
+)
+
+// Trending recomputes the trending tags of every window and stores them in Redis.
+type Trending struct {
+	cfg   config.Config
+	log   logger.LoggerI
+	strg  storage.StorageI
+	cache storage.CacheI
+}
+
+func NewTrending(cfg config.Config, log logger.LoggerI, strg storage.StorageI, cache storage.CacheI) *Trending {
+	return &Trending{
+		cfg:   cfg,
+		log:   log,
+		strg:  strg,
+		cache: cache,
+	}
+}
+
+// Run refreshes the trending tags every interval until ctx is done.
+func (t *Trending) Run(ctx context.Context) {
+	interval := t.cfg.TrendingRefreshInterval
+	if interval <= 0 {
+		interval = defaultTrendingInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		t.refresh(ctx, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Trending) refresh(ctx context.Context, interval time.Duration) {
+	// only one instance refreshes per interval, the lock expires before the next tick
+	ok, err := t.cache.Trending().Lock(ctx, interval*9/10)
+	if err != nil {
+		t.log.Error("---RefreshTrending--->>>", logger.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+
+	for window, d := range TrendingWindows {
+		tags, err := t.strg.Tag().GetTrending(ctx, &storage.TrendingQuery{
+			Window:         d,
+			HalfLife:       d / 4,
+			PostWeight:     postWeight,
+			CommentWeight:  commentWeight,
+			ReactionWeight: reactionWeight,
+			Limit:          trendingCandidates,
+		})
+		if err != nil {
+			t.log.Error("---RefreshTrending--->>>", logger.Error(err), logger.String("window", window))
+			continue
+		}
+
+		// sets that are not replaced, e.g. of a category without tags, expire soon after the next refresh
+		for category, top := range ByCategory(tags, TrendingSize) {
+			if err := t.cache.Trending().Replace(ctx, window, category, top, 3*interval); err != nil {
+				t.log.Error("---RefreshTrending--->>>", logger.Error(err), logger.String("window", window))
+			}
+		}
+	}
+}
+
+// ByCategory returns the first size tags of every category, and the first size of all tags under
+// the empty category. tags must be ordered by score, highest first.
+func ByCategory(tags []storage.TrendingTag, size int) map[string][]storage.TrendingTag {
+	top := map[string][]storage.TrendingTag{"": {}}
+
+	for _, tag := range tags {
+		if len(top[""]) < size {
+			top[""] = append(top[""], tag)
+		}
+		if tag.Category != "" && len(top[tag.Category]) < size {
+			top[tag.Category] = append(top[tag.Category], tag)
+		}
+	}
+
+	return top
+}
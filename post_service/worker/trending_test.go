@@ -0,0 +1,32 @@
+package worker_test
+
+import (
+	"post_service/storage"
+	"post_service/worker"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByCategory(t *testing.T) {
+	tags := []storage.TrendingTag{
+		{Tag: "football", Category: "sport", Score: 9},
+		{Tag: "golang", Category: "technology", Score: 8},
+		{Tag: "tennis", Category: "sport", Score: 7},
+		{Tag: "monday", Score: 6},
+		{Tag: "chess", Category: "sport", Score: 5},
+	}
+
+	top := worker.ByCategory(tags, 2)
+
+	assert.Equal(t, []storage.TrendingTag{tags[0], tags[1]}, top[""])
+	assert.Equal(t, []storage.TrendingTag{tags[0], tags[2]}, top["sport"])
+	assert.Equal(t, []storage.TrendingTag{tags[1]}, top["technology"])
+	assert.Len(t, top, 3, "tags without a category are only in the global list")
+}
+
+func TestByCategory_Empty(t *testing.T) {
+	top := worker.ByCategory(nil, 10)
+
+	assert.Equal(t, map[string][]storage.TrendingTag{"": {}}, top, "the global list is replaced even when nothing trends")
+}
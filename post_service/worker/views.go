@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"post_service/config"
+	"post_service/storage"
+	"time"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+const (
+	defaultViewFlushInterval = time.Minute
+	// viewFlushBatch is the number of posts whose views are written per query.
+	viewFlushBatch = 500
+)
+
+// Views moves the view counts from Redis to postgres. Every replica runs it, the posts are split
+// between them by popping them from Redis.
+type Views struct {
+	cfg   config.Config
+	log   logger.LoggerI
+	strg  storage.StorageI
+	cache storage.CacheI
+}
+
+func NewViews(cfg config.Config, log logger.LoggerI, strg storage.StorageI, cache storage.CacheI) *Views {
+	return &Views{
+		cfg:   cfg,
+		log:   log,
+		strg:  strg,
+		cache: cache,
+	}
+}
+
+// Run flushes the view counts every interval until ctx is done.
+func (v *Views) Run(ctx context.Context) {
+	interval := v.cfg.ViewFlushInterval
+	if interval <= 0 {
+		interval = defaultViewFlushInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		v.flush(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *Views) flush(ctx context.Context) {
+	for {
+		counts, err := v.cache.View().Pop(ctx, viewFlushBatch)
+		if err != nil {
+			v.log.Error("---FlushViews--->>>", logger.Error(err))
+			return
+		}
+
+		if err := v.strg.PostStats().Add(ctx, counts); err != nil {
+			v.log.Error("---FlushViews--->>>", logger.Error(err))
+			// the counts are flushed with the next batch instead of getting lost
+			if err := v.cache.View().Restore(ctx, counts); err != nil {
+				v.log.Error("---FlushViews--->>>", logger.Error(err))
+			}
+			return
+		}
+
+		if len(counts) < viewFlushBatch {
+			return
+		}
+	}
+}
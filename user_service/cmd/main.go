@@ -6,6 +6,7 @@ import (
 	"user_service/config"
 	"user_service/grpc"
 	"user_service/grpc/client"
+	"user_service/grpc/service"
 	"user_service/storage/postgres"
 
 	"github.com/saidamir98/udevs_pkg/logger"
@@ -34,6 +35,8 @@ func main() {
 	}
 	defer pgStore.CloseDB()
 
+	go service.FailExpiredJobs(context.Background(), log, pgStore)
+
 	svcs, err := client.NewGrpcClients(cfg)
 	if err != nil {
 		log.Panic("client.NewGrpcClients", logger.Error(err))
@@ -20,11 +20,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PostService_Create_FullMethodName    = "/post_service.PostService/Create"
-	PostService_GetSingle_FullMethodName = "/post_service.PostService/GetSingle"
-	PostService_GetList_FullMethodName   = "/post_service.PostService/GetList"
-	PostService_Update_FullMethodName    = "/post_service.PostService/Update"
-	PostService_Delete_FullMethodName    = "/post_service.PostService/Delete"
+	PostService_Create_FullMethodName         = "/post_service.PostService/Create"
+	PostService_GetSingle_FullMethodName      = "/post_service.PostService/GetSingle"
+	PostService_GetList_FullMethodName        = "/post_service.PostService/GetList"
+	PostService_Update_FullMethodName         = "/post_service.PostService/Update"
+	PostService_Delete_FullMethodName         = "/post_service.PostService/Delete"
+	PostService_Search_FullMethodName         = "/post_service.PostService/Search"
+	PostService_Reschedule_FullMethodName     = "/post_service.PostService/Reschedule"
+	PostService_CancelSchedule_FullMethodName = "/post_service.PostService/CancelSchedule"
+	PostService_Repost_FullMethodName         = "/post_service.PostService/Repost"
+	PostService_Unrepost_FullMethodName       = "/post_service.PostService/Unrepost"
+	PostService_GetStats_FullMethodName       = "/post_service.PostService/GetStats"
+	PostService_Vote_FullMethodName           = "/post_service.PostService/Vote"
 )
 
 // PostServiceClient is the client API for PostService service.
@@ -36,6 +43,17 @@ type PostServiceClient interface {
 	GetList(ctx context.Context, in *GetListPostRequest, opts ...grpc.CallOption) (*PostList, error)
 	Update(ctx context.Context, in *Post, opts ...grpc.CallOption) (*Post, error)
 	Delete(ctx context.Context, in *PostSingleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Search(ctx context.Context, in *SearchPostRequest, opts ...grpc.CallOption) (*PostSearchResult, error)
+	Reschedule(ctx context.Context, in *ReschedulePostRequest, opts ...grpc.CallOption) (*Post, error)
+	CancelSchedule(ctx context.Context, in *PostSingleRequest, opts ...grpc.CallOption) (*Post, error)
+	// Repost reposts a published post, or quotes it when content is set
+	Repost(ctx context.Context, in *RepostRequest, opts ...grpc.CallOption) (*Post, error)
+	// Unrepost deletes the repost of a post by owner_id, quotes are deleted like other posts
+	Unrepost(ctx context.Context, in *RepostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetStats returns the view counts of a post to its owner and to admins
+	GetStats(ctx context.Context, in *PostStatsRequest, opts ...grpc.CallOption) (*PostStats, error)
+	// Vote records the choices of a user in the poll of a published post, a user votes once
+	Vote(ctx context.Context, in *PollVoteRequest, opts ...grpc.CallOption) (*Poll, error)
 }
 
 type postServiceClient struct {
@@ -96,6 +114,76 @@ func (c *postServiceClient) Delete(ctx context.Context, in *PostSingleRequest, o
 	return out, nil
 }
 
+func (c *postServiceClient) Search(ctx context.Context, in *SearchPostRequest, opts ...grpc.CallOption) (*PostSearchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostSearchResult)
+	err := c.cc.Invoke(ctx, PostService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Reschedule(ctx context.Context, in *ReschedulePostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, PostService_Reschedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) CancelSchedule(ctx context.Context, in *PostSingleRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, PostService_CancelSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Repost(ctx context.Context, in *RepostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, PostService_Repost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Unrepost(ctx context.Context, in *RepostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PostService_Unrepost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) GetStats(ctx context.Context, in *PostStatsRequest, opts ...grpc.CallOption) (*PostStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostStats)
+	err := c.cc.Invoke(ctx, PostService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postServiceClient) Vote(ctx context.Context, in *PollVoteRequest, opts ...grpc.CallOption) (*Poll, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PostService_Vote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostServiceServer is the server API for PostService service.
 // All implementations should embed UnimplementedPostServiceServer
 // for forward compatibility.
@@ -105,6 +193,17 @@ type PostServiceServer interface {
 	GetList(context.Context, *GetListPostRequest) (*PostList, error)
 	Update(context.Context, *Post) (*Post, error)
 	Delete(context.Context, *PostSingleRequest) (*emptypb.Empty, error)
+	Search(context.Context, *SearchPostRequest) (*PostSearchResult, error)
+	Reschedule(context.Context, *ReschedulePostRequest) (*Post, error)
+	CancelSchedule(context.Context, *PostSingleRequest) (*Post, error)
+	// Repost reposts a published post, or quotes it when content is set
+	Repost(context.Context, *RepostRequest) (*Post, error)
+	// Unrepost deletes the repost of a post by owner_id, quotes are deleted like other posts
+	Unrepost(context.Context, *RepostRequest) (*emptypb.Empty, error)
+	// GetStats returns the view counts of a post to its owner and to admins
+	GetStats(context.Context, *PostStatsRequest) (*PostStats, error)
+	// Vote records the choices of a user in the poll of a published post, a user votes once
+	Vote(context.Context, *PollVoteRequest) (*Poll, error)
 }
 
 // UnimplementedPostServiceServer should be embedded to have
@@ -129,6 +228,27 @@ func (UnimplementedPostServiceServer) Update(context.Context, *Post) (*Post, err
 func (UnimplementedPostServiceServer) Delete(context.Context, *PostSingleRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedPostServiceServer) Search(context.Context, *SearchPostRequest) (*PostSearchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedPostServiceServer) Reschedule(context.Context, *ReschedulePostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reschedule not implemented")
+}
+func (UnimplementedPostServiceServer) CancelSchedule(context.Context, *PostSingleRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
+}
+func (UnimplementedPostServiceServer) Repost(context.Context, *RepostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Repost not implemented")
+}
+func (UnimplementedPostServiceServer) Unrepost(context.Context, *RepostRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unrepost not implemented")
+}
+func (UnimplementedPostServiceServer) GetStats(context.Context, *PostStatsRequest) (*PostStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedPostServiceServer) Vote(context.Context, *PollVoteRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
+}
 func (UnimplementedPostServiceServer) testEmbeddedByValue() {}
 
 // UnsafePostServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -239,6 +359,132 @@ func _PostService_Delete_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Search(ctx, req.(*SearchPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Reschedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReschedulePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Reschedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_Reschedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Reschedule(ctx, req.(*ReschedulePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_CancelSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).CancelSchedule(ctx, req.(*PostSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Repost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Repost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_Repost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Repost(ctx, req.(*RepostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Unrepost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Unrepost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_Unrepost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Unrepost(ctx, req.(*RepostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).GetStats(ctx, req.(*PostStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostService_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostServiceServer).Vote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostService_Vote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostServiceServer).Vote(ctx, req.(*PollVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostService_ServiceDesc is the grpc.ServiceDesc for PostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -266,6 +512,34 @@ var PostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _PostService_Delete_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _PostService_Search_Handler,
+		},
+		{
+			MethodName: "Reschedule",
+			Handler:    _PostService_Reschedule_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _PostService_CancelSchedule_Handler,
+		},
+		{
+			MethodName: "Repost",
+			Handler:    _PostService_Repost_Handler,
+		},
+		{
+			MethodName: "Unrepost",
+			Handler:    _PostService_Unrepost_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _PostService_GetStats_Handler,
+		},
+		{
+			MethodName: "Vote",
+			Handler:    _PostService_Vote_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "post.proto",
@@ -0,0 +1,157 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: settings.proto
+
+package user_service
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UserSettingsService_GetSingle_FullMethodName = "/user_service.UserSettingsService/GetSingle"
+	UserSettingsService_Update_FullMethodName    = "/user_service.UserSettingsService/Update"
+)
+
+// UserSettingsServiceClient is the client API for UserSettingsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserSettingsServiceClient interface {
+	GetSingle(ctx context.Context, in *UserSettingsSingleRequest, opts ...grpc.CallOption) (*UserSettings, error)
+	Update(ctx context.Context, in *UpdateUserSettingsRequest, opts ...grpc.CallOption) (*UserSettings, error)
+}
+
+type userSettingsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserSettingsServiceClient(cc grpc.ClientConnInterface) UserSettingsServiceClient {
+	return &userSettingsServiceClient{cc}
+}
+
+func (c *userSettingsServiceClient) GetSingle(ctx context.Context, in *UserSettingsSingleRequest, opts ...grpc.CallOption) (*UserSettings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSettings)
+	err := c.cc.Invoke(ctx, UserSettingsService_GetSingle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userSettingsServiceClient) Update(ctx context.Context, in *UpdateUserSettingsRequest, opts ...grpc.CallOption) (*UserSettings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserSettings)
+	err := c.cc.Invoke(ctx, UserSettingsService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserSettingsServiceServer is the server API for UserSettingsService service.
+// All implementations should embed UnimplementedUserSettingsServiceServer
+// for forward compatibility.
+type UserSettingsServiceServer interface {
+	GetSingle(context.Context, *UserSettingsSingleRequest) (*UserSettings, error)
+	Update(context.Context, *UpdateUserSettingsRequest) (*UserSettings, error)
+}
+
+// UnimplementedUserSettingsServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserSettingsServiceServer struct{}
+
+func (UnimplementedUserSettingsServiceServer) GetSingle(context.Context, *UserSettingsSingleRequest) (*UserSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSingle not implemented")
+}
+func (UnimplementedUserSettingsServiceServer) Update(context.Context, *UpdateUserSettingsRequest) (*UserSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedUserSettingsServiceServer) testEmbeddedByValue() {}
+
+// UnsafeUserSettingsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserSettingsServiceServer will
+// result in compilation errors.
+type UnsafeUserSettingsServiceServer interface {
+	mustEmbedUnimplementedUserSettingsServiceServer()
+}
+
+func RegisterUserSettingsServiceServer(s grpc.ServiceRegistrar, srv UserSettingsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserSettingsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserSettingsService_ServiceDesc, srv)
+}
+
+func _UserSettingsService_GetSingle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSettingsSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserSettingsServiceServer).GetSingle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserSettingsService_GetSingle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserSettingsServiceServer).GetSingle(ctx, req.(*UserSettingsSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserSettingsService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserSettingsServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserSettingsService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserSettingsServiceServer).Update(ctx, req.(*UpdateUserSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserSettingsService_ServiceDesc is the grpc.ServiceDesc for UserSettingsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserSettingsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "user_service.UserSettingsService",
+	HandlerType: (*UserSettingsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSingle",
+			Handler:    _UserSettingsService_GetSingle_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _UserSettingsService_Update_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "settings.proto",
+}
@@ -270,3 +270,179 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
 }
+
+const (
+	BulkUserJobService_Create_FullMethodName    = "/user_service.BulkUserJobService/Create"
+	BulkUserJobService_GetSingle_FullMethodName = "/user_service.BulkUserJobService/GetSingle"
+	BulkUserJobService_GetList_FullMethodName   = "/user_service.BulkUserJobService/GetList"
+)
+
+// BulkUserJobServiceClient is the client API for BulkUserJobService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BulkUserJobServiceClient interface {
+	Create(ctx context.Context, in *BulkUserActionRequest, opts ...grpc.CallOption) (*BulkUserJob, error)
+	GetSingle(ctx context.Context, in *BulkUserJobSingleRequest, opts ...grpc.CallOption) (*BulkUserJob, error)
+	GetList(ctx context.Context, in *GetListBulkUserJobRequest, opts ...grpc.CallOption) (*GetListBulkUserJobResponse, error)
+}
+
+type bulkUserJobServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBulkUserJobServiceClient(cc grpc.ClientConnInterface) BulkUserJobServiceClient {
+	return &bulkUserJobServiceClient{cc}
+}
+
+func (c *bulkUserJobServiceClient) Create(ctx context.Context, in *BulkUserActionRequest, opts ...grpc.CallOption) (*BulkUserJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkUserJob)
+	err := c.cc.Invoke(ctx, BulkUserJobService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bulkUserJobServiceClient) GetSingle(ctx context.Context, in *BulkUserJobSingleRequest, opts ...grpc.CallOption) (*BulkUserJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkUserJob)
+	err := c.cc.Invoke(ctx, BulkUserJobService_GetSingle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bulkUserJobServiceClient) GetList(ctx context.Context, in *GetListBulkUserJobRequest, opts ...grpc.CallOption) (*GetListBulkUserJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetListBulkUserJobResponse)
+	err := c.cc.Invoke(ctx, BulkUserJobService_GetList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BulkUserJobServiceServer is the server API for BulkUserJobService service.
+// All implementations should embed UnimplementedBulkUserJobServiceServer
+// for forward compatibility.
+type BulkUserJobServiceServer interface {
+	Create(context.Context, *BulkUserActionRequest) (*BulkUserJob, error)
+	GetSingle(context.Context, *BulkUserJobSingleRequest) (*BulkUserJob, error)
+	GetList(context.Context, *GetListBulkUserJobRequest) (*GetListBulkUserJobResponse, error)
+}
+
+// UnimplementedBulkUserJobServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBulkUserJobServiceServer struct{}
+
+func (UnimplementedBulkUserJobServiceServer) Create(context.Context, *BulkUserActionRequest) (*BulkUserJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedBulkUserJobServiceServer) GetSingle(context.Context, *BulkUserJobSingleRequest) (*BulkUserJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSingle not implemented")
+}
+func (UnimplementedBulkUserJobServiceServer) GetList(context.Context, *GetListBulkUserJobRequest) (*GetListBulkUserJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedBulkUserJobServiceServer) testEmbeddedByValue() {}
+
+// UnsafeBulkUserJobServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BulkUserJobServiceServer will
+// result in compilation errors.
+type UnsafeBulkUserJobServiceServer interface {
+	mustEmbedUnimplementedBulkUserJobServiceServer()
+}
+
+func RegisterBulkUserJobServiceServer(s grpc.ServiceRegistrar, srv BulkUserJobServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBulkUserJobServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BulkUserJobService_ServiceDesc, srv)
+}
+
+func _BulkUserJobService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUserActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BulkUserJobServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BulkUserJobService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BulkUserJobServiceServer).Create(ctx, req.(*BulkUserActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BulkUserJobService_GetSingle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUserJobSingleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BulkUserJobServiceServer).GetSingle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BulkUserJobService_GetSingle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BulkUserJobServiceServer).GetSingle(ctx, req.(*BulkUserJobSingleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BulkUserJobService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListBulkUserJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BulkUserJobServiceServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BulkUserJobService_GetList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BulkUserJobServiceServer).GetList(ctx, req.(*GetListBulkUserJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BulkUserJobService_ServiceDesc is the grpc.ServiceDesc for BulkUserJobService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BulkUserJobService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "user_service.BulkUserJobService",
+	HandlerType: (*BulkUserJobServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _BulkUserJobService_Create_Handler,
+		},
+		{
+			MethodName: "GetSingle",
+			Handler:    _BulkUserJobService_GetSingle_Handler,
+		},
+		{
+			MethodName: "GetList",
+			Handler:    _BulkUserJobService_GetList_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "user.proto",
+}
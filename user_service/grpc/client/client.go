@@ -11,10 +11,12 @@ import (
 
 type ServiceManagerI interface {
 	PostService() post_service.PostServiceClient
+	FeedService() post_service.FeedServiceClient
 }
 
 type grpcClients struct {
 	postService post_service.PostServiceClient
+	feedService post_service.FeedServiceClient
 }
 
 func NewGrpcClients(cfg config.Config) (ServiceManagerI, error) {
@@ -30,9 +32,14 @@ func NewGrpcClients(cfg config.Config) (ServiceManagerI, error) {
 
 	return &grpcClients{
 		postService: post_service.NewPostServiceClient(connPostService),
+		feedService: post_service.NewFeedServiceClient(connPostService),
 	}, nil
 }
 
 func (g *grpcClients) PostService() post_service.PostServiceClient {
 	return g.postService
 }
+
+func (g *grpcClients) FeedService() post_service.FeedServiceClient {
+	return g.feedService
+}
@@ -1,23 +1,47 @@
 package grpc
 
 import (
+	"context"
 	"user_service/config"
 	"user_service/genproto/user_service"
 	"user_service/grpc/client"
+	"user_service/grpc/interceptor"
 	"user_service/grpc/service"
 	"user_service/storage"
 
 	"github.com/saidamir98/udevs_pkg/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
 )
 
 func SetUpServer(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvc client.ServiceManagerI) (grpcServer *grpc.Server) {
 
-	grpcServer = grpc.NewServer()
+	grpcServer = grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Audit(log, strg.AuditEvent(), auditGetters(strg))),
+	)
 
 	user_service.RegisterUserServiceServer(grpcServer, service.NewUserService(cfg, log, strg, srvc))
 	user_service.RegisterSessionServiceServer(grpcServer, service.NewSessionService(cfg, log, strg, srvc))
+	user_service.RegisterUserSettingsServiceServer(grpcServer, service.NewUserSettingsService(cfg, log, strg, srvc))
+	user_service.RegisterBulkUserJobServiceServer(grpcServer, service.NewBulkUserJobService(cfg, log, strg, srvc))
+	user_service.RegisterAuditServiceServer(grpcServer, service.NewAuditService(cfg, log, strg, srvc))
+	user_service.RegisterFollowServiceServer(grpcServer, service.NewFollowService(cfg, log, strg, srvc))
 	reflection.Register(grpcServer)
 	return
 }
+
+// auditGetters load the state of a resource before it is changed, so the audit log can store a diff.
+func auditGetters(strg storage.StorageI) map[string]interceptor.Getter {
+	return map[string]interceptor.Getter{
+		"user_service.UserService": func(ctx context.Context, id string) (proto.Message, error) {
+			return strg.User().GetSingle(ctx, &user_service.UserSingleRequest{Id: id})
+		},
+		"user_service.SessionService": func(ctx context.Context, id string) (proto.Message, error) {
+			return strg.Session().GetSingle(ctx, &user_service.SessionSingleRequest{Id: id})
+		},
+		"user_service.UserSettingsService": func(ctx context.Context, id string) (proto.Message, error) {
+			return strg.UserSettings().GetSingle(ctx, &user_service.UserSettingsSingleRequest{UserId: id})
+		},
+	}
+}
@@ -0,0 +1,260 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode"
+	"user_service/genproto/user_service"
+	"user_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Getter loads the current state of a resource, it is used to capture the state before a change.
+type Getter func(ctx context.Context, id string) (proto.Message, error)
+
+var (
+	// mutatingPrefixes are the RPC method prefixes that are recorded in the audit log.
+	mutatingPrefixes = []string{"Create", "Update", "Upsert", "Delete", "MultipleUpsert", "Reschedule", "Cancel", "Restore", "Repost", "Unrepost", "Reorder"}
+
+	// sensitiveFields are never written to the audit log.
+	sensitiveFields = map[string]bool{
+		"password":     true,
+		"access_token": true,
+		"otp":          true,
+	}
+
+	// ignoredFields change on every write and only add noise to the diff.
+	ignoredFields = map[string]bool{
+		"updated_at": true,
+	}
+)
+
+const redacted = `"[REDACTED]"`
+
+// Audit records every mutating RPC with its actor, target and the fields it changed.
+// It is the same as post_service/grpc/interceptor/audit.go, the services do not share a module, so a
+// change to the prefixes or the redaction goes into both.
+// getters are keyed by the full service name, e.g. user_service.UserService.
+func Audit(log logger.LoggerI, repo storage.AuditEventRepoI, getters map[string]Getter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		service, method := splitMethod(info.FullMethod)
+		if !isMutating(method) {
+			return handler(ctx, req)
+		}
+
+		// a created resource is identified by the response, its request only refers to the parent
+		var targetID string
+		if !strings.HasPrefix(method, "Create") {
+			targetID = targetIDOf(req)
+		}
+
+		var before proto.Message
+		if get, ok := getters[service]; ok && targetID != "" {
+			before, _ = get(ctx, targetID)
+		}
+
+		resp, err := handler(ctx, req)
+
+		var after proto.Message
+		if err == nil && !strings.HasPrefix(method, "Delete") {
+			after, _ = resp.(proto.Message)
+		}
+
+		if targetID == "" && after != nil {
+			targetID = targetIDOf(after)
+		}
+
+		event := &user_service.AuditEvent{
+			Service:  service,
+			Method:   method,
+			Resource: resourceName(service),
+			TargetId: targetID,
+			Status:   status.Code(err).String(),
+			Changes:  Diff(before, after),
+		}
+		fillActor(ctx, event)
+
+		// the event is written even if the caller has already gone away
+		if werr := repo.Create(context.WithoutCancel(ctx), event); werr != nil {
+			log.Error("---AuditEvent--->>>", logger.Error(werr), logger.String("method", info.FullMethod))
+		}
+
+		return resp, err
+	}
+}
+
+// Diff returns the fields that differ between before and after, sensitive values are redacted.
+// A nil message is treated as a message without fields.
+func Diff(before, after proto.Message) map[string]*user_service.AuditChange {
+	var (
+		changes = map[string]*user_service.AuditChange{}
+		b       = toFields(before)
+		a       = toFields(after)
+	)
+
+	for key := range union(b, a) {
+		if ignoredFields[key] || reflect.DeepEqual(b[key], a[key]) {
+			continue
+		}
+
+		changes[key] = &user_service.AuditChange{
+			Before: encodeField(key, b, before != nil),
+			After:  encodeField(key, a, after != nil),
+		}
+	}
+
+	return changes
+}
+
+func toFields(m proto.Message) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if m == nil || reflect.ValueOf(m).IsNil() {
+		return fields
+	}
+
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return fields
+	}
+
+	_ = json.Unmarshal(body, &fields)
+	return fields
+}
+
+// encodeField returns the JSON encoded value of the field with sensitive values redacted.
+func encodeField(key string, fields map[string]interface{}, present bool) string {
+	value, ok := fields[key]
+	if !present || !ok {
+		return ""
+	}
+	if sensitiveFields[key] {
+		return redacted
+	}
+
+	body, _ := json.Marshal(redact(value))
+	return string(body)
+}
+
+// redact returns a copy of value where every sensitive field is replaced.
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if sensitiveFields[key] {
+				out[key] = "[REDACTED]"
+				continue
+			}
+			out[key] = redact(field)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = redact(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func union(a, b map[string]interface{}) map[string]bool {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
+
+func fillActor(ctx context.Context, event *user_service.AuditEvent) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		event.ActorId = first(md.Get("user_id"))
+		event.ActorRole = first(md.Get("user_role"))
+		event.SessionId = first(md.Get("session_id"))
+		event.ClientIp = first(md.Get("client_ip"))
+	}
+
+	if event.ClientIp == "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			event.ClientIp = p.Addr.String()
+		}
+	}
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// targetIDOf reads the id of the resource from the first non empty id field of a message.
+func targetIDOf(m interface{}) string {
+	msg, ok := m.(proto.Message)
+	if !ok || reflect.ValueOf(msg).IsNil() {
+		return ""
+	}
+
+	r := msg.ProtoReflect()
+	for _, name := range []protoreflect.Name{"id", "target_id", "user_id", "post_id", "tag"} {
+		field := r.Descriptor().Fields().ByName(name)
+		if field != nil && field.Kind() == protoreflect.StringKind {
+			if id := r.Get(field).String(); id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// splitMethod splits /user_service.UserService/Update into user_service.UserService and Update.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
+
+func isMutating(method string) bool {
+	for _, prefix := range mutatingPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceName turns user_service.UserSettingsService into user_settings.
+func resourceName(service string) string {
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		service = service[i+1:]
+	}
+	service = strings.TrimSuffix(service, "Service")
+
+	var b strings.Builder
+	for i, r := range service {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
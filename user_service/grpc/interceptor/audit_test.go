@@ -0,0 +1,64 @@
+package interceptor_test
+
+import (
+	"testing"
+	"user_service/genproto/user_service"
+	"user_service/grpc/interceptor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_RedactsSensitiveFields(t *testing.T) {
+	before := &user_service.User{
+		Id:        "83c49d98-04bf-47ac-ba80-c1064bd870cf",
+		FullName:  "John Doe",
+		Password:  "$2a$10$old",
+		Status:    "active",
+		UpdatedAt: "2025-01-01T00:00:00Z",
+	}
+	after := &user_service.User{
+		Id:        "83c49d98-04bf-47ac-ba80-c1064bd870cf",
+		FullName:  "John Smith",
+		Password:  "$2a$10$new",
+		Status:    "active",
+		UpdatedAt: "2025-01-02T00:00:00Z",
+	}
+
+	changes := interceptor.Diff(before, after)
+
+	require.Len(t, changes, 2)
+	assert.Equal(t, `"John Doe"`, changes["full_name"].Before)
+	assert.Equal(t, `"John Smith"`, changes["full_name"].After)
+	assert.Equal(t, `"[REDACTED]"`, changes["password"].Before)
+	assert.Equal(t, `"[REDACTED]"`, changes["password"].After)
+}
+
+func TestDiff_CreateAndDelete(t *testing.T) {
+	user := &user_service.User{
+		Id:       "83c49d98-04bf-47ac-ba80-c1064bd870cf",
+		UserName: "johndoe",
+		Password: "$2a$10$hash",
+	}
+
+	created := interceptor.Diff(nil, user)
+	assert.Equal(t, "", created["user_name"].Before)
+	assert.Equal(t, `"johndoe"`, created["user_name"].After)
+	assert.Equal(t, `"[REDACTED]"`, created["password"].After)
+
+	deleted := interceptor.Diff(user, nil)
+	assert.Equal(t, `"johndoe"`, deleted["user_name"].Before)
+	assert.Equal(t, "", deleted["user_name"].After)
+	assert.NotContains(t, deleted["password"].Before, "hash")
+}
+
+func TestDiff_RedactsNestedFields(t *testing.T) {
+	job := &user_service.BulkUserJob{
+		Id:      "95c5f6bd-8840-4893-ae6f-d97ee3a6d6ab",
+		Preview: []*user_service.User{{UserName: "johndoe", Password: "$2a$10$hash"}},
+	}
+
+	changes := interceptor.Diff(nil, job)
+	assert.Contains(t, changes["preview"].After, "johndoe")
+	assert.NotContains(t, changes["preview"].After, "hash")
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+	"user_service/config"
+	"user_service/genproto/user_service"
+	"user_service/grpc/client"
+
+	"user_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AuditService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewAuditService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *AuditService {
+	return &AuditService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+func (s *AuditService) GetList(ctx context.Context, req *user_service.GetListAuditEventRequest) (*user_service.GetListAuditEventResponse, error) {
+	s.log.Info("---GetAllAuditEvents--->>>", logger.Any("req", req))
+
+	if err := validateAuditFilter(req); err != nil {
+		return &user_service.GetListAuditEventResponse{}, err
+	}
+
+	resp, err := s.strg.AuditEvent().GetList(ctx, req)
+	if err != nil {
+		s.log.Error("---GetAllAuditEvents--->>>", logger.Error(err))
+		return &user_service.GetListAuditEventResponse{}, err
+	}
+
+	return resp, nil
+}
+
+func validateAuditFilter(req *user_service.GetListAuditEventRequest) error {
+	if req.ActorId != "" {
+		if _, err := uuid.Parse(req.ActorId); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid actor_id %q", req.ActorId)
+		}
+	}
+
+	for _, date := range []string{req.From, req.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			if _, err := time.Parse(time.RFC3339, date); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid date %q, expected YYYY-MM-DD or RFC3339", date)
+			}
+		}
+	}
+
+	return nil
+}
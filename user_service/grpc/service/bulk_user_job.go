@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"time"
+	"user_service/config"
+	"user_service/genproto/user_service"
+	"user_service/grpc/client"
+
+	"user_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// bulkBatchSize is the number of users changed in one transaction.
+	bulkBatchSize = 100
+	// bulkPreviewSize is the number of users returned by a dry run.
+	bulkPreviewSize = 20
+	// bulkJobLease is how long a job is left to its replica without a heartbeat, the replica renews
+	// it every third of the lease while the job runs.
+	bulkJobLease = time.Minute
+)
+
+var bulkActions = map[string]bool{
+	"block":       true,
+	"unblock":     true,
+	"change_role": true,
+	"verify":      true,
+	"delete":      true,
+}
+
+// userRoles are the values of the user_role enum.
+var userRoles = map[string]bool{
+	"user":      true,
+	"moderator": true,
+	"admin":     true,
+}
+
+type BulkUserJobService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewBulkUserJobService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *BulkUserJobService {
+	return &BulkUserJobService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create resolves the users matched by the filter and starts the job in the background.
+// With dry_run set nothing is changed, the matched users are returned as a preview instead.
+func (s *BulkUserJobService) Create(ctx context.Context, req *user_service.BulkUserActionRequest) (*user_service.BulkUserJob, error) {
+	s.log.Info("---CreateBulkUserJob--->>>", logger.Any("req", req))
+
+	if err := validateBulkUserAction(req); err != nil {
+		return &user_service.BulkUserJob{}, err
+	}
+
+	ids, err := s.strg.User().GetIDs(ctx, req.Filter)
+	if err != nil {
+		s.log.Error("---CreateBulkUserJob--->>>", logger.Error(err))
+		return &user_service.BulkUserJob{}, err
+	}
+
+	// an admin never applies a bulk action to their own account
+	for i, id := range ids {
+		if id == req.RequestedBy {
+			ids = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+
+	if req.DryRun {
+		preview, err := s.strg.User().GetListByIDs(ctx, ids[:min(len(ids), bulkPreviewSize)])
+		if err != nil {
+			s.log.Error("---CreateBulkUserJob--->>>", logger.Error(err))
+			return &user_service.BulkUserJob{}, err
+		}
+
+		return &user_service.BulkUserJob{
+			Action:      req.Action,
+			Role:        req.Role,
+			Filter:      req.Filter,
+			DryRun:      true,
+			Status:      "dry_run",
+			Total:       int64(len(ids)),
+			RequestedBy: req.RequestedBy,
+			Preview:     preview,
+		}, nil
+	}
+
+	job, err := s.strg.BulkUserJob().Create(ctx, &user_service.BulkUserJob{
+		Action:      req.Action,
+		Role:        req.Role,
+		Filter:      req.Filter,
+		Total:       int64(len(ids)),
+		RequestedBy: req.RequestedBy,
+	}, bulkJobLease)
+	if err != nil {
+		s.log.Error("---CreateBulkUserJob--->>>", logger.Error(err))
+		return &user_service.BulkUserJob{}, err
+	}
+
+	go s.run(job, req, ids)
+
+	return job, nil
+}
+
+func (s *BulkUserJobService) GetSingle(ctx context.Context, req *user_service.BulkUserJobSingleRequest) (*user_service.BulkUserJob, error) {
+	s.log.Info("---GetSingleBulkUserJob--->>>", logger.Any("req", req))
+
+	resp, err := s.strg.BulkUserJob().GetSingle(ctx, req)
+	if err != nil {
+		s.log.Error("---GetSingleBulkUserJob--->>>", logger.Error(err))
+		return &user_service.BulkUserJob{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *BulkUserJobService) GetList(ctx context.Context, req *user_service.GetListBulkUserJobRequest) (*user_service.GetListBulkUserJobResponse, error) {
+	s.log.Info("---GetAllBulkUserJobs--->>>", logger.Any("req", req))
+
+	resp, err := s.strg.BulkUserJob().GetList(ctx, req)
+	if err != nil {
+		s.log.Error("---GetAllBulkUserJobs--->>>", logger.Error(err))
+		return &user_service.GetListBulkUserJobResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// run applies the action batch by batch and stores the progress after every batch.
+// It outlives the request that created the job, so it uses its own context.
+func (s *BulkUserJobService) run(job *user_service.BulkUserJob, req *user_service.BulkUserActionRequest, ids []string) {
+	ctx := context.Background()
+
+	heartbeat, stop := context.WithCancel(ctx)
+	defer stop()
+	go s.renew(heartbeat, job.Id)
+
+	job.Status = "running"
+	if _, err := s.strg.BulkUserJob().Update(ctx, job); err != nil {
+		s.log.Error("---RunBulkUserJob--->>>", logger.Error(err))
+	}
+
+	for start := 0; start < len(ids); start += bulkBatchSize {
+		batch := ids[start:min(start+bulkBatchSize, len(ids))]
+
+		affected, err := s.strg.User().BulkAction(ctx, req, batch)
+		if err != nil {
+			s.log.Error("---RunBulkUserJob--->>>", logger.Error(err), logger.String("job_id", job.Id))
+			job.Failed += int64(len(batch))
+			job.Error = err.Error()
+		} else {
+			job.Succeeded += affected
+		}
+		job.Processed += int64(len(batch))
+
+		if _, err := s.strg.BulkUserJob().Update(ctx, job); err != nil {
+			s.log.Error("---RunBulkUserJob--->>>", logger.Error(err), logger.String("job_id", job.Id))
+		}
+	}
+
+	job.Status = "completed"
+	if job.Total > 0 && job.Failed == job.Total {
+		job.Status = "failed"
+	}
+
+	if _, err := s.strg.BulkUserJob().Update(ctx, job); err != nil {
+		s.log.Error("---RunBulkUserJob--->>>", logger.Error(err), logger.String("job_id", job.Id))
+	}
+}
+
+// renew keeps the lease of a running job until ctx is done, so other replicas do not fail it.
+func (s *BulkUserJobService) renew(ctx context.Context, id string) {
+	ticker := time.NewTicker(bulkJobLease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.strg.BulkUserJob().Renew(ctx, id, bulkJobLease); err != nil && ctx.Err() == nil {
+			s.log.Error("---RenewBulkUserJob--->>>", logger.Error(err), logger.String("job_id", id))
+		}
+	}
+}
+
+// FailExpiredJobs marks the jobs whose replica stopped failed, when it is called and then every lease
+// until ctx is done. A job is only failed once its lease ran out, so the jobs other replicas are
+// running are left alone.
+func FailExpiredJobs(ctx context.Context, log logger.LoggerI, strg storage.StorageI) {
+	ticker := time.NewTicker(bulkJobLease)
+	defer ticker.Stop()
+
+	for {
+		failed, err := strg.BulkUserJob().FailExpired(ctx)
+		if err != nil {
+			log.Error("---FailExpiredBulkUserJobs--->>>", logger.Error(err))
+		}
+		if failed > 0 {
+			log.Info("marked interrupted bulk user jobs failed", logger.Any("count", failed))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func validateBulkUserAction(req *user_service.BulkUserActionRequest) error {
+	if !bulkActions[req.Action] {
+		return status.Errorf(codes.InvalidArgument, "unknown action %q, expected block, unblock, change_role, verify or delete", req.Action)
+	}
+
+	if req.Action == "change_role" && !userRoles[req.Role] {
+		return status.Errorf(codes.InvalidArgument, "invalid role %q, expected user, moderator or admin", req.Role)
+	}
+
+	if req.Action != "change_role" {
+		req.Role = ""
+	}
+
+	f := req.Filter
+	if f == nil || (len(f.Ids) == 0 && f.Search == "" && f.Status == "" && f.CreatedFrom == "" && f.CreatedTo == "") {
+		return status.Error(codes.InvalidArgument, "filter is required, pass ids or at least one of search, status, created_from, created_to")
+	}
+
+	if f.Status != "" && f.Status != "active" && f.Status != "blocked" && f.Status != "inverify" {
+		return status.Errorf(codes.InvalidArgument, "invalid status %q", f.Status)
+	}
+
+	for _, date := range []string{f.CreatedFrom, f.CreatedTo} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			if _, err := time.Parse(time.RFC3339, date); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid date %q, expected YYYY-MM-DD or RFC3339", date)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"user_service/config"
+	"user_service/genproto/post_service"
+	"user_service/genproto/user_service"
+	"user_service/grpc/client"
+	"user_service/pkg/cursor"
+
+	"user_service/storage"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultFollowLimit = 20
+	// maxFollowLimit is high enough for the feed to load the followed accounts in a few pages.
+	maxFollowLimit = 1000
+)
+
+type FollowService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewFollowService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *FollowService {
+	return &FollowService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// Create follows followee_id, following an account twice changes nothing.
+func (s *FollowService) Create(ctx context.Context, req *user_service.Follow) (*user_service.FollowStats, error) {
+	s.log.Info("---CreateFollow--->>>", logger.Any("req", req))
+
+	if req.FollowerId == req.FolloweeId {
+		return &user_service.FollowStats{}, status.Error(codes.InvalidArgument, "users can't follow themselves")
+	}
+
+	created, err := s.strg.Follow().Create(ctx, req)
+	if err != nil {
+		s.log.Error("---CreateFollow--->>>", logger.Error(err))
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return &user_service.FollowStats{}, status.Error(codes.NotFound, "user not found")
+		}
+		return &user_service.FollowStats{}, err
+	}
+
+	if created {
+		s.resetFeed(ctx, req.FollowerId)
+	}
+
+	return s.GetStats(ctx, &user_service.FollowStatsRequest{UserId: req.FolloweeId, ViewerId: req.FollowerId})
+}
+
+// Delete unfollows followee_id, unfollowing an account that is not followed changes nothing.
+func (s *FollowService) Delete(ctx context.Context, req *user_service.Follow) (*user_service.FollowStats, error) {
+	s.log.Info("---DeleteFollow--->>>", logger.Any("req", req))
+
+	if _, err := s.strg.Follow().Delete(ctx, req); err != nil {
+		s.log.Error("---DeleteFollow--->>>", logger.Error(err))
+		return &user_service.FollowStats{}, err
+	}
+
+	return s.GetStats(ctx, &user_service.FollowStatsRequest{UserId: req.FolloweeId, ViewerId: req.FollowerId})
+}
+
+func (s *FollowService) GetList(ctx context.Context, req *user_service.GetListFollowRequest) (*user_service.FollowList, error) {
+	s.log.Info("---GetAllFollows--->>>", logger.Any("req", req))
+
+	if req.Direction != "followers" && req.Direction != "following" {
+		return &user_service.FollowList{}, status.Errorf(codes.InvalidArgument, "invalid direction %q, expected followers or following", req.Direction)
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultFollowLimit
+	}
+	req.Limit = min(req.Limit, maxFollowLimit)
+
+	resp, err := s.strg.Follow().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &user_service.FollowList{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		s.log.Error("---GetAllFollows--->>>", logger.Error(err))
+		return &user_service.FollowList{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *FollowService) GetStats(ctx context.Context, req *user_service.FollowStatsRequest) (*user_service.FollowStats, error) {
+	s.log.Info("---GetFollowStats--->>>", logger.Any("req", req))
+
+	resp, err := s.strg.Follow().GetStats(ctx, req)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &user_service.FollowStats{}, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		s.log.Error("---GetFollowStats--->>>", logger.Error(err))
+		return &user_service.FollowStats{}, err
+	}
+
+	return resp, nil
+}
+
+// resetFeed drops the cached home timeline of the follower, so the posts of the new account show up.
+// The follow is already stored, a failure only delays the old posts until the timeline expires.
+func (s *FollowService) resetFeed(ctx context.Context, userID string) {
+	_, err := s.services.FeedService().Reset(ctx, &post_service.FeedResetRequest{UserId: userID})
+	if err != nil {
+		s.log.Error("---ResetFeed--->>>", logger.Error(err), logger.String("user_id", userID))
+	}
+}
@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"user_service/config"
 	"user_service/genproto/user_service"
 	"user_service/grpc/client"
+	"user_service/pkg/cursor"
 
 	"user_service/storage"
 
 	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -55,7 +59,12 @@ func (s *SessionService) GetSingle(ctx context.Context, req *user_service.Sessio
 func (s *SessionService) GetList(ctx context.Context, req *user_service.GetListSessionRequest) (*user_service.GetListSessionResponse, error) {
 	s.log.Info("---GetAllSessions--->>>", logger.Any("req", req))
 
+	req.Limit = listLimit(req.Limit)
+
 	resp, err := s.strg.Session().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &user_service.GetListSessionResponse{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
 	if err != nil {
 		s.log.Error("---GetAllSessions--->>>", logger.Error(err))
 		return &user_service.GetListSessionResponse{}, err
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"user_service/config"
+	"user_service/genproto/user_service"
+	"user_service/grpc/client"
+	"user_service/pkg/settings"
+
+	"user_service/storage"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserSettingsService struct {
+	cfg      config.Config
+	log      logger.LoggerI
+	strg     storage.StorageI
+	services client.ServiceManagerI
+}
+
+func NewUserSettingsService(cfg config.Config, log logger.LoggerI, strg storage.StorageI, srvs client.ServiceManagerI) *UserSettingsService {
+	return &UserSettingsService{
+		cfg:      cfg,
+		log:      log,
+		strg:     strg,
+		services: srvs,
+	}
+}
+
+// GetSingle returns the stored settings of the user, or the defaults if the user never changed them.
+func (s *UserSettingsService) GetSingle(ctx context.Context, req *user_service.UserSettingsSingleRequest) (*user_service.UserSettings, error) {
+	s.log.Info("---GetSingleUserSettings--->>>", logger.Any("req", req))
+
+	if req.UserId == "" {
+		return &user_service.UserSettings{}, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	resp, err := s.strg.UserSettings().GetSingle(ctx, req)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return settings.Default(req.UserId), nil
+	}
+	if err != nil {
+		s.log.Error("---GetSingleUserSettings--->>>", logger.Error(err))
+		return &user_service.UserSettings{}, err
+	}
+
+	return resp, nil
+}
+
+func (s *UserSettingsService) Update(ctx context.Context, req *user_service.UpdateUserSettingsRequest) (*user_service.UserSettings, error) {
+	s.log.Info("---UpdateUserSettings--->>>", logger.Any("req", req))
+
+	current, err := s.GetSingle(ctx, &user_service.UserSettingsSingleRequest{UserId: req.UserId})
+	if err != nil {
+		return &user_service.UserSettings{}, err
+	}
+
+	settings.Apply(current, req)
+
+	if err := settings.Validate(current); err != nil {
+		return &user_service.UserSettings{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.strg.UserSettings().Upsert(ctx, current)
+	if err != nil {
+		s.log.Error("---UpdateUserSettings--->>>", logger.Error(err))
+		return &user_service.UserSettings{}, err
+	}
+
+	return resp, nil
+}
@@ -2,16 +2,26 @@ package service
 
 import (
 	"context"
+	"errors"
 	"user_service/config"
 	"user_service/genproto/user_service"
 	"user_service/grpc/client"
+	"user_service/pkg/cursor"
 
 	"user_service/storage"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	defaultListLimit = 10
+	maxListLimit     = 100
+)
+
 type UserService struct {
 	cfg      config.Config
 	log      logger.LoggerI
@@ -44,6 +54,9 @@ func (s *UserService) GetSingle(ctx context.Context, req *user_service.UserSingl
 	s.log.Info("---GetSingleUser--->>>", logger.Any("req", req))
 
 	resp, err := s.strg.User().GetSingle(ctx, req)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &user_service.User{}, status.Error(codes.NotFound, "user not found")
+	}
 	if err != nil {
 		s.log.Error("---GetSingleUser--->>>", logger.Error(err))
 		return &user_service.User{}, err
@@ -55,7 +68,12 @@ func (s *UserService) GetSingle(ctx context.Context, req *user_service.UserSingl
 func (s *UserService) GetList(ctx context.Context, req *user_service.GetListUserRequest) (*user_service.GetListUserResponse, error) {
 	s.log.Info("---GetAllUsers--->>>", logger.Any("req", req))
 
+	req.Limit = listLimit(req.Limit)
+
 	resp, err := s.strg.User().GetList(ctx, req)
+	if errors.Is(err, cursor.ErrInvalid) {
+		return &user_service.GetListUserResponse{}, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
 	if err != nil {
 		s.log.Error("---GetAllUsers--->>>", logger.Error(err))
 		return &user_service.GetListUserResponse{}, err
@@ -87,3 +105,11 @@ func (s *UserService) Delete(ctx context.Context, req *user_service.UserPrimaryK
 
 	return &emptypb.Empty{}, nil
 }
+
+// listLimit applies the default and the maximum page size of the list RPCs.
+func listLimit(limit uint64) uint64 {
+	if limit == 0 {
+		return defaultListLimit
+	}
+	return min(limit, maxListLimit)
+}
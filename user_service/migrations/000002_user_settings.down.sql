@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_settings;
+DROP TYPE IF EXISTS profile_visibility;
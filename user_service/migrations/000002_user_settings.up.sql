@@ -0,0 +1,22 @@
+CREATE TYPE profile_visibility AS ENUM (
+  'public',
+  'followers',
+  'private'
+);
+
+CREATE TABLE IF NOT EXISTS user_settings (
+  user_id uuid PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  locale VARCHAR(10) NOT NULL DEFAULT 'en',
+  timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+  default_post_status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (default_post_status IN ('draft', 'published')),
+  profile_visibility profile_visibility NOT NULL DEFAULT 'public',
+  show_email boolean NOT NULL DEFAULT false,
+  allow_mentions boolean NOT NULL DEFAULT true,
+  email_notifications boolean NOT NULL DEFAULT true,
+  notify_on_comment boolean NOT NULL DEFAULT true,
+  notify_on_mention boolean NOT NULL DEFAULT true,
+  notify_on_reaction boolean NOT NULL DEFAULT false,
+  notify_on_follow boolean NOT NULL DEFAULT true,
+  created_at timestamp NOT NULL DEFAULT 'now()',
+  updated_at timestamp NOT NULL DEFAULT 'now()'
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bulk_user_job;
+DROP TYPE IF EXISTS bulk_job_status;
+DROP TYPE IF EXISTS bulk_user_action;
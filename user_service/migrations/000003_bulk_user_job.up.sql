@@ -0,0 +1,31 @@
+CREATE TYPE bulk_user_action AS ENUM (
+  'block',
+  'unblock',
+  'change_role',
+  'verify',
+  'delete'
+);
+
+CREATE TYPE bulk_job_status AS ENUM (
+  'pending',
+  'running',
+  'completed',
+  'failed'
+);
+
+CREATE TABLE IF NOT EXISTS bulk_user_job (
+  id uuid PRIMARY KEY,
+  action bulk_user_action NOT NULL,
+  role user_role,
+  filter jsonb NOT NULL DEFAULT '{}',
+  status bulk_job_status NOT NULL DEFAULT 'pending',
+  total bigint NOT NULL DEFAULT 0,
+  processed bigint NOT NULL DEFAULT 0,
+  succeeded bigint NOT NULL DEFAULT 0,
+  failed bigint NOT NULL DEFAULT 0,
+  error text NOT NULL DEFAULT '',
+  requested_by uuid NOT NULL,
+  created_at timestamp NOT NULL DEFAULT 'now()',
+  updated_at timestamp NOT NULL DEFAULT 'now()',
+  finished_at timestamp
+);
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+  id uuid PRIMARY KEY,
+  actor_id uuid,
+  actor_role varchar(20) NOT NULL DEFAULT '',
+  session_id uuid,
+  client_ip varchar(64) NOT NULL DEFAULT '',
+  service varchar(100) NOT NULL,
+  method varchar(100) NOT NULL,
+  resource varchar(100) NOT NULL,
+  target_id varchar(64) NOT NULL DEFAULT '',
+  status varchar(32) NOT NULL,
+  changes jsonb NOT NULL DEFAULT '{}',
+  created_at timestamp NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events (created_at);
+CREATE INDEX IF NOT EXISTS audit_events_actor_idx ON audit_events (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS audit_events_resource_idx ON audit_events (resource, target_id, created_at);
+
+-- audit events are append-only, rows can never be changed or removed
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update
+  BEFORE UPDATE OR DELETE ON audit_events
+  FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+  BEFORE TRUNCATE ON audit_events
+  FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS follows (
+  follower_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  followee_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamp NOT NULL DEFAULT 'now()',
+  PRIMARY KEY (follower_id, followee_id),
+  CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS follows_followee_idx ON follows (followee_id, created_at DESC, follower_id DESC);
+CREATE INDEX IF NOT EXISTS follows_follower_idx ON follows (follower_id, created_at DESC, followee_id DESC);
+
+-- counters kept in sync by the follow repo, the home feed uses follower_count to pick the fan-out strategy
+ALTER TABLE users ADD COLUMN IF NOT EXISTS follower_count integer NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS following_count integer NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- enum values can not be dropped, the type is recreated without moderator
+UPDATE users SET user_role = 'user' WHERE user_role = 'moderator';
+UPDATE bulk_user_job SET role = 'user' WHERE role = 'moderator';
+
+ALTER TYPE user_role RENAME TO user_role_old;
+
+CREATE TYPE user_role AS ENUM (
+  'user',
+  'admin'
+);
+
+ALTER TABLE users ALTER COLUMN user_role TYPE user_role USING user_role::text::user_role;
+
+ALTER TABLE bulk_user_job ALTER COLUMN role TYPE user_role USING role::text::user_role;
+
+DROP TYPE user_role_old;
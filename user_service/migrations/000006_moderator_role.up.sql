@@ -0,0 +1,2 @@
+-- moderators review reports and act on reported posts and users
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'moderator';
@@ -0,0 +1 @@
+ALTER TABLE bulk_user_job DROP COLUMN IF EXISTS lease_expires_at;
@@ -0,0 +1,5 @@
+-- the replica running a job renews its lease, a pending or running job whose lease ran out was
+-- stopped with its replica
+ALTER TABLE bulk_user_job ADD COLUMN IF NOT EXISTS lease_expires_at timestamp;
+
+UPDATE bulk_user_job SET lease_expires_at = updated_at WHERE status IN ('pending', 'running');
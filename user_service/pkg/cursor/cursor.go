@@ -0,0 +1,39 @@
+// Package cursor encodes the position of the last row of a page, so the next page can be
+// fetched with a keyset condition instead of an OFFSET.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalid is returned for cursors that were not produced by Encode.
+var ErrInvalid = errors.New("invalid cursor")
+
+type position struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+// Encode returns an opaque cursor pointing at the row with the given created_at and id.
+func Encode(createdAt time.Time, id string) string {
+	body, _ := json.Marshal(position{CreatedAt: createdAt, ID: id})
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+// Decode returns the created_at and id stored in the cursor.
+func Decode(cursor string) (time.Time, string, error) {
+	body, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", ErrInvalid
+	}
+
+	var p position
+	if err := json.Unmarshal(body, &p); err != nil || p.ID == "" {
+		return time.Time{}, "", ErrInvalid
+	}
+
+	return p.CreatedAt, p.ID, nil
+}
@@ -0,0 +1,28 @@
+package cursor_test
+
+import (
+	"testing"
+	"time"
+	"user_service/pkg/cursor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	createdAt := time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.UTC)
+
+	c := cursor.Encode(createdAt, "2d0f1c8e-5f5e-4b7a-9d43-1f7e0c2a9b11")
+
+	gotTime, gotID, err := cursor.Decode(c)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(gotTime), "microseconds must survive the round trip")
+	assert.Equal(t, "2d0f1c8e-5f5e-4b7a-9d43-1f7e0c2a9b11", gotID)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	for _, c := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, _, err := cursor.Decode(c)
+		assert.ErrorIs(t, err, cursor.ErrInvalid, c)
+	}
+}
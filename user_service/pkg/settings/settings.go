@@ -0,0 +1,109 @@
+package settings
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+	us "user_service/genproto/user_service"
+)
+
+var (
+	localeRegex = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+	postStatuses = map[string]bool{
+		"draft":     true,
+		"published": true,
+	}
+
+	profileVisibilities = map[string]bool{
+		"public":    true,
+		"followers": true,
+		"private":   true,
+	}
+)
+
+// Default returns the settings every user starts with until they change something.
+func Default(userID string) *us.UserSettings {
+	return &us.UserSettings{
+		UserId:            userID,
+		Locale:            "en",
+		Timezone:          "UTC",
+		DefaultPostStatus: "draft",
+		Privacy: &us.PrivacySettings{
+			ProfileVisibility: "public",
+			ShowEmail:         false,
+			AllowMentions:     true,
+		},
+		Notifications: &us.NotificationSettings{
+			EmailEnabled: true,
+			OnComment:    true,
+			OnMention:    true,
+			OnReaction:   false,
+			OnFollow:     true,
+		},
+	}
+}
+
+// Apply copies every field that is set in the patch onto the current settings.
+func Apply(current *us.UserSettings, patch *us.UpdateUserSettingsRequest) {
+	if patch.Locale != nil {
+		current.Locale = patch.GetLocale()
+	}
+	if patch.Timezone != nil {
+		current.Timezone = patch.GetTimezone()
+	}
+	if patch.DefaultPostStatus != nil {
+		current.DefaultPostStatus = patch.GetDefaultPostStatus()
+	}
+
+	if p := patch.Privacy; p != nil {
+		if p.ProfileVisibility != nil {
+			current.Privacy.ProfileVisibility = p.GetProfileVisibility()
+		}
+		if p.ShowEmail != nil {
+			current.Privacy.ShowEmail = p.GetShowEmail()
+		}
+		if p.AllowMentions != nil {
+			current.Privacy.AllowMentions = p.GetAllowMentions()
+		}
+	}
+
+	if n := patch.Notifications; n != nil {
+		if n.EmailEnabled != nil {
+			current.Notifications.EmailEnabled = n.GetEmailEnabled()
+		}
+		if n.OnComment != nil {
+			current.Notifications.OnComment = n.GetOnComment()
+		}
+		if n.OnMention != nil {
+			current.Notifications.OnMention = n.GetOnMention()
+		}
+		if n.OnReaction != nil {
+			current.Notifications.OnReaction = n.GetOnReaction()
+		}
+		if n.OnFollow != nil {
+			current.Notifications.OnFollow = n.GetOnFollow()
+		}
+	}
+}
+
+// Validate checks the settings against the schema enforced by the user_settings table.
+func Validate(s *us.UserSettings) error {
+	if !localeRegex.MatchString(s.Locale) {
+		return fmt.Errorf("invalid locale %q, expected a format like en or en-US", s.Locale)
+	}
+
+	if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "" {
+		return fmt.Errorf("invalid timezone %q", s.Timezone)
+	}
+
+	if !postStatuses[s.DefaultPostStatus] {
+		return fmt.Errorf("invalid default_post_status %q, expected draft or published", s.DefaultPostStatus)
+	}
+
+	if !profileVisibilities[s.GetPrivacy().GetProfileVisibility()] {
+		return fmt.Errorf("invalid profile_visibility %q, expected public, followers or private", s.GetPrivacy().GetProfileVisibility())
+	}
+
+	return nil
+}
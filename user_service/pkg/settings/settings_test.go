@@ -0,0 +1,52 @@
+package settings_test
+
+import (
+	"testing"
+	"user_service/genproto/user_service"
+	"user_service/pkg/settings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestApply_OnlyChangesSetFields(t *testing.T) {
+	current := settings.Default("9e129b9e-795e-4942-9d7d-639ccc92953d")
+
+	settings.Apply(current, &user_service.UpdateUserSettingsRequest{
+		Timezone: proto.String("Asia/Tashkent"),
+		Privacy: &user_service.PrivacySettingsPatch{
+			ShowEmail: proto.Bool(true),
+		},
+		Notifications: &user_service.NotificationSettingsPatch{
+			OnComment: proto.Bool(false),
+		},
+	})
+
+	assert.Equal(t, "Asia/Tashkent", current.Timezone)
+	assert.Equal(t, "en", current.Locale)
+	assert.Equal(t, "draft", current.DefaultPostStatus)
+	assert.True(t, current.Privacy.ShowEmail)
+	assert.True(t, current.Privacy.AllowMentions)
+	assert.False(t, current.Notifications.OnComment)
+	assert.True(t, current.Notifications.OnMention)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, settings.Validate(settings.Default("9e129b9e-795e-4942-9d7d-639ccc92953d")))
+
+	cases := map[string]*user_service.UpdateUserSettingsRequest{
+		"locale":              {Locale: proto.String("english")},
+		"timezone":            {Timezone: proto.String("Mars/Olympus")},
+		"default_post_status": {DefaultPostStatus: proto.String("archived")},
+		"profile_visibility":  {Privacy: &user_service.PrivacySettingsPatch{ProfileVisibility: proto.String("friends")}},
+	}
+
+	for name, patch := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := settings.Default("9e129b9e-795e-4942-9d7d-639ccc92953d")
+			settings.Apply(s, patch)
+			assert.Error(t, settings.Validate(s))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	us "user_service/genproto/user_service"
+	"user_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type AuditEventRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditEventRepo(db *pgxpool.Pool) storage.AuditEventRepoI {
+	return &AuditEventRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.AuditEventRepoI.
+func (s *AuditEventRepo) Create(ctx context.Context, req *us.AuditEvent) error {
+	changes, err := json.Marshal(req.Changes)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(ctx, `
+		INSERT INTO audit_events (
+			id,
+			actor_id,
+			actor_role,
+			session_id,
+			client_ip,
+			service,
+			method,
+			resource,
+			target_id,
+			status,
+			changes
+		) VALUES (
+			$1, NULLIF($2, '')::uuid, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11
+		)`,
+		uuid.NewString(),
+		req.ActorId,
+		req.ActorRole,
+		req.SessionId,
+		req.ClientIp,
+		req.Service,
+		req.Method,
+		req.Resource,
+		req.TargetId,
+		req.Status,
+		changes,
+	)
+
+	if err != nil {
+		log.Println("error while creating audit event", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetList implements storage.AuditEventRepoI.
+func (s *AuditEventRepo) GetList(ctx context.Context, req *us.GetListAuditEventRequest) (*us.GetListAuditEventResponse, error) {
+	var (
+		resp       = &us.GetListAuditEventResponse{}
+		filter     = " WHERE TRUE"
+		args       []interface{}
+		created_at time.Time
+	)
+	offset := (req.Page - 1) * req.Limit
+
+	if req.ActorId != "" {
+		args = append(args, req.ActorId)
+		filter += fmt.Sprintf(" AND actor_id = $%d", len(args))
+	}
+
+	if req.Resource != "" {
+		args = append(args, req.Resource)
+		filter += fmt.Sprintf(" AND resource = $%d", len(args))
+	}
+
+	if req.TargetId != "" {
+		args = append(args, req.TargetId)
+		filter += fmt.Sprintf(" AND target_id = $%d", len(args))
+	}
+
+	if req.Method != "" {
+		args = append(args, req.Method)
+		filter += fmt.Sprintf(" AND method = $%d", len(args))
+	}
+
+	if req.From != "" {
+		args = append(args, req.From)
+		filter += fmt.Sprintf(" AND created_at >= $%d", len(args))
+	}
+
+	if req.To != "" {
+		args = append(args, req.To)
+		filter += fmt.Sprintf(" AND created_at < $%d", len(args))
+	}
+
+	err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM audit_events`+filter, args...).Scan(&resp.Count)
+	if err != nil {
+		log.Println("error while counting audit events:", err)
+		return nil, err
+	}
+
+	filter += fmt.Sprintf(" ORDER BY created_at DESC OFFSET %v LIMIT %v", offset, req.Limit)
+
+	rows, err := s.db.Query(ctx, `
+		SELECT
+			id,
+			COALESCE(actor_id::text, ''),
+			actor_role,
+			COALESCE(session_id::text, ''),
+			client_ip,
+			service,
+			method,
+			resource,
+			target_id,
+			status,
+			changes,
+			created_at
+		FROM audit_events
+	`+filter, args...)
+	if err != nil {
+		log.Println("error while getting audit events:", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			event   us.AuditEvent
+			changes []byte
+		)
+
+		err = rows.Scan(
+			&event.Id,
+			&event.ActorId,
+			&event.ActorRole,
+			&event.SessionId,
+			&event.ClientIp,
+			&event.Service,
+			&event.Method,
+			&event.Resource,
+			&event.TargetId,
+			&event.Status,
+			&changes,
+			&created_at,
+		)
+		if err != nil {
+			log.Println("error while scanning audit events:", err)
+			return nil, err
+		}
+
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			log.Println("error unmarshalling audit changes:", err)
+			return nil, err
+		}
+		event.CreatedAt = created_at.Format(time.RFC3339)
+
+		resp.Events = append(resp.Events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
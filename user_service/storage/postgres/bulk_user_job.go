@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	us "user_service/genproto/user_service"
+	"user_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type BulkUserJobRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBulkUserJobRepo(db *pgxpool.Pool) storage.BulkUserJobRepoI {
+	return &BulkUserJobRepo{
+		db: db,
+	}
+}
+
+const bulkUserJobColumns = `
+	id,
+	action,
+	COALESCE(role::text, ''),
+	filter,
+	status,
+	total,
+	processed,
+	succeeded,
+	failed,
+	error,
+	requested_by,
+	created_at,
+	updated_at,
+	finished_at`
+
+func scanBulkUserJob(row pgx.Row) (*us.BulkUserJob, error) {
+	var (
+		job                    = &us.BulkUserJob{}
+		filter                 []byte
+		created_at, updated_at time.Time
+		finished_at            sql.NullTime
+	)
+
+	err := row.Scan(
+		&job.Id,
+		&job.Action,
+		&job.Role,
+		&filter,
+		&job.Status,
+		&job.Total,
+		&job.Processed,
+		&job.Succeeded,
+		&job.Failed,
+		&job.Error,
+		&job.RequestedBy,
+		&created_at,
+		&updated_at,
+		&finished_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(filter, &job.Filter); err != nil {
+		return nil, err
+	}
+
+	job.CreatedAt = created_at.Format(time.RFC3339)
+	job.UpdatedAt = updated_at.Format(time.RFC3339)
+	if finished_at.Valid {
+		job.FinishedAt = finished_at.Time.Format(time.RFC3339)
+	}
+
+	return job, nil
+}
+
+// Create implements storage.BulkUserJobRepoI.
+func (s *BulkUserJobRepo) Create(ctx context.Context, req *us.BulkUserJob, lease time.Duration) (*us.BulkUserJob, error) {
+	id := uuid.NewString()
+
+	filter, err := json.Marshal(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	role := sql.NullString{String: req.Role, Valid: req.Role != ""}
+
+	_, err = s.db.Exec(ctx, `
+		INSERT INTO bulk_user_job (
+			id,
+			action,
+			role,
+			filter,
+			total,
+			requested_by,
+			lease_expires_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, NOW() + make_interval(secs => $7)
+		)`, id, req.Action, role, filter, req.Total, req.RequestedBy, lease.Seconds())
+
+	if err != nil {
+		log.Println("error while creating bulk user job", err)
+		return nil, err
+	}
+
+	job, err := s.GetSingle(ctx, &us.BulkUserJobSingleRequest{Id: id})
+	if err != nil {
+		log.Println("error while getting bulk user job after creating", err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetSingle implements storage.BulkUserJobRepoI.
+func (s *BulkUserJobRepo) GetSingle(ctx context.Context, req *us.BulkUserJobSingleRequest) (*us.BulkUserJob, error) {
+	job, err := scanBulkUserJob(s.db.QueryRow(ctx, `SELECT`+bulkUserJobColumns+` FROM bulk_user_job WHERE id = $1`, req.Id))
+	if err != nil {
+		log.Println("error while getting bulk user job", err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetList implements storage.BulkUserJobRepoI.
+func (s *BulkUserJobRepo) GetList(ctx context.Context, req *us.GetListBulkUserJobRequest) (*us.GetListBulkUserJobResponse, error) {
+	var (
+		resp   = &us.GetListBulkUserJobResponse{}
+		filter = " WHERE TRUE"
+		args   []interface{}
+	)
+	offset := (req.Page - 1) * req.Limit
+
+	if req.Status != "" {
+		args = append(args, req.Status)
+		filter += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM bulk_user_job`+filter, args...).Scan(&resp.Count)
+	if err != nil {
+		log.Println("error while counting bulk user jobs:", err)
+		return nil, err
+	}
+
+	filter += fmt.Sprintf(" ORDER BY created_at DESC OFFSET %v LIMIT %v", offset, req.Limit)
+
+	rows, err := s.db.Query(ctx, `SELECT`+bulkUserJobColumns+` FROM bulk_user_job`+filter, args...)
+	if err != nil {
+		log.Println("error while getting bulk user jobs:", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		job, err := scanBulkUserJob(rows)
+		if err != nil {
+			log.Println("error while scanning bulk user jobs:", err)
+			return nil, err
+		}
+
+		resp.Jobs = append(resp.Jobs, job)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Update implements storage.BulkUserJobRepoI.
+// Only the status and progress of a job can change after it is created.
+func (s *BulkUserJobRepo) Update(ctx context.Context, req *us.BulkUserJob) (*us.BulkUserJob, error) {
+	_, err := s.db.Exec(ctx, `
+		UPDATE bulk_user_job SET
+			status = $1,
+			processed = $2,
+			succeeded = $3,
+			failed = $4,
+			error = $5,
+			finished_at = CASE WHEN $1 IN ('completed', 'failed') THEN NOW() ELSE finished_at END,
+			updated_at = NOW()
+		WHERE id = $6`, req.Status, req.Processed, req.Succeeded, req.Failed, req.Error, req.Id)
+
+	if err != nil {
+		log.Println("error while updating bulk user job", err)
+		return nil, err
+	}
+
+	return s.GetSingle(ctx, &us.BulkUserJobSingleRequest{Id: req.Id})
+}
+
+// Renew implements storage.BulkUserJobRepoI.
+func (s *BulkUserJobRepo) Renew(ctx context.Context, id string, lease time.Duration) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE bulk_user_job SET
+			lease_expires_at = NOW() + make_interval(secs => $2)
+		WHERE id = $1 AND status IN ('pending', 'running')`, id, lease.Seconds())
+
+	if err != nil {
+		log.Println("error while renewing bulk user job lease", err)
+	}
+
+	return err
+}
+
+// FailExpired implements storage.BulkUserJobRepoI.
+// A job runs in the replica that created it and renews its lease while it runs, so a pending or
+// running job whose lease ran out was stopped with its replica. Its users are left as they were
+// after the last batch.
+func (s *BulkUserJobRepo) FailExpired(ctx context.Context) (int64, error) {
+	tag, err := s.db.Exec(ctx, `
+		UPDATE bulk_user_job SET
+			status = 'failed',
+			error = 'interrupted by a restart of the service',
+			finished_at = NOW(),
+			updated_at = NOW()
+		WHERE status IN ('pending', 'running') AND lease_expires_at < NOW()`)
+
+	if err != nil {
+		log.Println("error while failing interrupted bulk user jobs", err)
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	us "user_service/genproto/user_service"
+	"user_service/pkg/cursor"
+	"user_service/storage"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type FollowRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewFollowRepo(db *pgxpool.Pool) storage.FollowRepoI {
+	return &FollowRepo{
+		db: db,
+	}
+}
+
+// Create implements storage.FollowRepoI.
+// Following twice is not an error, false is returned when the follow already existed.
+func (s *FollowRepo) Create(ctx context.Context, req *us.Follow) (bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO follows (
+			follower_id,
+			followee_id
+		) VALUES (
+			$1, $2
+		) ON CONFLICT DO NOTHING`, req.FollowerId, req.FolloweeId)
+
+	if err != nil {
+		log.Println("error while creating follow", err)
+		return false, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := addFollowCounts(ctx, tx, req, 1); err != nil {
+		log.Println("error while updating follow counts", err)
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// Delete implements storage.FollowRepoI.
+// false is returned when there was nothing to delete.
+func (s *FollowRepo) Delete(ctx context.Context, req *us.Follow) (bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM follows
+		WHERE follower_id = $1 AND followee_id = $2`, req.FollowerId, req.FolloweeId)
+
+	if err != nil {
+		log.Println("error while deleting follow", err)
+		return false, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err := addFollowCounts(ctx, tx, req, -1); err != nil {
+		log.Println("error while updating follow counts", err)
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// GetList implements storage.FollowRepoI.
+// The list is ordered by the time of the follow, newest first, with keyset pagination.
+func (s *FollowRepo) GetList(ctx context.Context, req *us.GetListFollowRequest) (*us.FollowList, error) {
+	var (
+		resp = &us.FollowList{}
+		args = []interface{}{req.UserId}
+		// self is the column of the requested user, other is the column of the listed users
+		self, other = "followee_id", "follower_id"
+	)
+
+	if req.Direction == "following" {
+		self, other = other, self
+	}
+
+	filter := fmt.Sprintf(" WHERE f.%s = $1", self)
+
+	if req.Cursor != "" {
+		createdAt, id, err := cursor.Decode(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, createdAt, id)
+		filter += fmt.Sprintf(" AND (f.created_at, f.%s) < ($2, $3)", other)
+	}
+
+	// one extra row tells whether there is a next page
+	filter += fmt.Sprintf(" ORDER BY f.created_at DESC, f.%s DESC LIMIT %d", other, req.Limit+1)
+
+	rows, err := s.db.Query(ctx, fmt.Sprintf(`
+		SELECT
+			u.id,
+			u.follower_count,
+			f.created_at
+		FROM follows f
+		JOIN users u ON u.id = f.%s`, other)+filter, args...)
+	if err != nil {
+		log.Println("error while getting follows:", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var lastCreatedAt time.Time
+	for rows.Next() {
+		var (
+			user       us.FollowUser
+			created_at time.Time
+		)
+
+		if err = rows.Scan(&user.UserId, &user.FollowerCount, &created_at); err != nil {
+			log.Println("error while scanning follows:", err)
+			return nil, err
+		}
+
+		if uint64(len(resp.Items)) == req.Limit {
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Items[len(resp.Items)-1].UserId)
+			break
+		}
+
+		user.FollowedAt = created_at.Format(time.RFC3339)
+		resp.Items = append(resp.Items, &user)
+		lastCreatedAt = created_at
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Println("rows iteration error:", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetStats implements storage.FollowRepoI.
+func (s *FollowRepo) GetStats(ctx context.Context, req *us.FollowStatsRequest) (*us.FollowStats, error) {
+	resp := &us.FollowStats{}
+
+	err := s.db.QueryRow(ctx, `
+		SELECT
+			id,
+			follower_count,
+			following_count,
+			EXISTS (SELECT 1 FROM follows WHERE follower_id = NULLIF($2, '')::uuid AND followee_id = users.id)
+		FROM users
+		WHERE id = $1`, req.UserId, req.ViewerId).Scan(&resp.UserId, &resp.FollowerCount, &resp.FollowingCount, &resp.FollowedByViewer)
+
+	if err != nil {
+		log.Println("error while getting follow stats", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// addFollowCounts updates both users in one statement, so concurrent follows in both directions
+// lock the rows in the same order.
+func addFollowCounts(ctx context.Context, tx pgx.Tx, req *us.Follow, delta int) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE users SET
+			follower_count = GREATEST(follower_count + CASE WHEN id = $1 THEN $3::int ELSE 0 END, 0),
+			following_count = GREATEST(following_count + CASE WHEN id = $2 THEN $3::int ELSE 0 END, 0)
+		WHERE id IN ($1, $2)`, req.FolloweeId, req.FollowerId, delta)
+	return err
+}
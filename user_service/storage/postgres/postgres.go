@@ -12,9 +12,13 @@ import (
 )
 
 type Store struct {
-	db      *pgxpool.Pool
-	user    storage.UserRepoI
-	session storage.SessionRepoI
+	db       *pgxpool.Pool
+	user     storage.UserRepoI
+	session  storage.SessionRepoI
+	settings storage.UserSettingsRepoI
+	bulkJob  storage.BulkUserJobRepoI
+	audit    storage.AuditEventRepoI
+	follow   storage.FollowRepoI
 }
 
 func NewPostgres(ctx context.Context, cfg config.Config) (storage.StorageI, error) {
@@ -72,3 +76,39 @@ func (s *Store) Session() storage.SessionRepoI {
 
 	return s.session
 }
+
+// UserSettings implements storage.StorageI.
+func (s *Store) UserSettings() storage.UserSettingsRepoI {
+	if s.settings == nil {
+		s.settings = NewUserSettingsRepo(s.db)
+	}
+
+	return s.settings
+}
+
+// BulkUserJob implements storage.StorageI.
+func (s *Store) BulkUserJob() storage.BulkUserJobRepoI {
+	if s.bulkJob == nil {
+		s.bulkJob = NewBulkUserJobRepo(s.db)
+	}
+
+	return s.bulkJob
+}
+
+// AuditEvent implements storage.StorageI.
+func (s *Store) AuditEvent() storage.AuditEventRepoI {
+	if s.audit == nil {
+		s.audit = NewAuditEventRepo(s.db)
+	}
+
+	return s.audit
+}
+
+// Follow implements storage.StorageI.
+func (s *Store) Follow() storage.FollowRepoI {
+	if s.follow == nil {
+		s.follow = NewFollowRepo(s.db)
+	}
+
+	return s.follow
+}
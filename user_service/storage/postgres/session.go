@@ -8,6 +8,7 @@ import (
 	"time"
 
 	us "user_service/genproto/user_service"
+	"user_service/pkg/cursor"
 	"user_service/storage"
 
 	"github.com/google/uuid"
@@ -112,18 +113,29 @@ func (s *SessionRepo) GetSingle(ctx context.Context, req *us.SessionSingleReques
 
 // GetList implements storage.SessionRepoI.
 func (s *SessionRepo) GetList(ctx context.Context, req *us.GetListSessionRequest) (*us.GetListSessionResponse, error) {
-	resp := &us.GetListSessionResponse{}
 	var (
-		filter                                             string
-		created_at, updated_at, last_active_at, expires_at time.Time
+		resp   = &us.GetListSessionResponse{}
+		args   []interface{}
+		filter = " WHERE TRUE"
 	)
-	offset := (req.Page - 1) * req.Limit
 
 	if req.Search != "" {
-		filter = fmt.Sprintf(` WHERE user_id = '%s'`, req.Search)
+		args = append(args, req.Search)
+		filter += fmt.Sprintf(" AND user_id = $%d", len(args))
+	}
+
+	if req.WithTotal {
+		err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM session`+filter, args...).Scan(&resp.Count)
+		if err != nil {
+			log.Println("error while counting sessions:", err)
+			return nil, err
+		}
 	}
 
-	filter += fmt.Sprintf(" OFFSET %v LIMIT %v", offset, req.Limit)
+	filter, args, err := page{cursor: req.Cursor, number: req.Page, limit: req.Limit}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
 
 	query := `
         SELECT
@@ -140,7 +152,7 @@ func (s *SessionRepo) GetList(ctx context.Context, req *us.GetListSessionRequest
         FROM session
     ` + filter
 
-	rows, err := s.db.Query(ctx, query)
+	rows, err := s.db.Query(ctx, query, args...)
 
 	if err != nil {
 		log.Println("error while getting all sessions:", err)
@@ -149,11 +161,13 @@ func (s *SessionRepo) GetList(ctx context.Context, req *us.GetListSessionRequest
 
 	defer rows.Close()
 
-	var count int64
-
+	var lastCreatedAt time.Time
 	for rows.Next() {
-		var user us.Session
-		count++
+		var (
+			user                                               us.Session
+			created_at, updated_at, last_active_at, expires_at time.Time
+		)
+
 		err = rows.Scan(
 			&user.Id,
 			&user.UserId,
@@ -171,12 +185,20 @@ func (s *SessionRepo) GetList(ctx context.Context, req *us.GetListSessionRequest
 			log.Println("error while scanning users:", err)
 			return nil, err
 		}
+
+		if uint64(len(resp.Sessions)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Sessions[len(resp.Sessions)-1].Id)
+			break
+		}
+
 		user.CreatedAt = created_at.Format(time.RFC3339)
 		user.UpdatedAt = updated_at.Format(time.RFC3339)
 		user.LastActiveAt = last_active_at.Format(time.RFC3339)
 		user.ExpiresAt = expires_at.Format(time.RFC3339)
 
 		resp.Sessions = append(resp.Sessions, &user)
+		lastCreatedAt = created_at
 	}
 
 	if err = rows.Err(); err != nil {
@@ -184,8 +206,6 @@ func (s *SessionRepo) GetList(ctx context.Context, req *us.GetListSessionRequest
 		return nil, err
 	}
 
-	resp.Count = count
-
 	return resp, nil
 }
 
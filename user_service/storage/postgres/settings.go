@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"time"
+
+	us "user_service/genproto/user_service"
+	"user_service/storage"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type UserSettingsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewUserSettingsRepo(db *pgxpool.Pool) storage.UserSettingsRepoI {
+	return &UserSettingsRepo{
+		db: db,
+	}
+}
+
+// GetSingle implements storage.UserSettingsRepoI.
+func (s *UserSettingsRepo) GetSingle(ctx context.Context, req *us.UserSettingsSingleRequest) (*us.UserSettings, error) {
+	resp := &us.UserSettings{
+		Privacy:       &us.PrivacySettings{},
+		Notifications: &us.NotificationSettings{},
+	}
+
+	var (
+		created_at, updated_at time.Time
+	)
+
+	err := s.db.QueryRow(ctx, `
+		SELECT
+			user_id,
+			locale,
+			timezone,
+			default_post_status,
+			profile_visibility,
+			show_email,
+			allow_mentions,
+			email_notifications,
+			notify_on_comment,
+			notify_on_mention,
+			notify_on_reaction,
+			notify_on_follow,
+			created_at,
+			updated_at
+		FROM user_settings
+		WHERE user_id = $1`, req.UserId).Scan(
+		&resp.UserId,
+		&resp.Locale,
+		&resp.Timezone,
+		&resp.DefaultPostStatus,
+		&resp.Privacy.ProfileVisibility,
+		&resp.Privacy.ShowEmail,
+		&resp.Privacy.AllowMentions,
+		&resp.Notifications.EmailEnabled,
+		&resp.Notifications.OnComment,
+		&resp.Notifications.OnMention,
+		&resp.Notifications.OnReaction,
+		&resp.Notifications.OnFollow,
+		&created_at,
+		&updated_at,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.CreatedAt = created_at.Format(time.RFC3339)
+	resp.UpdatedAt = updated_at.Format(time.RFC3339)
+
+	return resp, nil
+}
+
+// Upsert implements storage.UserSettingsRepoI.
+func (s *UserSettingsRepo) Upsert(ctx context.Context, req *us.UserSettings) (*us.UserSettings, error) {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO user_settings (
+			user_id,
+			locale,
+			timezone,
+			default_post_status,
+			profile_visibility,
+			show_email,
+			allow_mentions,
+			email_notifications,
+			notify_on_comment,
+			notify_on_mention,
+			notify_on_reaction,
+			notify_on_follow
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			locale = EXCLUDED.locale,
+			timezone = EXCLUDED.timezone,
+			default_post_status = EXCLUDED.default_post_status,
+			profile_visibility = EXCLUDED.profile_visibility,
+			show_email = EXCLUDED.show_email,
+			allow_mentions = EXCLUDED.allow_mentions,
+			email_notifications = EXCLUDED.email_notifications,
+			notify_on_comment = EXCLUDED.notify_on_comment,
+			notify_on_mention = EXCLUDED.notify_on_mention,
+			notify_on_reaction = EXCLUDED.notify_on_reaction,
+			notify_on_follow = EXCLUDED.notify_on_follow,
+			updated_at = NOW()`,
+		req.UserId,
+		req.Locale,
+		req.Timezone,
+		req.DefaultPostStatus,
+		req.GetPrivacy().GetProfileVisibility(),
+		req.GetPrivacy().GetShowEmail(),
+		req.GetPrivacy().GetAllowMentions(),
+		req.GetNotifications().GetEmailEnabled(),
+		req.GetNotifications().GetOnComment(),
+		req.GetNotifications().GetOnMention(),
+		req.GetNotifications().GetOnReaction(),
+		req.GetNotifications().GetOnFollow(),
+	)
+
+	if err != nil {
+		log.Println("error while upserting user settings", err)
+		return nil, err
+	}
+
+	settings, err := s.GetSingle(ctx, &us.UserSettingsSingleRequest{UserId: req.UserId})
+	if err != nil {
+		log.Println("error while getting user settings after upserting", err)
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"time"
 	us "user_service/genproto/user_service"
+	"user_service/pkg/cursor"
 	"user_service/storage"
 
 	"github.com/google/uuid"
@@ -139,18 +140,29 @@ func (s *UserRepo) GetSingle(ctx context.Context, req *us.UserSingleRequest) (*u
 
 // GetList implements storage.UserRepoI.
 func (s *UserRepo) GetList(ctx context.Context, req *us.GetListUserRequest) (*us.GetListUserResponse, error) {
-	resp := &us.GetListUserResponse{}
 	var (
-		filter                 = " WHERE TRUE"
-		created_at, updated_at time.Time
+		resp   = &us.GetListUserResponse{}
+		args   []interface{}
+		filter = " WHERE TRUE"
 	)
-	offset := (req.Page - 1) * req.Limit
 
 	if req.Search != "" {
-		filter += fmt.Sprintf(` AND (user_name ILIKE '%%%v%%' OR full_name ILIKE '%%%v%%' OR email ILIKE '%%%v%%')`, req.Search, req.Search, req.Search)
+		args = append(args, "%"+req.Search+"%")
+		filter += fmt.Sprintf(" AND (user_name ILIKE $%[1]d OR full_name ILIKE $%[1]d OR email ILIKE $%[1]d)", len(args))
+	}
+
+	if req.WithTotal {
+		err := s.db.QueryRow(ctx, `SELECT COUNT(*) FROM users`+filter, args...).Scan(&resp.Count)
+		if err != nil {
+			log.Println("error while counting users:", err)
+			return nil, err
+		}
 	}
 
-	filter += fmt.Sprintf(" OFFSET %v LIMIT %v", offset, req.Limit)
+	filter, args, err := page{cursor: req.Cursor, number: req.Page, limit: req.Limit}.apply(filter, args)
+	if err != nil {
+		return nil, err
+	}
 
 	rows, err := s.db.Query(ctx, `
 		SELECT
@@ -165,7 +177,7 @@ func (s *UserRepo) GetList(ctx context.Context, req *us.GetListUserRequest) (*us
 			created_at,
 			updated_at
 		FROM users
-	`+filter)
+	`+filter, args...)
 
 	if err != nil {
 		log.Println("error while getting all users:", err)
@@ -174,21 +186,30 @@ func (s *UserRepo) GetList(ctx context.Context, req *us.GetListUserRequest) (*us
 
 	defer rows.Close()
 
-	var count int64
-
+	var lastCreatedAt time.Time
 	for rows.Next() {
-		var user us.User
-		count++
-		err = rows.Scan(&user.Id, &user.UserType, &user.UserRole, &user.FullName, &user.UserName, &user.Email, &user.Gender, &user.Status, &created_at, &updated_at)
+		var (
+			user                   us.User
+			created_at, updated_at time.Time
+		)
 
+		err = rows.Scan(&user.Id, &user.UserType, &user.UserRole, &user.FullName, &user.UserName, &user.Email, &user.Gender, &user.Status, &created_at, &updated_at)
 		if err != nil {
 			log.Println("error while scanning users:", err)
 			return nil, err
 		}
+
+		if uint64(len(resp.Users)) == req.Limit {
+			resp.HasMore = true
+			resp.NextCursor = cursor.Encode(lastCreatedAt, resp.Users[len(resp.Users)-1].Id)
+			break
+		}
+
 		user.CreatedAt = created_at.Format(time.RFC3339)
 		user.UpdatedAt = updated_at.Format(time.RFC3339)
 
 		resp.Users = append(resp.Users, &user)
+		lastCreatedAt = created_at
 	}
 
 	if err = rows.Err(); err != nil {
@@ -196,8 +217,6 @@ func (s *UserRepo) GetList(ctx context.Context, req *us.GetListUserRequest) (*us
 		return nil, err
 	}
 
-	resp.Count = count
-
 	return resp, nil
 }
 
@@ -242,3 +261,151 @@ func (s *UserRepo) Delete(ctx context.Context, req *us.UserPrimaryKey) (*emptypb
 
 	return &emptypb.Empty{}, nil
 }
+
+// GetIDs implements storage.UserRepoI.
+func (s *UserRepo) GetIDs(ctx context.Context, req *us.BulkUserFilter) ([]string, error) {
+	var (
+		filter = " WHERE TRUE"
+		args   []interface{}
+		ids    []string
+	)
+
+	if len(req.Ids) > 0 {
+		args = append(args, req.Ids)
+		filter += fmt.Sprintf(" AND id = ANY($%d)", len(args))
+	}
+
+	if req.Search != "" {
+		args = append(args, "%"+req.Search+"%")
+		filter += fmt.Sprintf(" AND (user_name ILIKE $%d OR full_name ILIKE $%d OR email ILIKE $%d)", len(args), len(args), len(args))
+	}
+
+	if req.Status != "" {
+		args = append(args, req.Status)
+		filter += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	if req.CreatedFrom != "" {
+		args = append(args, req.CreatedFrom)
+		filter += fmt.Sprintf(" AND created_at >= $%d", len(args))
+	}
+
+	if req.CreatedTo != "" {
+		args = append(args, req.CreatedTo)
+		filter += fmt.Sprintf(" AND created_at < $%d", len(args))
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT id FROM users`+filter+` ORDER BY created_at, id`, args...)
+	if err != nil {
+		log.Println("error while getting user ids:", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			log.Println("error while scanning user ids:", err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetListByIDs implements storage.UserRepoI.
+func (s *UserRepo) GetListByIDs(ctx context.Context, ids []string) ([]*us.User, error) {
+	var (
+		users                  []*us.User
+		created_at, updated_at time.Time
+	)
+
+	rows, err := s.db.Query(ctx, `
+		SELECT
+			id,
+			user_type,
+			user_role,
+			full_name,
+			user_name,
+			email,
+			gender,
+			status,
+			created_at,
+			updated_at
+		FROM users
+		WHERE id = ANY($1)
+		ORDER BY created_at, id`, ids)
+	if err != nil {
+		log.Println("error while getting users by ids:", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var user us.User
+		err = rows.Scan(&user.Id, &user.UserType, &user.UserRole, &user.FullName, &user.UserName, &user.Email, &user.Gender, &user.Status, &created_at, &updated_at)
+		if err != nil {
+			log.Println("error while scanning users:", err)
+			return nil, err
+		}
+		user.CreatedAt = created_at.Format(time.RFC3339)
+		user.UpdatedAt = updated_at.Format(time.RFC3339)
+
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// BulkAction implements storage.UserRepoI.
+// It applies the action to the given users in one transaction and returns the number of affected users.
+func (s *UserRepo) BulkAction(ctx context.Context, req *us.BulkUserActionRequest, ids []string) (int64, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var query string
+	args := []interface{}{ids}
+
+	switch req.Action {
+	case "block":
+		query = `UPDATE users SET status = 'blocked', updated_at = NOW() WHERE id = ANY($1) AND status <> 'blocked'`
+	case "unblock":
+		query = `UPDATE users SET status = 'active', updated_at = NOW() WHERE id = ANY($1) AND status = 'blocked'`
+	case "verify":
+		query = `UPDATE users SET status = 'active', updated_at = NOW() WHERE id = ANY($1) AND status = 'inverify'`
+	case "change_role":
+		query = `UPDATE users SET user_role = $2, updated_at = NOW() WHERE id = ANY($1) AND user_role <> $2`
+		args = append(args, req.Role)
+	case "delete":
+		query = `DELETE FROM users WHERE id = ANY($1)`
+	default:
+		return 0, fmt.Errorf("unknown bulk action %q", req.Action)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		log.Println("error while applying bulk action to users:", err)
+		return 0, err
+	}
+
+	// blocked users must not keep their active sessions
+	if req.Action == "block" {
+		_, err = tx.Exec(ctx, `UPDATE session SET is_active = false, updated_at = NOW() WHERE user_id = ANY($1)`, ids)
+		if err != nil {
+			log.Println("error while deactivating sessions of blocked users:", err)
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 	us "user_service/genproto/user_service"
 
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -11,6 +12,10 @@ type StorageI interface {
 	CloseDB()
 	User() UserRepoI
 	Session() SessionRepoI
+	UserSettings() UserSettingsRepoI
+	BulkUserJob() BulkUserJobRepoI
+	AuditEvent() AuditEventRepoI
+	Follow() FollowRepoI
 }
 
 type (
@@ -20,6 +25,9 @@ type (
 		GetList(ctx context.Context, req *us.GetListUserRequest) (*us.GetListUserResponse, error)
 		Update(ctx context.Context, req *us.User) (*us.User, error)
 		Delete(ctx context.Context, req *us.UserPrimaryKey) (*emptypb.Empty, error)
+		GetIDs(ctx context.Context, req *us.BulkUserFilter) ([]string, error)
+		GetListByIDs(ctx context.Context, ids []string) ([]*us.User, error)
+		BulkAction(ctx context.Context, req *us.BulkUserActionRequest, ids []string) (int64, error)
 	}
 
 	SessionRepoI interface {
@@ -29,4 +37,35 @@ type (
 		Update(ctx context.Context, req *us.Session) (*us.Session, error)
 		Delete(ctx context.Context, req *us.SessionSingleRequest) (*emptypb.Empty, error)
 	}
+
+	UserSettingsRepoI interface {
+		GetSingle(ctx context.Context, req *us.UserSettingsSingleRequest) (*us.UserSettings, error)
+		Upsert(ctx context.Context, req *us.UserSettings) (*us.UserSettings, error)
+	}
+
+	BulkUserJobRepoI interface {
+		// Create stores a pending job leased to the caller for lease.
+		Create(ctx context.Context, req *us.BulkUserJob, lease time.Duration) (*us.BulkUserJob, error)
+		GetSingle(ctx context.Context, req *us.BulkUserJobSingleRequest) (*us.BulkUserJob, error)
+		GetList(ctx context.Context, req *us.GetListBulkUserJobRequest) (*us.GetListBulkUserJobResponse, error)
+		Update(ctx context.Context, req *us.BulkUserJob) (*us.BulkUserJob, error)
+		// Renew extends the lease of a job that is still pending or running to lease from now.
+		Renew(ctx context.Context, id string, lease time.Duration) error
+		// FailExpired marks the pending and running jobs whose lease ran out failed and returns how
+		// many it marked.
+		FailExpired(ctx context.Context) (int64, error)
+	}
+
+	FollowRepoI interface {
+		Create(ctx context.Context, req *us.Follow) (bool, error)
+		Delete(ctx context.Context, req *us.Follow) (bool, error)
+		GetList(ctx context.Context, req *us.GetListFollowRequest) (*us.FollowList, error)
+		GetStats(ctx context.Context, req *us.FollowStatsRequest) (*us.FollowStats, error)
+	}
+
+	// AuditEventRepoI is append-only, events can't be updated or deleted.
+	AuditEventRepoI interface {
+		Create(ctx context.Context, req *us.AuditEvent) error
+		GetList(ctx context.Context, req *us.GetListAuditEventRequest) (*us.GetListAuditEventResponse, error)
+	}
 )